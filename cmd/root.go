@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	apppkg "github.com/pantheon-systems/secrets-searcher/pkg/app"
 	"github.com/pantheon-systems/secrets-searcher/pkg/app/config"
 	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
 )
 
 const (
@@ -44,8 +48,13 @@ func execute() (passed bool, err error) {
 		return
 	}
 
+	// Interrupt the app on the first signal, exit on the second
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go handleSignals(cancel, app.Log())
+
 	// EXECUTE APP
-	passed, err = app.Execute()
+	passed, err = app.Execute(ctx)
 	if err != nil {
 		err = errors.WithMessage(err, "unable to execute app")
 		return
@@ -53,3 +62,16 @@ func execute() (passed bool, err error) {
 
 	return
 }
+
+// In-flight jobs finish the commit they're on after the first signal, so what was searched is kept
+func handleSignals(cancel context.CancelFunc, log logg.Logg) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	<-signals
+	log.Warn("Stopping the search, press Ctrl-C again to quit now")
+	cancel()
+
+	<-signals
+	os.Exit(exitCodeErr)
+}
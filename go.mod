@@ -7,18 +7,22 @@ require (
 	github.com/google/go-github/v29 v29.0.3
 	github.com/grantae/certinfo v0.0.0-20170412194111-59d56a35515b
 	github.com/hako/durafmt v0.0.0-20191009132224-3f39dc1ed9f4
+	github.com/magiconair/properties v1.8.1
 	github.com/mgutz/ansi v0.0.0-20170206155736-9520e82c474b // indirect
 	github.com/mitchellh/mapstructure v1.3.0
 	github.com/onsi/ginkgo v1.12.0
 	github.com/onsi/gomega v1.10.0
 	github.com/orcaman/concurrent-map v0.0.0-20190826125027-8c72a8bb44f6
 	github.com/otiai10/copy v1.1.1
+	github.com/pelletier/go-toml v1.2.0
 	github.com/pkg/errors v0.9.1
+	github.com/sergi/go-diff v1.0.0
 	github.com/sirsean/go-pool v0.0.0-20170808185629-2b94e61c3882
 	github.com/sirupsen/logrus v1.6.0
 	github.com/spf13/pflag v1.0.5
 	github.com/spf13/viper v1.7.0
 	github.com/stretchr/testify v1.5.1
+	github.com/subosito/gotenv v1.2.0
 	github.com/vbauerster/mpb/v5 v5.0.4
 	github.com/wlbr/templify v0.0.0-20190823200653-c12e62ca00c1 // indirect
 	github.com/x-cray/logrus-prefixed-formatter v0.5.2
@@ -27,6 +31,7 @@ require (
 	golang.org/x/sys v0.0.0-20220209214540-3681064d5158 // indirect
 	golang.org/x/tools v0.1.9 // indirect
 	gopkg.in/asaskevich/govalidator.v9 v9.0.0-20180315120708-ccb8e960c48f
+	gopkg.in/ini.v1 v1.51.0
 	gopkg.in/src-d/go-git.v4 v4.13.1
 	gopkg.in/yaml.v2 v2.2.8
 )
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"os"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	searchpkg "github.com/pantheon-systems/secrets-searcher/pkg/search"
 	sourcepkg "github.com/pantheon-systems/secrets-searcher/pkg/source"
 	"github.com/pantheon-systems/secrets-searcher/pkg/stats"
+	verifypkg "github.com/pantheon-systems/secrets-searcher/pkg/verify"
 )
 
 type App struct {
@@ -22,6 +24,7 @@ type App struct {
 	logFile         string
 	nonZero         bool
 	enableProfiling bool
+	resume          bool
 
 	enableSourcePhase bool
 	enableSearchPhase bool
@@ -34,6 +37,7 @@ type App struct {
 	source   *sourcepkg.Source
 	search   *searchpkg.Search
 	reporter *reporterpkg.Reporter
+	verify   *verifypkg.Verify
 	stats    *stats.Stats
 	db       *database.Database
 	log      logg.Logg
@@ -62,9 +66,11 @@ func New(appCfg *config.AppConfig) (a *App, err error) {
 		enableSearchPhase: params.EnableSearchPhase,
 		enableReportPhase: params.EnableReportPhase,
 		enableProfiling:   params.EnableProfiling,
+		resume:            params.Resume,
 		source:            params.Source,
 		search:            params.Search,
 		reporter:          params.Reporter,
+		verify:            params.Verify,
 		stats:             params.Stats,
 		db:                params.DB,
 		log:               params.AppLog,
@@ -73,7 +79,12 @@ func New(appCfg *config.AppConfig) (a *App, err error) {
 	return
 }
 
-func (a *App) Execute() (passed bool, err error) {
+func (a *App) Log() logg.Logg {
+	return a.log
+}
+
+// Cancelling the context interrupts the search, it can be resumed later
+func (a *App) Execute(ctx context.Context) (passed bool, err error) {
 	passed = true
 	a.stats.AppStartTime = time.Now()
 
@@ -112,9 +123,19 @@ func (a *App) Execute() (passed bool, err error) {
 	// Search phase
 	if a.enableSearchPhase {
 
-		// Reset search tables
-		if err = a.db.DeleteSearchTables(); err != nil {
-			err = errors.WithMessage(err, "unable to delete search tables")
+		// Reset search tables, unless an interrupted search is resumed or only new commits are searched
+		keepPrevious := a.resume
+		if !keepPrevious {
+			if keepPrevious, err = a.search.KeepsPreviousResults(); err != nil {
+				err = errors.WithMessage(err, "unable to check for previous search results")
+				return
+			}
+		}
+		if !keepPrevious {
+			if err = a.db.DeleteSearchTables(); err != nil {
+				err = errors.WithMessage(err, "unable to delete search tables")
+				return
+			}
 		}
 
 		// Pre reporting
@@ -130,11 +151,18 @@ func (a *App) Execute() (passed bool, err error) {
 		}
 
 		// Searching
-		if err = a.search.Search(); err != nil {
+		if err = a.search.Search(ctx); err != nil {
 			err = errors.WithMessage(err, "unable to execute search phase")
 			return
 		}
 		passed = !a.nonZero || a.stats.SecretsFoundCount == 0
+
+		// Verify found secrets
+		if err = a.verify.VerifySecrets(ctx); err != nil {
+			err = errors.WithMessage(err, "unable to verify secrets")
+			return
+		}
+
 		a.searchPhaseCompleted = true
 	}
 
@@ -197,6 +225,9 @@ func (a *App) printDoneMessage() {
 		a.log.Infof("- Commits searched:    %d", a.stats.CommitsSearchedCount)
 		a.log.Infof("- Duration per commit: %dms (%dns)",
 			commitDuration.Milliseconds(), commitDuration.Nanoseconds())
+		for _, skipCount := range a.stats.SkipCounts.Counts() {
+			a.log.Infof("- Skipped (%s): %d file changes", skipCount.Reason, skipCount.Count)
+		}
 	}
 }
 
@@ -16,6 +16,7 @@ import (
 	reporterpkg "github.com/pantheon-systems/secrets-searcher/pkg/reporter"
 	searchpkg "github.com/pantheon-systems/secrets-searcher/pkg/search"
 	sourcepkg "github.com/pantheon-systems/secrets-searcher/pkg/source"
+	verifypkg "github.com/pantheon-systems/secrets-searcher/pkg/verify"
 )
 
 type AppParams struct {
@@ -26,9 +27,11 @@ type AppParams struct {
 	EnableSearchPhase bool
 	EnableReportPhase bool
 	EnableProfiling   bool
+	Resume            bool
 	Source            *sourcepkg.Source
 	Search            *searchpkg.Search
 	Reporter          *reporterpkg.Reporter
+	Verify            *verifypkg.Verify
 	Stats             *statspkg.Stats
 	DB                *database.Database
 	AppLog            logg.Logg
@@ -64,6 +67,7 @@ func App(appCfg *config.AppConfig) (result *AppParams, err error) {
 	sourceLog := appLog.WithPrefix("source")
 	searchLog := appLog.WithPrefix("search")
 	reporterLog := appLog.WithPrefix("report")
+	verifyLog := appLog.WithPrefix("verify")
 
 	// Stats
 	stats := statspkg.New()
@@ -83,7 +87,7 @@ func App(appCfg *config.AppConfig) (result *AppParams, err error) {
 	secretIDFilter := SecretIDFilter(&appCfg.SearchConfig)
 
 	// Git service
-	git := gitpkg.New(gitLog)
+	git := gitpkg.New(Credentials(&appCfg.SourceConfig), gitLog)
 
 	// Interact service
 	interact := interactpkg.New(appCfg.Interactive, interactLog)
@@ -114,6 +118,7 @@ func App(appCfg *config.AppConfig) (result *AppParams, err error) {
 		commitFilter,
 		fileChangeFilter,
 		enableProfiling,
+		appCfg.Resume,
 		git,
 		interact,
 		stats,
@@ -123,13 +128,19 @@ func App(appCfg *config.AppConfig) (result *AppParams, err error) {
 		err = errors.WithMessage(err, "unable to build search")
 	}
 
+	// Verify service
+	verify := Verify(&appCfg.VerifyConfig, &appCfg.SearchConfig, db, verifyLog)
+
 	// Reporter service
 	reporter := Reporter(
 		&appCfg.ReporterConfig,
 		outputDir,
+		sourceDir,
 		vars.URL,
 		sourceProvider,
 		secretIDFilter,
+		ProcSeverities(&appCfg.SearchConfig),
+		git,
 		stats,
 		db,
 		reporterLog,
@@ -144,9 +155,11 @@ func App(appCfg *config.AppConfig) (result *AppParams, err error) {
 		EnableSearchPhase: appCfg.EnableSearchPhase,
 		EnableReportPhase: appCfg.EnableReportPhase,
 		EnableProfiling:   appCfg.EnableProfiling,
+		Resume:            appCfg.Resume,
 		Source:            source,
 		Search:            search,
 		Reporter:          reporter,
+		Verify:            verify,
 		Stats:             stats,
 		DB:                db,
 		AppLog:            appLog,
@@ -93,7 +93,15 @@ func FileChangeFilter(searchCfg *config.SearchConfig) (result *gitpkg.FileChange
 		excludeOnesWithNoCodeChanges = true
 	)
 
-	result = gitpkg.NewFileChangeFilter(pathFilter, excludeFileDeletions, excludeBinaryOrEmpty, excludeOnesWithNoCodeChanges)
+	// Binary files are still searched by processors that can read them
+	var binaryPathFilter *manip.RegexpFilter
+	if binaryPathMatchStrings := ProcBinaryPathMatchStrings(searchCfg); len(binaryPathMatchStrings) > 0 {
+		binaryPathFilter = manip.NewStringRegexpFilter(binaryPathMatchStrings, nil)
+	}
+
+	maxBlobSize := int64(searchCfg.MaxBlobSize)
+
+	result = gitpkg.NewFileChangeFilter(pathFilter, excludeFileDeletions, excludeBinaryOrEmpty, excludeOnesWithNoCodeChanges, binaryPathFilter, maxBlobSize)
 
 	return
 }
@@ -9,12 +9,14 @@ import (
 
 	"github.com/pantheon-systems/secrets-searcher/pkg/app/config"
 	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	gitpkg "github.com/pantheon-systems/secrets-searcher/pkg/git"
 	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
 	reporterpkg "github.com/pantheon-systems/secrets-searcher/pkg/reporter"
+	"github.com/pantheon-systems/secrets-searcher/pkg/risk"
 	"github.com/pantheon-systems/secrets-searcher/pkg/source"
 )
 
-func Reporter(reporterCfg *config.ReportConfig, outputDir, url string, sourceProvider source.ProviderI, secretIDFilter *manip.SliceFilter, stats *stats.Stats, db *database.Database, log logg.Logg) *reporterpkg.Reporter {
+func Reporter(reporterCfg *config.ReportConfig, outputDir, sourceDir, url string, sourceProvider source.ProviderI, secretIDFilter *manip.SliceFilter, procSeverities map[string]risk.Severity, git *gitpkg.Git, stats *stats.Stats, db *database.Database, log logg.Logg) *reporterpkg.Reporter {
 	reportDir := reporterCfg.ReportDir
 	if reportDir == "" {
 		reportDir = filepath.Join(outputDir, "report")
@@ -24,6 +26,8 @@ func Reporter(reporterCfg *config.ReportConfig, outputDir, url string, sourcePro
 		reportArchivesDir = filepath.Join(outputDir, "report-archive")
 	}
 
+	scorer := risk.NewScorer(reporterCfg.ProdPathMatch, nil)
+
 	return reporterpkg.New(
 		reportDir,
 		reportArchivesDir,
@@ -32,6 +36,10 @@ func Reporter(reporterCfg *config.ReportConfig, outputDir, url string, sourcePro
 		reporterCfg.EnablePreReports,
 		reporterCfg.PreReportInterval,
 		secretIDFilter,
+		scorer,
+		procSeverities,
+		sourceDir,
+		git,
 		sourceProvider,
 		stats,
 		db,
@@ -1,6 +1,10 @@
 package build
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 
 	"github.com/pantheon-systems/secrets-searcher/pkg/stats"
@@ -20,7 +24,7 @@ import (
 
 var SecretFileMatch = regexp.MustCompile(`^secret-([0-9a-f]{5,40}).yaml$`)
 
-func Search(searchCfg *config.SearchConfig, repoFilter *manip.SliceFilter, sourceDir string, commitFilter *gitpkg.CommitFilter, fileChangeFilter *gitpkg.FileChangeFilter, enableProfiling bool, git *gitpkg.Git, interact *interactpkg.Interact, stats *stats.Stats, db *database.Database, searchLog logg.Logg) (result *searchpkg.Search, err error) {
+func Search(searchCfg *config.SearchConfig, repoFilter *manip.SliceFilter, sourceDir string, commitFilter *gitpkg.CommitFilter, fileChangeFilter *gitpkg.FileChangeFilter, enableProfiling bool, resume bool, git *gitpkg.Git, interact *interactpkg.Interact, stats *stats.Stats, db *database.Database, searchLog logg.Logg) (result *searchpkg.Search, err error) {
 
 	// Build search targets
 	var targets *searchpkg.TargetSet
@@ -29,7 +33,7 @@ func Search(searchCfg *config.SearchConfig, repoFilter *manip.SliceFilter, sourc
 	// Processors
 	processorsLog := searchLog.AddPrefixPath("processor")
 	var processors []contract.ProcessorI
-	if processors, err = Procs(searchCfg, targets, processorsLog); err != nil {
+	if processors, err = Procs(searchCfg, targets, db, processorsLog); err != nil {
 		err = errors.WithMessage(err, "unable to build processors")
 		return
 	}
@@ -44,6 +48,21 @@ func Search(searchCfg *config.SearchConfig, repoFilter *manip.SliceFilter, sourc
 	workerLog := searchLog.AddPrefixPath("worker")
 	writerLog := searchLog.AddPrefixPath("db-result-writer")
 
+	// Ruleset version, for the blob cache and incremental searches
+	var rulesetVersion string
+	if searchCfg.BlobCache || searchCfg.Incremental {
+		if rulesetVersion, err = RulesetVersion(searchCfg); err != nil {
+			err = errors.WithMessage(err, "unable to build ruleset version")
+			return
+		}
+	}
+
+	// Scan marks
+	var scanMarks *searchpkg.ScanMarks
+	if searchCfg.Incremental {
+		scanMarks = searchpkg.NewScanMarks(rulesetVersion, db, searchLog.AddPrefixPath("scan-marks"))
+	}
+
 	// Search builder
 	jobBuilder := searchpkg.NewJobBuilder(
 		repoFilter,
@@ -53,7 +72,9 @@ func Search(searchCfg *config.SearchConfig, repoFilter *manip.SliceFilter, sourc
 		chunkSize,
 		showBarPerJob,
 		enableProfiling,
+		resume,
 		git,
+		scanMarks,
 		interact,
 		stats,
 		db,
@@ -63,17 +84,114 @@ func Search(searchCfg *config.SearchConfig, repoFilter *manip.SliceFilter, sourc
 	// Results writer
 	dbResultWriter := searchpkg.NewDBResultWriter(db, writerLog)
 
+	// Blob cache
+	var blobCache *searchpkg.BlobCache
+	if searchCfg.BlobCache {
+		blobCache = searchpkg.NewBlobCache(rulesetVersion, db, searchLog.AddPrefixPath("blob-cache"))
+		if err = blobCache.Prune(); err != nil {
+			err = errors.WithMessage(err, "unable to prune blob cache")
+			return
+		}
+	}
+
+	// Keyword prefilter
+	var prefilter *searchpkg.Prefilter
+	if !searchCfg.SkipPrefilter {
+		prefilter = searchpkg.NewPrefilter(processors, searchLog.AddPrefixPath("prefilter"))
+	}
+
+	// File changes searched at once by all of the workers together
+	fileChangePool := searchpkg.NewFileChangePool(searchCfg.FileChangeWorkerCount)
+
+	// Resource limits
+	limiter := searchpkg.NewLimiter(searchCfg.MaxLineLength, searchCfg.FileChangeTimeout, stats, db, searchLog.AddPrefixPath("limiter"))
+
 	// Workers
 	workers := make([]*searchpkg.Worker, workerCount)
 	for i := 0; i < workerCount; i++ {
-		workers[i] = searchpkg.NewWorker(processors, fileChangeFilter, workerLog)
+		workers[i] = searchpkg.NewWorker(processors, fileChangeFilter, blobCache, prefilter, fileChangePool, limiter, workerLog)
 	}
 
 	// Search runner
 	jobRunner := searchpkg.NewJobRunner(workers, dbResultWriter, searchLog)
 
 	// Search service
-	result = searchpkg.New(jobBuilder, jobRunner, interact, stats, db, searchLog)
+	result = searchpkg.New(jobBuilder, jobRunner, scanMarks, interact, stats, db, searchLog)
+
+	return
+}
+
+// Changes when anything that affects what the processors find changes, so cached blob scans
+// and scan marks from before the change aren't used
+func RulesetVersion(searchCfg *config.SearchConfig) (result string, err error) {
+
+	// The inventory's files can change without its config changing
+	var keyInventoryFiles map[string]string
+	if keyInventoryFiles, err = keyInventoryFileHashes(searchCfg.KeyInventoryConfigs); err != nil {
+		err = errors.WithMessage(err, "unable to hash key inventory files")
+		return
+	}
+
+	ruleset := struct {
+		FormatVersion       int
+		ProcessorConfigs    []*config.ProcessorConfig
+		TargetConfigs       []*config.TargetConfig
+		CustomTargetConfigs []*config.TargetConfig
+		IncludeTargets      []string
+		ExcludeTargets      []string
+		KeyInventoryConfigs []*config.KeyInventoryConfig
+		KeyInventoryFiles   map[string]string
+		MaxBlobSize         int
+		MaxLineLength       int
+	}{
+		FormatVersion:       searchpkg.BlobCacheFormatVersion,
+		ProcessorConfigs:    ProcConfigs(searchCfg),
+		TargetConfigs:       builtin.TargetConfigs(),
+		CustomTargetConfigs: searchCfg.CustomTargetConfigs,
+		IncludeTargets:      searchCfg.IncludeTargets,
+		ExcludeTargets:      searchCfg.ExcludeTargets,
+		KeyInventoryConfigs: searchCfg.KeyInventoryConfigs,
+		KeyInventoryFiles:   keyInventoryFiles,
+		MaxBlobSize:         searchCfg.MaxBlobSize,
+		MaxLineLength:       searchCfg.MaxLineLength,
+	}
+
+	var rulesetJSON []byte
+	if rulesetJSON, err = json.Marshal(ruleset); err != nil {
+		err = errors.Wrap(err, "unable to encode ruleset")
+		return
+	}
+
+	result = database.CreateHashID(string(rulesetJSON))
+
+	return
+}
+
+// Hashes of the files the key inventory is loaded from, by path
+func keyInventoryFileHashes(keyInventoryCfgs []*config.KeyInventoryConfig) (result map[string]string, err error) {
+	result = map[string]string{}
+	for _, keyInventoryCfg := range keyInventoryCfgs {
+		err = filepath.Walk(keyInventoryCfg.Path, func(path string, info os.FileInfo, walkErr error) (err error) {
+			if walkErr != nil {
+				return walkErr
+			}
+			if !info.Mode().IsRegular() {
+				return
+			}
+
+			var contents []byte
+			if contents, err = ioutil.ReadFile(path); err != nil {
+				return errors.Wrapv(err, "unable to read key inventory file", path)
+			}
+			result[path] = database.CreateHashID(string(contents))
+
+			return
+		})
+		if err != nil {
+			err = errors.WithMessagev(err, "unable to walk key inventory path", keyInventoryCfg.Path)
+			return
+		}
+	}
 
 	return
 }
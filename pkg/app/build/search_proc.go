@@ -2,34 +2,93 @@ package build
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/pantheon-systems/secrets-searcher/pkg/builtin"
 
 	"github.com/pantheon-systems/secrets-searcher/pkg/app/config"
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
 	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
 	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
 	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/risk"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/decode"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/dotfile"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/dsn"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/entropy"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/iac"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/jwt"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/k8s"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/keystore"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/notebook"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/pem"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/regex"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/setter"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/structured"
 )
 
-func Procs(searchCfg *config.SearchConfig, targets *search.TargetSet, processorsLog logg.Logg) (result []contract.ProcessorI, err error) {
+func Procs(searchCfg *config.SearchConfig, targets *search.TargetSet, db *database.Database, processorsLog logg.Logg) (result []contract.ProcessorI, err error) {
 	result = []contract.ProcessorI{}
 
-	// Custom procs names for filter
-	customProcNames := make([]string, len(searchCfg.ProcessorConfigs))
-	for i, procConfig := range searchCfg.ProcessorConfigs {
+	var inventory *pem.KeyInventory
+	if inventory, err = KeyInventory(searchCfg.KeyInventoryConfigs, processorsLog.AddPrefixPath("key-inventory")); err != nil {
+		err = errors.WithMessage(err, "unable to load key inventory")
+		return
+	}
+
+	var hasPEMProc bool
+	var lineProcs []contract.LineProcessorI
+	var notebookProcConfig *config.ProcessorConfig
+	for _, procConfig := range ProcConfigs(searchCfg) {
+
+		// Notebook processors run the line processors, so they're built after them
+		if procConfig.Processor == search.Notebook.String() {
+			if notebookProcConfig == nil {
+				notebookProcConfig = procConfig
+			}
+			continue
+		}
+
 		var proc contract.ProcessorI
-		if proc, err = Proc(procConfig, targets, processorsLog); err != nil {
-			err = errors.New("unable to create custom processesor: " + procConfig.Name)
+		if proc, err = Proc(procConfig, targets, inventory, processorsLog); err != nil {
+			err = errors.New("unable to create processesor: " + procConfig.Name)
 			return
 		}
-
 		result = append(result, proc)
+		hasPEMProc = hasPEMProc || procConfig.Processor == search.PEM.String()
+		if wrapper, ok := proc.(*search.LineProcessorWrapper); ok {
+			lineProcs = append(lineProcs, wrapper.LineProcessor())
+		}
+	}
+
+	if len(result) == 0 {
+		err = errors.New("no processors are configured")
+		return
+	}
+
+	// Notebooks are searched by cell first, so results on the raw JSON lines overlap and are ignored
+	if notebookProcConfig != nil && len(lineProcs) > 0 {
+		notebookProc := ProcNotebook(notebookProcConfig.Name, lineProcs, processorsLog.AddPrefixPath(notebookProcConfig.Name))
+		result = append([]contract.ProcessorI{notebookProc}, result...)
+	}
+
+	// Certificates are collected so the keys PEM processors find can be paired with them
+	if hasPEMProc {
+		result = append(result, pem.NewCertificateCollector(db, processorsLog.AddPrefixPath(pem.CertificateCollectorName)))
+	}
+
+	return
+}
+
+// Custom processor configs, followed by the builtin ones that pass the filter
+func ProcConfigs(searchCfg *config.SearchConfig) (result []*config.ProcessorConfig) {
+
+	// Custom procs names for filter
+	customProcNames := make([]string, len(searchCfg.ProcessorConfigs))
+	for i, procConfig := range searchCfg.ProcessorConfigs {
+		result = append(result, procConfig)
 		customProcNames[i] = procConfig.Name
 	}
 
@@ -38,35 +97,84 @@ func Procs(searchCfg *config.SearchConfig, targets *search.TargetSet, processors
 	processorFilter := manip.StringFilter(searchCfg.IncludeProcessors, searchCfg.ExcludeProcessors)
 	for _, procConfig := range coreProcConfigs {
 		if processorFilter.Includes(procConfig.Name) && !manip.SliceContains(customProcNames, procConfig.Name) {
-			var proc contract.ProcessorI
-			if proc, err = Proc(procConfig, targets, processorsLog); err != nil {
-				err = errors.New("unable to create builtin processesor: " + procConfig.Name)
-				return
-			}
-			result = append(result, proc)
+			result = append(result, procConfig)
 		}
 	}
 
-	if len(result) == 0 {
-		err = errors.New("no processors are configured")
-		return
+	return
+}
+
+// Severity of each processor by name, used for risk scoring
+func ProcSeverities(searchCfg *config.SearchConfig) (result map[string]risk.Severity) {
+	result = map[string]risk.Severity{}
+	for _, procConfig := range ProcConfigs(searchCfg) {
+		result[procConfig.Name] = ProcSeverity(procConfig)
 	}
 
 	return
 }
 
-func Proc(procCfg *config.ProcessorConfig, targets *search.TargetSet, processorsLog logg.Logg) (result contract.ProcessorI, err error) {
+// Paths of binary files the processors can read
+func ProcBinaryPathMatchStrings(searchCfg *config.SearchConfig) (result []string) {
+	for _, procConfig := range ProcConfigs(searchCfg) {
+		if procConfig.Processor != search.Keystore.String() {
+			continue
+		}
+		format := keystore.NewFormatFromValue(procConfig.KeystoreFormat)
+		if format.IsBinary() {
+			result = append(result, format.PathMatchString())
+		}
+	}
+
+	return
+}
+
+func ProcSeverity(procCfg *config.ProcessorConfig) risk.Severity {
+	if procCfg.Severity != "" {
+		return risk.NewSeverityFromValue(procCfg.Severity)
+	}
+
+	switch procCfg.Processor {
+	case search.PEM.String(), search.Keystore.String():
+		return risk.Critical
+	case search.Regex.String(), search.DSN.String(), search.K8sSecret.String(), search.Dotfile.String(), search.IaC.String():
+		return risk.High
+	case search.Entropy.String():
+		return risk.Low
+	default:
+		return risk.Medium
+	}
+}
+
+func Proc(procCfg *config.ProcessorConfig, targets *search.TargetSet, inventory *pem.KeyInventory, processorsLog logg.Logg) (result contract.ProcessorI, err error) {
 	processorLog := processorsLog.AddPrefixPath(procCfg.GetName())
 
 	switch procCfg.Processor {
 	case search.Regex.String():
 		result = ProcRegexWrapped(procCfg.Name, &procCfg.RegexProcessorConfig, processorLog)
 	case search.PEM.String():
-		result = ProcPEM(procCfg.Name, &procCfg.PEMProcessorConfig, processorLog)
+		result = ProcPEM(procCfg.Name, &procCfg.PEMProcessorConfig, inventory, processorLog)
 	case search.Setter.String():
 		result, err = ProcSetterWrapped(procCfg.Name, &procCfg.SetterProcessorConfig, targets, processorLog)
 	case search.Entropy.String():
 		result = ProcEntropy(procCfg.Name, &procCfg.EntropyProcessorConfig, processorLog)
+	case search.Keystore.String():
+		result = ProcKeystore(procCfg.Name, &procCfg.KeystoreProcessorConfig, processorLog)
+	case search.JWT.String():
+		result = ProcJWTWrapped(procCfg.Name, &procCfg.JWTProcessorConfig, processorLog)
+	case search.DSN.String():
+		result = ProcDSNWrapped(procCfg.Name, &procCfg.DSNProcessorConfig, processorLog)
+	case search.K8sSecret.String():
+		result = ProcK8sSecret(procCfg.Name, &procCfg.K8sSecretProcessorConfig, targets, processorLog)
+	case search.Structured.String():
+		result = ProcStructured(procCfg.Name, &procCfg.StructuredProcessorConfig, targets, processorLog)
+	case search.Dotfile.String():
+		result = ProcDotfile(procCfg.Name, &procCfg.DotfileProcessorConfig, processorLog)
+	case search.IaC.String():
+		result = ProcIaC(procCfg.Name, &procCfg.IaCProcessorConfig, processorLog)
+	case search.Notebook.String():
+		err = errors.Errorv("notebook processors are built with the line processors they run", procCfg.Name)
+		return
 	default:
 		err = errors.Errorv("unknown processor", procCfg.Processor)
 		return
@@ -86,7 +194,15 @@ func ProcRegexWrapped(name string, regexProcCfg *config.RegexProcessorConfig, pr
 func ProcRegex(name string, regexProcCfg *config.RegexProcessorConfig, processorLog logg.Logg) (result *regex.Processor) {
 	codeWhitelist := CodeWhitelist(regexProcCfg.WhitelistCodeMatch, processorLog)
 	re := regexp.MustCompile(regexProcCfg.RegexString)
-	result = regex.NewProcessor(name, re, codeWhitelist, processorLog)
+
+	var checksum *regex.Checksum
+	if regexProcCfg.Checksum != "" {
+		checksumVal := regex.NewChecksumFromValue(regexProcCfg.Checksum)
+		checksum = &checksumVal
+	}
+
+	decoder := Decoder(regexProcCfg.DecodeDepth)
+	result = regex.NewProcessor(name, re, checksum, codeWhitelist, decoder, processorLog)
 
 	return
 }
@@ -94,12 +210,24 @@ func ProcRegex(name string, regexProcCfg *config.RegexProcessorConfig, processor
 //
 // PEM processor
 
-func ProcPEM(name string, pemProcCfg *config.PEMProcessorConfig, processorLog logg.Logg) (result contract.ProcessorI) {
-	result = pem.NewProcessor(name, pemProcCfg.PEMType, processorLog)
+func ProcPEM(name string, pemProcCfg *config.PEMProcessorConfig, inventory *pem.KeyInventory, processorLog logg.Logg) (result contract.ProcessorI) {
+	result = pem.NewProcessor(name, pemProcCfg.PEMType, inventory, processorLog)
 
 	return
 }
 
+func KeyInventory(keyInventoryCfgs []*config.KeyInventoryConfig, inventoryLog logg.Logg) (result *pem.KeyInventory, err error) {
+	sources := make([]*pem.KeyInventorySource, len(keyInventoryCfgs))
+	for i, keyInventoryCfg := range keyInventoryCfgs {
+		sources[i] = &pem.KeyInventorySource{
+			Path:  keyInventoryCfg.Path,
+			Label: keyInventoryCfg.Label,
+		}
+	}
+
+	return pem.LoadKeyInventory(sources, inventoryLog)
+}
+
 //
 // Setter processor
 
@@ -114,7 +242,8 @@ func ProcSetterWrapped(name string, setterProcCfg *config.SetterProcessorConfig,
 func ProcSetter(name string, setterProcCfg *config.SetterProcessorConfig, targets *search.TargetSet, processorLog logg.Logg) (result *setter.Processor, err error) {
 	rule := ProcSetterRule(name+"Rule", setterProcCfg, targets)
 	rules := []*setter.Rule{rule} // FIXME There's only ever one
-	result = setter.NewProcessor(name, rules, targets, processorLog)
+	decoder := Decoder(setterProcCfg.DecodeDepth)
+	result = setter.NewProcessor(name, rules, targets, decoder, processorLog)
 	return
 }
 
@@ -147,10 +276,100 @@ func ProcEntropy(name string, entropyProcCfg *config.EntropyProcessorConfig, pro
 		entropyProcCfg.Threshold,
 		codeWhitelist,
 		entropyProcCfg.SkipPEMs,
+		Decoder(entropyProcCfg.DecodeDepth),
 		processorLog,
 	)
 }
 
+//
+// Decoding
+
+// Nil when decoding is disabled
+func Decoder(depth int) (result *decode.Pipeline) {
+	if depth <= 0 {
+		return
+	}
+
+	return decode.NewPipeline(depth)
+}
+
+//
+// Keystore processor
+
+func ProcKeystore(name string, keystoreProcCfg *config.KeystoreProcessorConfig, processorLog logg.Logg) (result contract.ProcessorI) {
+	format := keystore.NewFormatFromValue(keystoreProcCfg.KeystoreFormat)
+	return keystore.NewProcessor(name, format, processorLog)
+}
+
+//
+// JWT processor
+
+func ProcJWTWrapped(name string, jwtProcCfg *config.JWTProcessorConfig, processorLog logg.Logg) (result contract.ProcessorI) {
+	lineProc := ProcJWT(name, jwtProcCfg, processorLog)
+	result = search.NewLineProcessorWrapper(lineProc, processorLog)
+	return
+}
+
+func ProcJWT(name string, jwtProcCfg *config.JWTProcessorConfig, processorLog logg.Logg) (result *jwt.Processor) {
+	return jwt.NewProcessor(name, jwtProcCfg.SkipExpiredAfter, jwtProcCfg.LongLivedAfter, time.Now(), processorLog)
+}
+
+//
+// DSN processor
+
+func ProcDSNWrapped(name string, dsnProcCfg *config.DSNProcessorConfig, processorLog logg.Logg) (result contract.ProcessorI) {
+	lineProc := ProcDSN(name, dsnProcCfg, processorLog)
+	result = search.NewLineProcessorWrapper(lineProc, processorLog)
+	return
+}
+
+func ProcDSN(name string, dsnProcCfg *config.DSNProcessorConfig, processorLog logg.Logg) (result *dsn.Processor) {
+	codeWhitelist := CodeWhitelist(dsnProcCfg.WhitelistCodeMatch, processorLog)
+	return dsn.NewProcessor(name, codeWhitelist, processorLog)
+}
+
+//
+// Kubernetes Secret processor
+
+func ProcK8sSecret(name string, k8sSecretProcCfg *config.K8sSecretProcessorConfig, targets *search.TargetSet, processorLog logg.Logg) (result contract.ProcessorI) {
+	return k8s.NewProcessor(name, k8sSecretProcCfg.SecretKinds, targets, processorLog)
+}
+
+//
+// Structured file processor
+
+func ProcStructured(name string, structuredProcCfg *config.StructuredProcessorConfig, targets *search.TargetSet, processorLog logg.Logg) (result contract.ProcessorI) {
+	formats := make([]structured.Format, len(structuredProcCfg.StructuredFormats))
+	for i, value := range structuredProcCfg.StructuredFormats {
+		formats[i] = structured.NewFormatFromValue(value)
+	}
+
+	return structured.NewProcessor(name, formats, targets, processorLog)
+}
+
+//
+// Dotfile processor
+
+func ProcDotfile(name string, dotfileProcCfg *config.DotfileProcessorConfig, processorLog logg.Logg) (result contract.ProcessorI) {
+	format := dotfile.NewFormatFromValue(dotfileProcCfg.DotfileFormat)
+	return dotfile.NewProcessor(name, format, processorLog)
+}
+
+//
+// IaC processor
+
+func ProcIaC(name string, iacProcCfg *config.IaCProcessorConfig, processorLog logg.Logg) (result contract.ProcessorI) {
+	format := iac.NewFormatFromValue(iacProcCfg.IaCFormat)
+	return iac.NewProcessor(name, format, processorLog)
+}
+
+//
+// Notebook processor
+
+func ProcNotebook(name string, lineProcs []contract.LineProcessorI, processorLog logg.Logg) (result contract.ProcessorI) {
+	return notebook.NewProcessor(name, lineProcs, processorLog)
+}
+
 //
 // Helpers
 
@@ -19,6 +19,7 @@ func Source(sourceCfg *config.SourceConfig, sourceDir string, repoFilter *manip.
 	return source.New(
 		sourceDir,
 		sourceCfg.SkipFetch,
+		FetchOptions(sourceCfg),
 		sourceCfg.WorkerCount,
 		repoFilter,
 		git,
@@ -29,6 +30,38 @@ func Source(sourceCfg *config.SourceConfig, sourceDir string, repoFilter *manip.
 	)
 }
 
+func FetchOptions(sourceCfg *config.SourceConfig) *gitpkg.FetchOptions {
+	return gitpkg.NewFetchOptions(sourceCfg.Depth, sourceCfg.ShallowSince, int64(sourceCfg.MaxBlobSize), sourceCfg.CheckoutExcludePaths)
+}
+
+func Credentials(sourceCfg *config.SourceConfig) *gitpkg.Credentials {
+	hostAuths := make(map[string]*gitpkg.Auth, len(sourceCfg.HostAuthConfigs))
+	for _, hostAuthCfg := range sourceCfg.HostAuthConfigs {
+		hostAuths[hostAuthCfg.Host] = buildAuth(&hostAuthCfg.AuthConfig)
+	}
+
+	return gitpkg.NewCredentials(buildAuth(&sourceCfg.AuthConfig), hostAuths)
+}
+
+func buildAuth(authCfg *config.AuthConfig) *gitpkg.Auth {
+	username, password := authCfg.HTTPSUsername, authCfg.HTTPSPassword
+	if authCfg.HTTPSToken != "" {
+		password = authCfg.HTTPSToken
+		if username == "" {
+			username = "x-access-token"
+		}
+	}
+
+	return &gitpkg.Auth{
+		SSHKeyPath:        authCfg.SSHKey,
+		SSHKeyPassphrase:  authCfg.SSHKeyPassphrase,
+		SSHKnownHostsPath: authCfg.SSHKnownHosts,
+		SSHHostKeyCheck:   authCfg.SSHHostKeyCheck,
+		HTTPSUsername:     username,
+		HTTPSPassword:     password,
+	}
+}
+
 func buildSourceProvider(sourceCfg *config.SourceConfig, git *gitpkg.Git, sourceLog logg.Logg) (result source.ProviderI) {
 	providerLog := sourceLog.AddPrefixPath("provider")
 	switch sourceCfg.Provider {
@@ -0,0 +1,33 @@
+package build
+
+import (
+	"net/http"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/app/config"
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	verifypkg "github.com/pantheon-systems/secrets-searcher/pkg/verify"
+)
+
+func Verify(verifyCfg *config.VerifyConfig, searchCfg *config.SearchConfig, db *database.Database, log logg.Logg) *verifypkg.Verify {
+	client := &http.Client{Timeout: verifyCfg.Timeout}
+
+	// Verifiers by name
+	verifiers := map[string]verifypkg.VerifierI{}
+	for _, name := range verifypkg.VerifierNames() {
+		baseURL := verifyCfg.Endpoints[name.Value()]
+		verifiers[name.Value()] = verifypkg.NewVerifier(name, baseURL, client)
+	}
+
+	// Processors that opted into verification
+	procVerifiers := map[string]string{}
+	for _, procCfg := range ProcConfigs(searchCfg) {
+		if procCfg.Verifier != "" {
+			procVerifiers[procCfg.Name] = procCfg.Verifier
+		}
+	}
+
+	limiter := verifypkg.NewRateLimiter(verifyCfg.RateLimit)
+
+	return verifypkg.New(verifyCfg.Enable, verifiers, procVerifiers, limiter, verifyCfg.Timeout, db, log)
+}
@@ -24,10 +24,12 @@ type AppConfig struct {
 	EnableReportPhase bool         `param:"enable-report-phase" env:"true"`
 	EnableProfiling   bool         `param:"enable-profiling" env:"true"`
 	RescanPrevious    bool         `param:"rescan-previous" env:"true"`
+	Resume            bool         `param:"resume" env:"true"`
 	DevConfig         DevConfig    `param:"dev"`
 	SourceConfig      SourceConfig `param:"source"`
 	SearchConfig      SearchConfig `param:"search"`
 	ReporterConfig    ReportConfig `param:"report"`
+	VerifyConfig      VerifyConfig `param:"verify"`
 }
 
 func NewAppConfig() (appCfg *AppConfig) {
@@ -38,6 +40,7 @@ func NewAppConfig() (appCfg *AppConfig) {
 		EnableReportPhase: true,
 		SourceConfig:      *NewSourceConfig(),
 		SearchConfig:      *NewSearchConfig(),
+		VerifyConfig:      *NewVerifyConfig(),
 	}
 	appCfg.SetDefaults()
 	return
@@ -64,9 +67,14 @@ func (appCfg AppConfig) Validate() (err error) {
 	return va.ValidateStructWithContext(ctx, &appCfg,
 		va.Field(&appCfg.LogLevel, va.Required, va.In(manip.DowncastSlice(logg.ValidLevelValues())...)),
 		va.Field(&appCfg.OutputDir, va.Required),
+		va.Field(&appCfg.RescanPrevious, va.When(appCfg.SearchConfig.Incremental,
+			va.Empty.Error("cannot be used with an incremental search"))),
+		va.Field(&appCfg.Resume, va.When(appCfg.RescanPrevious,
+			va.Empty.Error("cannot be used with rescan-previous"))),
 		va.Field(&appCfg.SourceConfig),
 		va.Field(&appCfg.SearchConfig),
 		va.Field(&appCfg.ReporterConfig),
+		va.Field(&appCfg.VerifyConfig),
 	)
 }
 
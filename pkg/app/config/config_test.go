@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	searchpkg "github.com/pantheon-systems/secrets-searcher/pkg/search"
 	"github.com/pantheon-systems/secrets-searcher/pkg/source"
@@ -77,11 +78,13 @@ var _ = Describe("Config tests", func() {
 			It("they are set in a new config object", func() {
 				const outputDirValue = "output-dir"
 				const sourceAPITokenValue = "source-api-token"
+				const sourceHTTPSTokenValue = "source-https-token"
 				args := []string{"", "--config=" + testConfigPath("config-empty.yaml")}
 				env := []string{
 					"SECRETS_LOG_LEVEL=" + logg.Trace.Value(),
 					"SECRETS_OUTPUT_DIR=" + outputDirValue,
 					"SECRETS_SOURCE_API_TOKEN=" + sourceAPITokenValue,
+					"SECRETS_SOURCE_HTTPS_TOKEN=" + sourceHTTPSTokenValue,
 					"SECRETS_DEV_ENABLED=true",
 				}
 
@@ -91,6 +94,7 @@ var _ = Describe("Config tests", func() {
 				Expect(appCfg.LogLevel).To(Equal(logg.Trace.Value()))
 				Expect(appCfg.OutputDir).To(Equal(outputDirValue))
 				Expect(appCfg.SourceConfig.APIToken).To(Equal(sourceAPITokenValue))
+				Expect(appCfg.SourceConfig.HTTPSToken).To(Equal(sourceHTTPSTokenValue))
 			})
 		})
 	})
@@ -146,6 +150,12 @@ var _ = Describe("Config tests", func() {
 		Entry("source.provider from enum", setSourceProvider(source.Local.Value()), noErr()),
 		Entry("source.local-dir string not empty", setSourceLocalDir("anystring"), notErr(va.ErrRequired)),
 		Entry("source.local-dir outside of output directory", setSourceLocalDirOutsideOutputDir(), noErr()),
+		Entry("source.depth positive", setSourceDepth(10), noErr()),
+		Entry("source.shallow-since without depth", setSourceShallowSince(0), noErr()),
+		Entry("source.shallow-since at search.earliest-date",
+			setSourceShallowSinceAndEarliestDate(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), noErr()),
+		Entry("source.ssh-host-key-check from enum", setSourceSSHHostKeyCheck("ignore"), noErr()),
+		Entry("source.https-token without password", setSourceHTTPSToken(""), noErr()),
 	)
 
 	DescribeTable("invalid values should cause validation errors",
@@ -161,6 +171,12 @@ var _ = Describe("Config tests", func() {
 		Entry("source.provider not from enum", setSourceProvider("not-in-enum"), va.ErrInInvalid),
 		Entry("source.local-dir inside of output directory",
 			setSourceLocalDirInsideOutputDir(), valid.ErrPathNotWithinParam),
+		Entry("source.depth negative", setSourceDepth(-1), va.ErrMinGreaterEqualThanRequired),
+		Entry("source.shallow-since with depth", setSourceShallowSince(10), va.ErrEmpty),
+		Entry("source.shallow-since after search.earliest-date",
+			setSourceShallowSinceAndEarliestDate(time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)), valid.ErrNotAfterTimeParam),
+		Entry("source.ssh-host-key-check not from enum", setSourceSSHHostKeyCheck("sometimes"), va.ErrInInvalid),
+		Entry("source.https-token with password", setSourceHTTPSToken("password"), va.ErrEmpty),
 	)
 })
 
@@ -256,6 +272,49 @@ func setSourceLocalDirInsideOutputDir() getCfgAndField {
 	}
 }
 
+func setSourceDepth(value int) getCfgAndField {
+	return func() (*AppConfig, interface{}) {
+		c := buildAppConfigObjectFromFile("config-empty.yaml")
+		c.SourceConfig.Depth = value
+		return c, &c.SourceConfig.Depth
+	}
+}
+
+func setSourceShallowSince(depth int) getCfgAndField {
+	return func() (*AppConfig, interface{}) {
+		c := buildAppConfigObjectFromFile("config-empty.yaml")
+		c.SourceConfig.Depth = depth
+		c.SourceConfig.ShallowSince = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		return c, &c.SourceConfig.ShallowSince
+	}
+}
+
+func setSourceShallowSinceAndEarliestDate(earliestDate time.Time) getCfgAndField {
+	return func() (*AppConfig, interface{}) {
+		c := buildAppConfigObjectFromFile("config-empty.yaml")
+		c.SourceConfig.ShallowSince = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		c.SearchConfig.EarliestTime = earliestDate
+		return c, &c.SourceConfig.ShallowSince
+	}
+}
+
+func setSourceSSHHostKeyCheck(value string) getCfgAndField {
+	return func() (*AppConfig, interface{}) {
+		c := buildAppConfigObjectFromFile("config-empty.yaml")
+		c.SourceConfig.SSHHostKeyCheck = value
+		return c, &c.SourceConfig.SSHHostKeyCheck
+	}
+}
+
+func setSourceHTTPSToken(password string) getCfgAndField {
+	return func() (*AppConfig, interface{}) {
+		c := buildAppConfigObjectFromFile("config-empty.yaml")
+		c.SourceConfig.HTTPSPassword = password
+		c.SourceConfig.HTTPSToken = "token"
+		return c, &c.SourceConfig.HTTPSToken
+	}
+}
+
 func setSearchTarget(value string) getCfgAndField {
 	return func() (*AppConfig, interface{}) {
 		c := buildAppConfigObjectFromFile("config-empty.yaml")
@@ -5,6 +5,7 @@ import (
 
 	va "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	"github.com/pantheon-systems/secrets-searcher/pkg/valid"
 )
 
 type ReportConfig struct {
@@ -13,11 +14,13 @@ type ReportConfig struct {
 	ShowDebugOutput   bool          `param:"show-debug-output" env:"true"`
 	EnablePreReports  bool          `param:"enable-pre-reports" env:"true"`
 	PreReportInterval time.Duration `param:"pre-report-interval" env:"true"`
+	ProdPathMatch     []string      `param:"prod-path-match"`
 }
 
 func (reportCfg ReportConfig) Validate() (err error) {
 	return va.ValidateStruct(&reportCfg,
 		va.Field(&reportCfg.PreReportInterval, va.When(reportCfg.EnablePreReports, va.By(checkPreReportInterval))),
+		va.Field(&reportCfg.ProdPathMatch, va.Each(va.Required, valid.RegexpPattern)),
 	)
 }
 
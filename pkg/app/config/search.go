@@ -19,6 +19,8 @@ type SearchConfig struct {
 	IncludeProcessors []string           `param:"include-processors"`
 	ExcludeProcessors []string           `param:"exclude-processors"`
 
+	KeyInventoryConfigs []*KeyInventoryConfig `param:"key-inventory"`
+
 	EarliestTime              time.Time `param:"earliest-date" env:"true"`
 	LatestTime                time.Time `param:"latest-date" env:"true"`
 	WhitelistPathMatchStrings []string  `param:"whitelist-path-match"`
@@ -28,6 +30,24 @@ type SearchConfig struct {
 	WorkerCount               int       `param:"worker-count" env:"true"`
 	ShowBarPerJob             bool      `param:"show-bar-per-job" env:"true"`
 	DetailedStats             bool      `param:"detailed-stats" env:"true"`
+
+	// Reuse what was found in blobs that were already searched, in this run or an earlier one
+	BlobCache bool `param:"blob-cache" env:"true"`
+
+	// Only search commits added since the last completed search, unless the ruleset changed
+	Incremental bool `param:"incremental" env:"true"`
+
+	// Run line processors on every line, instead of only the lines with one of their keywords
+	SkipPrefilter bool `param:"skip-prefilter" env:"true"`
+
+	// File changes searched at once, shared by all workers
+	FileChangeWorkerCount int `param:"file-change-worker-count" env:"true"`
+
+	// Resource limits, 0 for no limit. Larger blobs are skipped, longer added lines are truncated and
+	// file changes that take longer are stopped. What hits a limit is listed in the report.
+	MaxBlobSize       int           `param:"max-blob-size" env:"true"`
+	MaxLineLength     int           `param:"max-line-length" env:"true"`
+	FileChangeTimeout time.Duration `param:"file-change-timeout" env:"true"`
 }
 
 func NewSearchConfig() (result *SearchConfig) {
@@ -43,6 +63,9 @@ func (searchCfg *SearchConfig) SetDefaults() {
 	if searchCfg.WorkerCount == 0 {
 		searchCfg.WorkerCount = 8
 	}
+	if searchCfg.FileChangeWorkerCount == 0 {
+		searchCfg.FileChangeWorkerCount = 8
+	}
 }
 
 func (searchCfg SearchConfig) ValidateWithContext(ctx context.Context) (err error) {
@@ -51,6 +74,7 @@ func (searchCfg SearchConfig) ValidateWithContext(ctx context.Context) (err erro
 		va.Field(&searchCfg.IncludeTargets, va.Each(va.Required)),
 		va.Field(&searchCfg.ExcludeTargets, va.Each(va.Required)),
 		va.Field(&searchCfg.ProcessorConfigs, validProcessorNames()),
+		va.Field(&searchCfg.KeyInventoryConfigs),
 		va.Field(&searchCfg.EarliestTime, valid.WhenBothNotZero(
 			valid.BeforeTime(manip.NewBasicParam(&searchCfg, &searchCfg.LatestTime)))),
 		va.Field(&searchCfg.WhitelistPathMatchStrings, va.Each(va.Required, valid.RegexpPattern)),
@@ -58,6 +82,10 @@ func (searchCfg SearchConfig) ValidateWithContext(ctx context.Context) (err erro
 		va.Field(&searchCfg.WhitelistSecretDir, va.When(searchCfg.WhitelistSecretDir != "", valid.ExistingDir)),
 		va.Field(&searchCfg.ChunkSize, va.Required),
 		va.Field(&searchCfg.WorkerCount, va.Required),
+		va.Field(&searchCfg.FileChangeWorkerCount, va.Required),
+		va.Field(&searchCfg.MaxBlobSize, va.Min(0)),
+		va.Field(&searchCfg.MaxLineLength, va.Min(0)),
+		va.Field(&searchCfg.FileChangeTimeout, va.Min(time.Duration(0))),
 	)
 }
 
@@ -136,3 +164,21 @@ func (targetCfg *TargetConfig) Validate() (err error) {
 		va.Field(&targetCfg.ValLenMax, va.Min(targetCfg.ValLenMin+1)),
 	)
 }
+
+//
+// KeyInventoryConfig
+
+type KeyInventoryConfig struct {
+
+	// File or directory of authorized_keys files, PEM certificates/public keys, or JWKS JSON
+	Path string `param:"path"`
+
+	// Name of the host or service the keys belong to, derived from the files if empty
+	Label string `param:"label"`
+}
+
+func (keyInventoryCfg *KeyInventoryConfig) Validate() (err error) {
+	return va.ValidateStruct(keyInventoryCfg,
+		va.Field(&keyInventoryCfg.Path, va.Required, valid.ExistingPath),
+	)
+}
@@ -1,20 +1,39 @@
 package config
 
 import (
+	"time"
+
 	va "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pantheon-systems/secrets-searcher/pkg/entropy"
 	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/risk"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/decode"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/dotfile"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/iac"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/keystore"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/regex"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/structured"
 	"github.com/pantheon-systems/secrets-searcher/pkg/valid"
+	"github.com/pantheon-systems/secrets-searcher/pkg/verify"
 )
 
 type ProcessorConfig struct {
-	Name                   string `param:"name"`
-	Processor              string `param:"processor"`
-	RegexProcessorConfig   `param:",squash"`
-	PEMProcessorConfig     `param:",squash"`
-	SetterProcessorConfig  `param:",squash"`
-	EntropyProcessorConfig `param:",squash"`
+	Name                      string `param:"name"`
+	Processor                 string `param:"processor"`
+	Severity                  string `param:"severity"`
+	Verifier                  string `param:"verifier"`
+	RegexProcessorConfig      `param:",squash"`
+	PEMProcessorConfig        `param:",squash"`
+	SetterProcessorConfig     `param:",squash"`
+	EntropyProcessorConfig    `param:",squash"`
+	KeystoreProcessorConfig   `param:",squash"`
+	JWTProcessorConfig        `param:",squash"`
+	DSNProcessorConfig        `param:",squash"`
+	K8sSecretProcessorConfig  `param:",squash"`
+	StructuredProcessorConfig `param:",squash"`
+	DotfileProcessorConfig    `param:",squash"`
+	IaCProcessorConfig        `param:",squash"`
 }
 
 func (procCfg *ProcessorConfig) GetName() string {
@@ -25,6 +44,8 @@ func (procCfg *ProcessorConfig) Validate() (err error) {
 	err = va.ValidateStruct(procCfg,
 		va.Field(&procCfg.Name, va.Required),
 		va.Field(&procCfg.Processor, va.Required, va.In(manip.DowncastSlice(search.ValidProcessorTypeValues())...)),
+		va.Field(&procCfg.Severity, va.In(manip.DowncastSlice(risk.ValidSeverityValues())...)),
+		va.Field(&procCfg.Verifier, va.In(manip.DowncastSlice(verify.ValidVerifierNameValues())...)),
 	)
 	if err != nil {
 		return
@@ -43,6 +64,22 @@ func (procCfg *ProcessorConfig) getSubCfg() (result va.Validatable) {
 		result = &procCfg.SetterProcessorConfig
 	case search.Entropy.String():
 		result = &procCfg.EntropyProcessorConfig
+	case search.Keystore.String():
+		result = &procCfg.KeystoreProcessorConfig
+	case search.JWT.String():
+		result = &procCfg.JWTProcessorConfig
+	case search.DSN.String():
+		result = &procCfg.DSNProcessorConfig
+	case search.K8sSecret.String():
+		result = &procCfg.K8sSecretProcessorConfig
+	case search.Structured.String():
+		result = &procCfg.StructuredProcessorConfig
+	case search.Dotfile.String():
+		result = &procCfg.DotfileProcessorConfig
+	case search.IaC.String():
+		result = &procCfg.IaCProcessorConfig
+	case search.Notebook.String():
+		// Notebook processors have no options, they run the line processors
 	default:
 		panic("unknown processor: " + procCfg.Processor)
 	}
@@ -55,13 +92,19 @@ func (procCfg *ProcessorConfig) getSubCfg() (result va.Validatable) {
 
 type RegexProcessorConfig struct {
 	RegexString        string   `param:"regex"`
+	Checksum           string   `param:"checksum"`
 	WhitelistCodeMatch []string `param:"whitelist-code-match"`
+
+	// How many layers of encoding to decode in encoded values, zero to disable
+	DecodeDepth int `param:"decode-depth"`
 }
 
 func (regexProcCfg *RegexProcessorConfig) Validate() (err error) {
 	return va.ValidateStruct(regexProcCfg,
 		va.Field(&regexProcCfg.RegexString, va.Required, valid.RegexpPattern),
+		va.Field(&regexProcCfg.Checksum, va.In(manip.DowncastSlice(regex.ValidChecksumValues())...)),
 		va.Field(&regexProcCfg.WhitelistCodeMatch, va.Each(valid.RegexpPattern)),
+		va.Field(&regexProcCfg.DecodeDepth, va.Min(0), va.Max(decode.MaxDepth)),
 	)
 }
 
@@ -92,6 +135,9 @@ type EntropyProcessorConfig struct {
 	Threshold           float64  `param:"threshold"`
 	SkipPEMs            bool     `param:"skip-pems"`
 	WhitelistCodeMatch  []string `param:"whitelist-code-match"`
+
+	// How many layers of encoding to decode in encoded values, zero to disable
+	DecodeDepth int `param:"decode-depth"`
 }
 
 // FIXME This is being skipped during viper/mapstructure creation of appConfig, so the defaults won't work
@@ -107,5 +153,99 @@ func (entropyProcCfg *EntropyProcessorConfig) Validate() (err error) {
 		va.Field(&entropyProcCfg.WordLengthThreshold, va.Required),
 		va.Field(&entropyProcCfg.Threshold),
 		va.Field(&entropyProcCfg.WhitelistCodeMatch, va.Each(valid.RegexpPattern)),
+		va.Field(&entropyProcCfg.DecodeDepth, va.Min(0), va.Max(decode.MaxDepth)),
+	)
+}
+
+//
+// Keystore processor
+
+type KeystoreProcessorConfig struct {
+	KeystoreFormat string `param:"keystore-format"`
+}
+
+func (keystoreProcCfg *KeystoreProcessorConfig) Validate() (err error) {
+	return va.ValidateStruct(keystoreProcCfg,
+		va.Field(&keystoreProcCfg.KeystoreFormat, va.Required, va.In(manip.DowncastSlice(keystore.ValidFormatValues())...)),
+	)
+}
+
+//
+// JWT processor
+
+type JWTProcessorConfig struct {
+	SkipExpiredAfter time.Duration `param:"skip-expired-after"`
+	LongLivedAfter   time.Duration `param:"long-lived-after"`
+}
+
+func (jwtProcCfg *JWTProcessorConfig) Validate() (err error) {
+	return va.ValidateStruct(jwtProcCfg,
+		va.Field(&jwtProcCfg.SkipExpiredAfter, va.Min(time.Duration(0))),
+		va.Field(&jwtProcCfg.LongLivedAfter, va.Min(time.Duration(0))),
+	)
+}
+
+//
+// DSN processor
+
+type DSNProcessorConfig struct {
+	WhitelistCodeMatch []string `param:"whitelist-code-match"`
+}
+
+func (dsnProcCfg *DSNProcessorConfig) Validate() (err error) {
+	return va.ValidateStruct(dsnProcCfg,
+		va.Field(&dsnProcCfg.WhitelistCodeMatch, va.Each(valid.RegexpPattern)),
+	)
+}
+
+//
+// Kubernetes Secret processor
+
+type K8sSecretProcessorConfig struct {
+	SecretKinds []string `param:"secret-kinds"`
+}
+
+func (k8sSecretProcCfg *K8sSecretProcessorConfig) Validate() (err error) {
+	return va.ValidateStruct(k8sSecretProcCfg,
+		va.Field(&k8sSecretProcCfg.SecretKinds, va.Required),
+	)
+}
+
+//
+// Structured file processor
+
+type StructuredProcessorConfig struct {
+	StructuredFormats []string `param:"structured-formats"`
+}
+
+func (structuredProcCfg *StructuredProcessorConfig) Validate() (err error) {
+	return va.ValidateStruct(structuredProcCfg,
+		va.Field(&structuredProcCfg.StructuredFormats, va.Required, va.Each(va.In(manip.DowncastSlice(structured.ValidFormatValues())...))),
+	)
+}
+
+//
+// Dotfile processor
+
+type DotfileProcessorConfig struct {
+	DotfileFormat string `param:"dotfile-format"`
+}
+
+func (dotfileProcCfg *DotfileProcessorConfig) Validate() (err error) {
+	return va.ValidateStruct(dotfileProcCfg,
+		va.Field(&dotfileProcCfg.DotfileFormat, va.Required, va.In(manip.DowncastSlice(dotfile.ValidFormatValues())...)),
+	)
+}
+
+//
+// IaC processor
+
+type IaCProcessorConfig struct {
+	IaCFormat string `param:"iac-format"`
+}
+
+func (iacProcCfg *IaCProcessorConfig) Validate() (err error) {
+	return va.ValidateStruct(iacProcCfg,
+		va.Field(&iacProcCfg.IaCFormat, va.Required, va.In(manip.DowncastSlice(iac.ValidFormatValues())...)),
 	)
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	va "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/decode"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/rulebuild"
 	"github.com/pantheon-systems/secrets-searcher/pkg/valid"
 )
@@ -36,6 +37,9 @@ type SetterProcessorConfig struct {
 	// Disable certain value characters set in the target if the context requires it
 	// Like, in a URL path, you wouldn't want to match slashes in the value if you were looking at its segments
 	NotValChars []string
+
+	// How many layers of encoding to decode in encoded values, zero to disable
+	DecodeDepth int `param:"decode-depth"`
 }
 
 func (setterProcCfg *SetterProcessorConfig) SetDefaults() {
@@ -59,5 +63,6 @@ func (setterProcCfg *SetterProcessorConfig) Validate() (err error) {
 		va.Field(&setterProcCfg.Operator, valid.RegexpPattern),
 		va.Field(&setterProcCfg.KeyChars, va.Required, va.Each(va.Required, valid.RegexpPattern)),
 		va.Field(&setterProcCfg.NotValChars, va.Each(va.Required, valid.RegexpPattern)),
+		va.Field(&setterProcCfg.DecodeDepth, va.Min(0), va.Max(decode.MaxDepth)),
 	)
 }
@@ -2,7 +2,9 @@ package config
 
 import (
 	"context"
+	"time"
 
+	gitpkg "github.com/pantheon-systems/secrets-searcher/pkg/git"
 	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
 	"github.com/pantheon-systems/secrets-searcher/pkg/source"
 
@@ -11,11 +13,27 @@ import (
 )
 
 type SourceConfig struct {
-	Provider             string   `param:"provider" env:"true"`
-	IncludeRepos         []string `param:"include-repos" env:"true"`
-	ExcludeRepos         []string `param:"exclude-repos" env:"true"`
-	SkipFetch            bool     `param:"skip-fetch" env:"true"`
-	WorkerCount          int      `param:"worker-count" env:"true"`
+	Provider     string   `param:"provider" env:"true"`
+	IncludeRepos []string `param:"include-repos" env:"true"`
+	ExcludeRepos []string `param:"exclude-repos" env:"true"`
+	SkipFetch    bool     `param:"skip-fetch" env:"true"`
+	WorkerCount  int      `param:"worker-count" env:"true"`
+
+	// How much of each repo is fetched, everything by default. Depth and shallow date can't both be set,
+	// and the shallow date can't be later than the search's earliest date. Blobs larger than the max size
+	// are left out. The search treats the oldest commits of a shallow clone as fully added.
+	Depth        int       `param:"depth" env:"true"`
+	ShallowSince time.Time `param:"shallow-since" env:"true"`
+	MaxBlobSize  int       `param:"max-blob-size" env:"true"`
+
+	// Sparse checkout patterns left out of the worktree, to save disk. Their history is still fetched
+	// and searched. Needs git 2.35 or later.
+	CheckoutExcludePaths []string `param:"checkout-exclude-paths" env:"true"`
+
+	// Credentials for cloning and fetching, for any host without its own in host auth
+	AuthConfig      `param:",squash"`
+	HostAuthConfigs []*HostAuthConfig `param:"host-auth"`
+
 	LocalProviderConfig  `param:",squash"`
 	GithubProviderConfig `param:",squash"`
 }
@@ -33,12 +51,25 @@ func (sourceCfg *SourceConfig) SetDefaults() {
 	if sourceCfg.WorkerCount == 0 {
 		sourceCfg.WorkerCount = 5
 	}
+	sourceCfg.AuthConfig.SetDefaults()
+	for _, hostAuthCfg := range sourceCfg.HostAuthConfigs {
+		hostAuthCfg.AuthConfig.SetDefaults()
+	}
 }
 
 func (sourceCfg SourceConfig) ValidateWithContext(ctx context.Context) (err error) {
+	appCfg := getAppCfgToContext(ctx)
 	err = va.ValidateStructWithContext(ctx, &sourceCfg,
 		va.Field(&sourceCfg.Provider, va.Required, va.In(manip.DowncastSlice(source.ValidProviderValues())...)),
 		va.Field(&sourceCfg.WorkerCount, va.Required),
+		va.Field(&sourceCfg.Depth, va.Min(0)),
+		va.Field(&sourceCfg.ShallowSince,
+			va.When(sourceCfg.Depth > 0, va.Empty.Error("can't be used together with \"depth\"")),
+			valid.WhenBothNotZero(valid.NotAfterTime(NewConfigParam(appCfg, &appCfg.SearchConfig.EarliestTime)))),
+		va.Field(&sourceCfg.MaxBlobSize, va.Min(0)),
+		va.Field(&sourceCfg.CheckoutExcludePaths, va.Each(va.Required)),
+		va.Field(&sourceCfg.AuthConfig),
+		va.Field(&sourceCfg.HostAuthConfigs),
 	)
 	if err != nil {
 		return
@@ -61,6 +92,51 @@ func (sourceCfg SourceConfig) getSubCfg() (result va.ValidatableWithContext) {
 	return
 }
 
+//
+// Auth
+
+type AuthConfig struct {
+	// The SSH agent is used when there's no key, and the user's known_hosts files when there's no known hosts file
+	SSHKey           string `param:"ssh-key" env:"true"`
+	SSHKeyPassphrase string `param:"ssh-key-passphrase" env:"true"`
+	SSHKnownHosts    string `param:"ssh-known-hosts" env:"true"`
+	SSHHostKeyCheck  string `param:"ssh-host-key-check" env:"true"`
+
+	// A token is used as the password, with "x-access-token" as the username if there isn't one
+	HTTPSUsername string `param:"https-username" env:"true"`
+	HTTPSPassword string `param:"https-password" env:"true"`
+	HTTPSToken    string `param:"https-token" env:"true"`
+}
+
+func (authCfg *AuthConfig) SetDefaults() {
+	if authCfg.SSHHostKeyCheck == "" {
+		authCfg.SSHHostKeyCheck = gitpkg.HostKeyCheckStrict
+	}
+}
+
+func (authCfg AuthConfig) ValidateWithContext(ctx context.Context) (err error) {
+	return va.ValidateStructWithContext(ctx, &authCfg,
+		va.Field(&authCfg.SSHKey, valid.ExistingFile),
+		va.Field(&authCfg.SSHKnownHosts, valid.ExistingFile),
+		va.Field(&authCfg.SSHHostKeyCheck, va.In(gitpkg.HostKeyCheckStrict, gitpkg.HostKeyCheckIgnore)),
+		va.Field(&authCfg.HTTPSToken, va.When(authCfg.HTTPSPassword != "",
+			va.Empty.Error("can't be used together with \"https-password\""))),
+	)
+}
+
+type HostAuthConfig struct {
+	// Host name as it appears in repo URLs, with the port if there is one
+	Host       string `param:"host"`
+	AuthConfig `param:",squash"`
+}
+
+func (hostAuthCfg *HostAuthConfig) ValidateWithContext(ctx context.Context) (err error) {
+	return va.ValidateStructWithContext(ctx, hostAuthCfg,
+		va.Field(&hostAuthCfg.Host, va.Required),
+		va.Field(&hostAuthCfg.AuthConfig),
+	)
+}
+
 //
 // Local provider
 
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"time"
+
+	va "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/verify"
+)
+
+type VerifyConfig struct {
+	Enable bool `param:"enable" env:"true"`
+
+	// Requests per second across all verifiers
+	RateLimit float64       `param:"rate-limit" env:"true"`
+	Timeout   time.Duration `param:"timeout" env:"true"`
+
+	// Base URLs by verifier name, so verifiers can be pointed at local stand-ins
+	Endpoints map[string]string `param:"endpoints"`
+}
+
+func NewVerifyConfig() (result *VerifyConfig) {
+	result = &VerifyConfig{}
+	result.SetDefaults()
+	return
+}
+
+func (verifyCfg *VerifyConfig) SetDefaults() {
+	if verifyCfg.RateLimit == 0 {
+		verifyCfg.RateLimit = 2
+	}
+	if verifyCfg.Timeout == 0 {
+		verifyCfg.Timeout = 10 * time.Second
+	}
+}
+
+func (verifyCfg VerifyConfig) Validate() (err error) {
+	return va.ValidateStruct(&verifyCfg,
+		va.Field(&verifyCfg.RateLimit, va.Min(0.0)),
+		va.Field(&verifyCfg.Timeout, va.Min(time.Millisecond)),
+		va.Field(&verifyCfg.Endpoints, va.By(validEndpoints)),
+	)
+}
+
+func validEndpoints(value interface{}) (err error) {
+	endpoints, _ := value.(map[string]string)
+	validNames := manip.DowncastSlice(verify.ValidVerifierNameValues())
+	for name, baseURL := range endpoints {
+		if err = va.Validate(name, va.In(validNames...)); err != nil {
+			return errors.WithMessagev(err, "invalid verifier name", name)
+		}
+		if err = va.Validate(baseURL, va.Required, is.URL); err != nil {
+			return errors.WithMessagev(err, "invalid endpoint for verifier", name)
+		}
+	}
+	return
+}
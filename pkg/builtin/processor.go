@@ -1,17 +1,34 @@
 package builtin
 
 import (
+	"time"
+
 	"github.com/pantheon-systems/secrets-searcher/pkg/app/config"
 	"github.com/pantheon-systems/secrets-searcher/pkg/entropy"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/dotfile"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/iac"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/keystore"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/regex"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/structured"
 	. "github.com/pantheon-systems/secrets-searcher/pkg/search/rulebuild"
+	"github.com/pantheon-systems/secrets-searcher/pkg/verify"
 )
 
 // Target definitions
 func processorDefinitions() (result []*config.ProcessorConfig) {
 	result = []*config.ProcessorConfig{}
+	// Before the setters, so values they would match are parsed by the more specific processors
+	result = append(result, jwtProcessorDefinitions()...)
+	result = append(result, dsnProcessorDefinitions()...)
+	result = append(result, k8sSecretProcessorDefinitions()...)
+	result = append(result, dotfileProcessorDefinitions()...)
+	result = append(result, iacProcessorDefinitions()...)
+	result = append(result, notebookProcessorDefinitions()...)
+	result = append(result, structuredProcessorDefinitions()...)
 	result = append(result, setterProcessorDefinitions()...)
 	result = append(result, pemProcessorDefinitions()...)
+	result = append(result, keystoreProcessorDefinitions()...)
 	result = append(result, regexProcessorDefinitions()...)
 	//result = append(result, entropyProcessorDefinitions()...)
 	return
@@ -410,6 +427,153 @@ func setterProcessorDefinitions() (result []*config.ProcessorConfig) {
 			},
 		},
 
+		//
+		// Java, Kotlin and Scala setters
+
+		// JVM field and variable assignment, including annotation attributes
+		//
+		// MATCH                             KKKKKKK----VVVV-
+		//       private static final String API_KEY = "shhh";
+		// MATCH      KKKKKK----VVVV-
+		//       this.apiKey = "shhh";
+		//       val apiKey = "shhh"
+		//       @Client(apiKey = "shhh")
+		// MATCH           KKKKKK------------VVVV-
+		//       const val apiKey: String = "shhh"
+		{
+			Name:      JVMFieldAssignSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts: JVMExtPaths(),
+				KeyTmpls: []string{TypedStrVarKey},
+				Operator: EqOper,
+				ValTmpls: []string{DblQuoteVal},
+			},
+		},
+
+		// Spring @Value annotation default
+		//
+		// MATCH ----------KKKKKKKKKKKKK-VVVV--
+		//       @Value("${stripe.api-key:shhh}")
+		{
+			Name:      JVMAnnotationValueDefaultSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts: JVMExtPaths(),
+				MainTmpl: `@Value\(\s{0,10}"\$` + OpenBrace + KeyTmplVar + `:` + ValTmplVar + CloseBrace,
+				KeyTmpls: []string{VarKey},
+				ValTmpls: []string{JustVal},
+			},
+		},
+
+		// MicroProfile @ConfigProperty default
+		//
+		// MATCH ------------------------KKKKKKKKK-----------------VVVV-
+		//       @ConfigProperty(name = "api.token", defaultValue = "shhh")
+		{
+			Name:      JVMConfigPropertyDefaultSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts: JVMExtPaths(),
+				MainTmpl: `name` + Space(EqOper) + KeyTmplVar + `\s{0,10},\s{0,10}defaultValue` + OpVar + ValTmplVar,
+				KeyTmpls: []string{DblQuoteKey},
+				Operator: EqOper,
+				ValTmpls: []string{DblQuoteVal},
+			},
+		},
+
+		//
+		// C# and .NET setters
+
+		// C# field, variable and object initializer assignment
+		//
+		// MATCH                        KKKKKK----VVVV-
+		//       private const string ApiKey = "shhh";
+		// MATCH     KKKKKK-----VVVV-
+		//       var apiKey = @"shhh";
+		//       new Client { ApiKey = "shhh" }
+		{
+			Name:      CSharpVarAssignSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts: CSharpExtPaths(),
+				KeyTmpls: []string{VarKey},
+				Operator: EqOper,
+				ValTmpls: []string{VerbatimDblQuoteVal},
+			},
+		},
+
+		// C# auto-property initializer
+		//
+		// MATCH               KKKKKK-----------------VVVV-
+		//       public string ApiKey { get; set; } = "shhh";
+		{
+			Name:      CSharpPropertyInitializerSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts: CSharpExtPaths(),
+				MainTmpl: KeyTmplVar + `\s{0,10}` + OpenBrace + `[^}]*` + CloseBrace + OpVar + ValTmplVar,
+				KeyTmpls: []string{VarKey},
+				Operator: EqOper,
+				ValTmpls: []string{VerbatimDblQuoteVal},
+			},
+		},
+
+		// .NET config file app setting
+		//
+		// MATCH ----------KKKKKK---------VVVV-
+		//       <add key="ApiKey" value="shhh" />
+		{
+			Name:      DotNetConfigAppSettingSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts: DotNetConfigExtPaths(),
+				MainTmpl: `<add\s{1,10}key=` + KeyTmplVar + `\s{1,10}value=` + ValTmplVar,
+				KeyTmpls: []string{DblQuoteKey},
+				Operator: NoOper,
+				ValTmpls: []string{DblQuoteVal},
+			},
+		},
+
+		// appsettings.json and user secrets field, where keys can be colon-separated paths
+		//
+		// MATCH -KKKKKKKKKKKKKKKK----VVVV-
+		//       "Stripe:SecretKey": "shhh"
+		//       "SecretKey": "shhh"
+		{
+			Name:      AppSettingsJSONFieldSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts: AppSettingsExtPaths(),
+				KeyTmpls: []string{DblQuoteKey},
+				KeyChars: append(Base64PeriodDashUnderscoreChars(), ColonChar),
+				Operator: ColonOper,
+				ValTmpls: []string{DblQuoteVal},
+			},
+		},
+
+		//
+		// PowerShell setters
+
+		// PowerShell variable, environment variable and hashtable assignment
+		//
+		// MATCH -KKKKKK----VVVV-
+		//       $apiKey = "shhh"
+		// MATCH -----KKKKKKK----VVVV-
+		//       $env:API_KEY = 'shhh'
+		// MATCH    KKKKKK----VVVV-
+		//       @{ ApiKey = "shhh" }
+		{
+			Name:      PowerShellVarAssignSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts: PowerShellExtPaths(),
+				KeyTmpls: []string{DollarEnvVarKey},
+				Operator: EqOper,
+				ValTmpls: []string{SingleDblQuoteVal},
+			},
+		},
+
 		//
 		// Config setters
 
@@ -460,6 +624,98 @@ func setterProcessorDefinitions() (result []*config.ProcessorConfig) {
 			},
 		},
 
+		// Java properties file
+		//
+		// MATCH KKKKKKKKKKKKKKKKKKKKKKKKKK-VVVV
+		//       spring.datasource.password=shhh
+		//       spring.datasource.password = shhh
+		//       spring.datasource.password: shhh
+		{
+			Name:      PropertiesFieldSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts: PropertiesExtPaths(),
+				MainTmpl: `^\s{0,10}` + StandardMainTmpl,
+				KeyTmpls: []string{VarKey},
+				Operator: MatchAnyOf(EqOper, ColonOper),
+				ValTmpls: []string{JustVal},
+			},
+		},
+
+		// HCL attribute, like Terraform and Nomad
+		//
+		// MATCH KKKKKKKK----VVVV-
+		//       password = "shhh"
+		// MATCH -KKKKKKK-----VVVV-
+		//       "api_key" = "shhh"
+		//       "api_key": "shhh"
+		{
+			Name:      HCLAttrAssignSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts: HCLExtPaths(),
+				KeyTmpls: []string{DblQuoteNoneKey},
+				Operator: MatchAnyOf(EqOper, ColonOper),
+				ValTmpls: []string{DblQuoteVal},
+			},
+		},
+
+		// HCL variable default on one line
+		//
+		// MATCH ----------KKKKKKKKKKKKK------------------VVVV---
+		//       variable "db_password" { default = "shhh" }
+		{
+			Name:      HCLVariableDefaultSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts: HCLExtPaths(),
+				MainTmpl: `variable\s{1,10}` + KeyTmplVar + `\s{0,10}` + OpenBrace + `[^}]*?default` + OpVar + ValTmplVar,
+				KeyTmpls: []string{DblQuoteKey},
+				Operator: EqOper,
+				ValTmpls: []string{DblQuoteVal},
+			},
+		},
+
+		//
+		// Dockerfile setters
+
+		// Dockerfile ENV instruction
+		//
+		// MATCH ----KKKKKKK-VVVV
+		//       ENV API_KEY=shhh
+		//       ENV API_KEY shhh
+		//       ENV API_KEY="shhh"
+		{
+			Name:      DockerfileEnvSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts:     DockerfileExtPaths(),
+				MainTmpl:     `^\s{0,10}ENV\s{1,10}` + StandardMainTmpl,
+				KeyTmpls:     []string{VarKey},
+				Operator:     `(?:=| {1,10})`, // Equals sign or some whitespace
+				NoWhitespace: true,
+				ValTmpls:     []string{SingleDblQuoteJustVal},
+			},
+		},
+
+		// Dockerfile ARG default
+		//
+		// MATCH ----KKKKKKK-VVVV
+		//       ARG API_KEY=shhh
+		//       ARG API_KEY="shhh"
+		{
+			Name:      DockerfileArgDefaultSetter.String(),
+			Processor: search.Setter.String(),
+			SetterProcessorConfig: config.SetterProcessorConfig{
+				FileExts:     DockerfileExtPaths(),
+				MainTmpl:     `^\s{0,10}ARG\s{1,10}` + StandardMainTmpl,
+				KeyTmpls:     []string{VarKey},
+				Operator:     EqOper,
+				NoWhitespace: true,
+				ValTmpls:     []string{SingleDblQuoteJustVal},
+			},
+		},
+
 		//
 		// Shell setters
 
@@ -691,6 +947,66 @@ func pemProcessorDefinitions() (result []*config.ProcessorConfig) {
 				PEMType: "PGP PRIVATE KEY BLOCK",
 			},
 		},
+
+		// PKCS#8 Private Key PEM processor
+		{
+			Name:      PKCS8PrivateKeyPEM.String(),
+			Processor: search.PEM.String(),
+			PEMProcessorConfig: config.PEMProcessorConfig{
+				PEMType: "PRIVATE KEY",
+			},
+		},
+
+		// Encrypted PKCS#8 Private Key PEM processor
+		{
+			Name:      EncryptedPrivateKeyPEM.String(),
+			Processor: search.PEM.String(),
+			PEMProcessorConfig: config.PEMProcessorConfig{
+				PEMType: "ENCRYPTED PRIVATE KEY",
+			},
+		},
+
+		// DSA Private Key PEM processor
+		{
+			Name:      DSAPrivateKeyPEM.String(),
+			Processor: search.PEM.String(),
+			PEMProcessorConfig: config.PEMProcessorConfig{
+				PEMType: "DSA PRIVATE KEY",
+			},
+		},
+	}
+}
+
+// Keystore processor definitions
+func keystoreProcessorDefinitions() (result []*config.ProcessorConfig) {
+	return []*config.ProcessorConfig{
+
+		// PuTTY private key (.ppk) processor
+		{
+			Name:      PuTTYPrivateKeyKeystore.String(),
+			Processor: search.Keystore.String(),
+			KeystoreProcessorConfig: config.KeystoreProcessorConfig{
+				KeystoreFormat: keystore.PuTTY.Value(),
+			},
+		},
+
+		// Java keystore (JKS/JCEKS) processor
+		{
+			Name:      JavaKeystore.String(),
+			Processor: search.Keystore.String(),
+			KeystoreProcessorConfig: config.KeystoreProcessorConfig{
+				KeystoreFormat: keystore.JKS.Value(),
+			},
+		},
+
+		// PKCS#12 keystore (.p12/.pfx) processor
+		{
+			Name:      PKCS12Keystore.String(),
+			Processor: search.Keystore.String(),
+			KeystoreProcessorConfig: config.KeystoreProcessorConfig{
+				KeystoreFormat: keystore.PKCS12.Value(),
+			},
+		},
 	}
 }
 
@@ -702,6 +1018,7 @@ func regexProcessorDefinitions() (result []*config.ProcessorConfig) {
 		{
 			Name:      SlackTokenRegex.String(),
 			Processor: search.Regex.String(),
+			Verifier:  verify.Slack.Value(),
 			RegexProcessorConfig: config.RegexProcessorConfig{
 				RegexString: `(xox[p|b|o|a]-[0-9]{12}-[0-9]{12}-[0-9]{12}-[a-z0-9]{32})`,
 			},
@@ -738,6 +1055,7 @@ func regexProcessorDefinitions() (result []*config.ProcessorConfig) {
 		{
 			Name:      HerokuAPIKeyRegex.String(),
 			Processor: search.Regex.String(),
+			Verifier:  verify.Heroku.Value(),
 			RegexProcessorConfig: config.RegexProcessorConfig{
 				RegexString: `[h|H][e|E][r|R][o|O][k|K][u|U].*[0-9A-F]{8}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{12}`,
 			},
@@ -770,6 +1088,107 @@ func regexProcessorDefinitions() (result []*config.ProcessorConfig) {
 			},
 		},
 
+		// AWS Access Key ID regex
+		{
+			Name:      AWSAccessKeyIDRegex.String(),
+			Processor: search.Regex.String(),
+			RegexProcessorConfig: config.RegexProcessorConfig{
+				RegexString: `\b((?:AKIA|ASIA|AGPA|AIDA|AROA|AIPA|ANPA|ANVA)[0-9A-Z]{16})\b`,
+			},
+		},
+
+		// AWS Secret Access Key regex
+		{
+			Name:      AWSSecretAccessKeyRegex.String(),
+			Processor: search.Regex.String(),
+			RegexProcessorConfig: config.RegexProcessorConfig{
+				RegexString: `(?i)aws_?(?:secret)?_?(?:access)?_?key['"]?\s*(?::|=>|=)\s*['"]?([A-Za-z0-9/+]{40})(?:[^A-Za-z0-9/+=]|$)`,
+			},
+		},
+
+		// GitHub personal access, OAuth, user-to-server, server-to-server and refresh token regex
+		{
+			Name:      GitHubTokenRegex.String(),
+			Processor: search.Regex.String(),
+			RegexProcessorConfig: config.RegexProcessorConfig{
+				RegexString: `\b((?:ghp|gho|ghu|ghs|ghr)_[0-9A-Za-z]{36})\b`,
+				Checksum:    regex.GitHub.Value(),
+			},
+		},
+
+		// GitHub fine-grained personal access token regex
+		{
+			Name:      GitHubFineGrainedTokenRegex.String(),
+			Processor: search.Regex.String(),
+			RegexProcessorConfig: config.RegexProcessorConfig{
+				RegexString: `\b(github_pat_[0-9A-Za-z]{22}_[0-9A-Za-z]{59})\b`,
+			},
+		},
+
+		// Stripe live secret and restricted key regex
+		{
+			Name:      StripeSecretKeyRegex.String(),
+			Processor: search.Regex.String(),
+			RegexProcessorConfig: config.RegexProcessorConfig{
+				RegexString: `\b((?:sk|rk)_live_[0-9A-Za-z]{24,99})\b`,
+			},
+		},
+
+		// SendGrid API key regex
+		{
+			Name:      SendGridAPIKeyRegex.String(),
+			Processor: search.Regex.String(),
+			RegexProcessorConfig: config.RegexProcessorConfig{
+				RegexString: `\b(SG\.[0-9A-Za-z_-]{22}\.[0-9A-Za-z_-]{43})(?:[^0-9A-Za-z_-]|$)`,
+			},
+		},
+
+		// npm access token regex
+		{
+			Name:      NPMTokenRegex.String(),
+			Processor: search.Regex.String(),
+			RegexProcessorConfig: config.RegexProcessorConfig{
+				RegexString: `\b(npm_[0-9A-Za-z]{36})\b`,
+				Checksum:    regex.NPM.Value(),
+			},
+		},
+
+		// PyPI upload token regex
+		{
+			Name:      PyPITokenRegex.String(),
+			Processor: search.Regex.String(),
+			RegexProcessorConfig: config.RegexProcessorConfig{
+				RegexString: `\b(pypi-AgEIcHlwaS5vcmc[0-9A-Za-z_-]{50,})`,
+			},
+		},
+
+		// Datadog API key regex
+		{
+			Name:      DatadogAPIKeyRegex.String(),
+			Processor: search.Regex.String(),
+			RegexProcessorConfig: config.RegexProcessorConfig{
+				RegexString: `(?i)(?:datadog|dd)_?api_?key['"]?\s*(?::|=>|=)\s*['"]?([0-9a-f]{32})\b`,
+			},
+		},
+
+		// Datadog application key regex
+		{
+			Name:      DatadogAppKeyRegex.String(),
+			Processor: search.Regex.String(),
+			RegexProcessorConfig: config.RegexProcessorConfig{
+				RegexString: `(?i)(?:datadog|dd)_?app(?:lication)?_?key['"]?\s*(?::|=>|=)\s*['"]?([0-9a-f]{40})\b`,
+			},
+		},
+
+		// Azure storage account key regex
+		{
+			Name:      AzureStorageAccountKeyRegex.String(),
+			Processor: search.Regex.String(),
+			RegexProcessorConfig: config.RegexProcessorConfig{
+				RegexString: `AccountKey=([A-Za-z0-9+/]{86}==)`,
+			},
+		},
+
 		// URL Password regex
 		{
 			Name:      URLPasswordRegex.String(),
@@ -790,6 +1209,160 @@ func regexProcessorDefinitions() (result []*config.ProcessorConfig) {
 	}
 }
 
+// JWT processor definitions
+func jwtProcessorDefinitions() (result []*config.ProcessorConfig) {
+	return []*config.ProcessorConfig{
+
+		// JSON Web Token
+		{
+			Name:      JSONWebTokenJWT.String(),
+			Processor: search.JWT.String(),
+			JWTProcessorConfig: config.JWTProcessorConfig{
+				SkipExpiredAfter: 90 * 24 * time.Hour,
+				LongLivedAfter:   7 * 24 * time.Hour,
+			},
+		},
+	}
+}
+
+// DSN processor definitions
+func dsnProcessorDefinitions() (result []*config.ProcessorConfig) {
+	return []*config.ProcessorConfig{
+
+		// Database URLs, JDBC and ADO.NET connection strings
+		{
+			Name:      ConnectionStringDSN.String(),
+			Processor: search.DSN.String(),
+		},
+	}
+}
+
+// Kubernetes Secret processor definitions
+func k8sSecretProcessorDefinitions() (result []*config.ProcessorConfig) {
+	return []*config.ProcessorConfig{
+
+		// Secret manifests, and the template of sealed secrets
+		{
+			Name:      KubernetesSecretManifest.String(),
+			Processor: search.K8sSecret.String(),
+			K8sSecretProcessorConfig: config.K8sSecretProcessorConfig{
+				SecretKinds: []string{"Secret", "SealedSecret"},
+			},
+		},
+	}
+}
+
+// Dotfile processor definitions
+func dotfileProcessorDefinitions() (result []*config.ProcessorConfig) {
+	return []*config.ProcessorConfig{
+
+		// .netrc machine passwords
+		{
+			Name:      NetrcDotfile.String(),
+			Processor: search.Dotfile.String(),
+			DotfileProcessorConfig: config.DotfileProcessorConfig{
+				DotfileFormat: dotfile.Netrc.Value(),
+			},
+		},
+
+		// .npmrc registry tokens
+		{
+			Name:      NpmrcDotfile.String(),
+			Processor: search.Dotfile.String(),
+			DotfileProcessorConfig: config.DotfileProcessorConfig{
+				DotfileFormat: dotfile.Npmrc.Value(),
+			},
+		},
+
+		// .pypirc repository passwords
+		{
+			Name:      PypircDotfile.String(),
+			Processor: search.Dotfile.String(),
+			DotfileProcessorConfig: config.DotfileProcessorConfig{
+				DotfileFormat: dotfile.Pypirc.Value(),
+			},
+		},
+
+		// Docker config.json registry auths
+		{
+			Name:      DockerConfigDotfile.String(),
+			Processor: search.Dotfile.String(),
+			DotfileProcessorConfig: config.DotfileProcessorConfig{
+				DotfileFormat: dotfile.DockerConfig.Value(),
+			},
+		},
+
+		// .git-credentials URLs
+		{
+			Name:      GitCredentialsDotfile.String(),
+			Processor: search.Dotfile.String(),
+			DotfileProcessorConfig: config.DotfileProcessorConfig{
+				DotfileFormat: dotfile.GitCredentials.Value(),
+			},
+		},
+
+		// .htpasswd hashes
+		{
+			Name:      HtpasswdDotfile.String(),
+			Processor: search.Dotfile.String(),
+			DotfileProcessorConfig: config.DotfileProcessorConfig{
+				DotfileFormat: dotfile.Htpasswd.Value(),
+			},
+		},
+	}
+}
+
+// IaC processor definitions
+func iacProcessorDefinitions() (result []*config.ProcessorConfig) {
+	return []*config.ProcessorConfig{
+
+		// Sensitive attributes and outputs in terraform.tfstate
+		{
+			Name:      TerraformStateIaC.String(),
+			Processor: search.IaC.String(),
+			IaCProcessorConfig: config.IaCProcessorConfig{
+				IaCFormat: iac.TerraformState.Value(),
+			},
+		},
+
+		// Defaults of CloudFormation parameters with NoEcho
+		{
+			Name:      CloudFormationNoEchoIaC.String(),
+			Processor: search.IaC.String(),
+			IaCProcessorConfig: config.IaCProcessorConfig{
+				IaCFormat: iac.CloudFormation.Value(),
+			},
+		},
+	}
+}
+
+// Notebook processor definitions
+func notebookProcessorDefinitions() (result []*config.ProcessorConfig) {
+	return []*config.ProcessorConfig{
+
+		// Jupyter notebook cells and outputs, searched with the line processors
+		{
+			Name:      JupyterNotebookCells.String(),
+			Processor: search.Notebook.String(),
+		},
+	}
+}
+
+// Structured file processor definitions
+func structuredProcessorDefinitions() (result []*config.ProcessorConfig) {
+	return []*config.ProcessorConfig{
+
+		// YAML, JSON, TOML, INI, .properties and .env files
+		{
+			Name:      StructuredConfigFile.String(),
+			Processor: search.Structured.String(),
+			StructuredProcessorConfig: config.StructuredProcessorConfig{
+				StructuredFormats: structured.ValidFormatValues(),
+			},
+		},
+	}
+}
+
 // Entropy processor definitions
 func entropyProcessorDefinitions() (result []*config.ProcessorConfig) {
 	return []*config.ProcessorConfig{
@@ -26,9 +26,22 @@ const (
 	RubyHashFieldAssignSetter
 	RubyArrowParamSetter
 	RubyColonParamSetter
+	JVMFieldAssignSetter
+	JVMAnnotationValueDefaultSetter
+	JVMConfigPropertyDefaultSetter
+	CSharpVarAssignSetter
+	CSharpPropertyInitializerSetter
+	DotNetConfigAppSettingSetter
+	AppSettingsJSONFieldSetter
+	PowerShellVarAssignSetter
 	ConfParamSystemdServiceEnvVarSetter
 	ConfParamLogstashStyleSetter
 	ConfParamLogstashStyleEnvVarDefaultSetter
+	PropertiesFieldSetter
+	HCLAttrAssignSetter
+	HCLVariableDefaultSetter
+	DockerfileEnvSetter
+	DockerfileArgDefaultSetter
 	ShellScriptVarAssignSetter
 	ShellCmdParamValSetter
 	YAMLDictFieldValSetter
@@ -43,6 +56,33 @@ const (
 	OpenSSHPrivateKeyPEM
 	ECPrivateKeyPEM
 	PGPPrivateKeyBlockPEM
+	PKCS8PrivateKeyPEM
+	EncryptedPrivateKeyPEM
+	DSAPrivateKeyPEM
+
+	PuTTYPrivateKeyKeystore
+	JavaKeystore
+	PKCS12Keystore
+
+	JSONWebTokenJWT
+
+	ConnectionStringDSN
+
+	KubernetesSecretManifest
+
+	StructuredConfigFile
+
+	NetrcDotfile
+	NpmrcDotfile
+	PypircDotfile
+	DockerConfigDotfile
+	GitCredentialsDotfile
+	HtpasswdDotfile
+
+	TerraformStateIaC
+	CloudFormationNoEchoIaC
+
+	JupyterNotebookCells
 
 	SlackTokenRegex
 	FacebookOAuthRegex
@@ -52,6 +92,17 @@ const (
 	SlackWebhookRegex
 	GCPServiceAccountRegex
 	TwilioAPIKeyRegex
+	AWSAccessKeyIDRegex
+	AWSSecretAccessKeyRegex
+	GitHubTokenRegex
+	GitHubFineGrainedTokenRegex
+	StripeSecretKeyRegex
+	SendGridAPIKeyRegex
+	NPMTokenRegex
+	PyPITokenRegex
+	DatadogAPIKeyRegex
+	DatadogAppKeyRegex
+	AzureStorageAccountKeyRegex
 	URLPasswordRegex
 	GenericSecretRegex
 
@@ -29,39 +29,82 @@ func _() {
 	_ = x[RubyHashFieldAssignSetter-18]
 	_ = x[RubyArrowParamSetter-19]
 	_ = x[RubyColonParamSetter-20]
-	_ = x[ConfParamSystemdServiceEnvVarSetter-21]
-	_ = x[ConfParamLogstashStyleSetter-22]
-	_ = x[ConfParamLogstashStyleEnvVarDefaultSetter-23]
-	_ = x[ShellScriptVarAssignSetter-24]
-	_ = x[ShellCmdParamValSetter-25]
-	_ = x[YAMLDictFieldValSetter-26]
-	_ = x[JSONObjFieldValSetter-27]
-	_ = x[XMLTagValSetter-28]
-	_ = x[XMLTagValKeyAsAttrSetter-29]
-	_ = x[XMLAttrValSetter-30]
-	_ = x[HTMLTableRowValSetter-31]
-	_ = x[GenericSetter-32]
-	_ = x[RSAPrivateKeyPEM-33]
-	_ = x[OpenSSHPrivateKeyPEM-34]
-	_ = x[ECPrivateKeyPEM-35]
-	_ = x[PGPPrivateKeyBlockPEM-36]
-	_ = x[SlackTokenRegex-37]
-	_ = x[FacebookOAuthRegex-38]
-	_ = x[GoogleOAuthRegex-39]
-	_ = x[TwitterRegex-40]
-	_ = x[HerokuAPIKeyRegex-41]
-	_ = x[SlackWebhookRegex-42]
-	_ = x[GCPServiceAccountRegex-43]
-	_ = x[TwilioAPIKeyRegex-44]
-	_ = x[URLPasswordRegex-45]
-	_ = x[GenericSecretRegex-46]
-	_ = x[Base64Entropy-47]
-	_ = x[HexEntropy-48]
+	_ = x[JVMFieldAssignSetter-21]
+	_ = x[JVMAnnotationValueDefaultSetter-22]
+	_ = x[JVMConfigPropertyDefaultSetter-23]
+	_ = x[CSharpVarAssignSetter-24]
+	_ = x[CSharpPropertyInitializerSetter-25]
+	_ = x[DotNetConfigAppSettingSetter-26]
+	_ = x[AppSettingsJSONFieldSetter-27]
+	_ = x[PowerShellVarAssignSetter-28]
+	_ = x[ConfParamSystemdServiceEnvVarSetter-29]
+	_ = x[ConfParamLogstashStyleSetter-30]
+	_ = x[ConfParamLogstashStyleEnvVarDefaultSetter-31]
+	_ = x[PropertiesFieldSetter-32]
+	_ = x[HCLAttrAssignSetter-33]
+	_ = x[HCLVariableDefaultSetter-34]
+	_ = x[DockerfileEnvSetter-35]
+	_ = x[DockerfileArgDefaultSetter-36]
+	_ = x[ShellScriptVarAssignSetter-37]
+	_ = x[ShellCmdParamValSetter-38]
+	_ = x[YAMLDictFieldValSetter-39]
+	_ = x[JSONObjFieldValSetter-40]
+	_ = x[XMLTagValSetter-41]
+	_ = x[XMLTagValKeyAsAttrSetter-42]
+	_ = x[XMLAttrValSetter-43]
+	_ = x[HTMLTableRowValSetter-44]
+	_ = x[GenericSetter-45]
+	_ = x[RSAPrivateKeyPEM-46]
+	_ = x[OpenSSHPrivateKeyPEM-47]
+	_ = x[ECPrivateKeyPEM-48]
+	_ = x[PGPPrivateKeyBlockPEM-49]
+	_ = x[PKCS8PrivateKeyPEM-50]
+	_ = x[EncryptedPrivateKeyPEM-51]
+	_ = x[DSAPrivateKeyPEM-52]
+	_ = x[PuTTYPrivateKeyKeystore-53]
+	_ = x[JavaKeystore-54]
+	_ = x[PKCS12Keystore-55]
+	_ = x[JSONWebTokenJWT-56]
+	_ = x[ConnectionStringDSN-57]
+	_ = x[KubernetesSecretManifest-58]
+	_ = x[StructuredConfigFile-59]
+	_ = x[NetrcDotfile-60]
+	_ = x[NpmrcDotfile-61]
+	_ = x[PypircDotfile-62]
+	_ = x[DockerConfigDotfile-63]
+	_ = x[GitCredentialsDotfile-64]
+	_ = x[HtpasswdDotfile-65]
+	_ = x[TerraformStateIaC-66]
+	_ = x[CloudFormationNoEchoIaC-67]
+	_ = x[JupyterNotebookCells-68]
+	_ = x[SlackTokenRegex-69]
+	_ = x[FacebookOAuthRegex-70]
+	_ = x[GoogleOAuthRegex-71]
+	_ = x[TwitterRegex-72]
+	_ = x[HerokuAPIKeyRegex-73]
+	_ = x[SlackWebhookRegex-74]
+	_ = x[GCPServiceAccountRegex-75]
+	_ = x[TwilioAPIKeyRegex-76]
+	_ = x[AWSAccessKeyIDRegex-77]
+	_ = x[AWSSecretAccessKeyRegex-78]
+	_ = x[GitHubTokenRegex-79]
+	_ = x[GitHubFineGrainedTokenRegex-80]
+	_ = x[StripeSecretKeyRegex-81]
+	_ = x[SendGridAPIKeyRegex-82]
+	_ = x[NPMTokenRegex-83]
+	_ = x[PyPITokenRegex-84]
+	_ = x[DatadogAPIKeyRegex-85]
+	_ = x[DatadogAppKeyRegex-86]
+	_ = x[AzureStorageAccountKeyRegex-87]
+	_ = x[URLPasswordRegex-88]
+	_ = x[GenericSecretRegex-89]
+	_ = x[Base64Entropy-90]
+	_ = x[HexEntropy-91]
 }
 
-const _ProcessorName_name = "URLPathParamValSetterURLQueryStringParamValSetterPyVarAssignSetterPyDictFieldAssignSetterPyDictLiteralFieldSetterPyTupleSetterPHPVarAssignSetterPHPAssocArrayFieldAssignSetterPHPAssocArrayLiteralFieldSetterPHPConstDefineSetterJSVarAssignSetterJSObjFieldAssignSetterJSObjLiteralFieldSetterGoVarAssignSetterGoHashFieldAssignSetterGoHashLiteralFieldSetterGoFlagDefaultValSetterRubyVarAssignSetterRubyHashFieldAssignSetterRubyArrowParamSetterRubyColonParamSetterConfParamSystemdServiceEnvVarSetterConfParamLogstashStyleSetterConfParamLogstashStyleEnvVarDefaultSetterShellScriptVarAssignSetterShellCmdParamValSetterYAMLDictFieldValSetterJSONObjFieldValSetterXMLTagValSetterXMLTagValKeyAsAttrSetterXMLAttrValSetterHTMLTableRowValSetterGenericSetterRSAPrivateKeyPEMOpenSSHPrivateKeyPEMECPrivateKeyPEMPGPPrivateKeyBlockPEMSlackTokenRegexFacebookOAuthRegexGoogleOAuthRegexTwitterRegexHerokuAPIKeyRegexSlackWebhookRegexGCPServiceAccountRegexTwilioAPIKeyRegexURLPasswordRegexGenericSecretRegexBase64EntropyHexEntropy"
+const _ProcessorName_name = "URLPathParamValSetterURLQueryStringParamValSetterPyVarAssignSetterPyDictFieldAssignSetterPyDictLiteralFieldSetterPyTupleSetterPHPVarAssignSetterPHPAssocArrayFieldAssignSetterPHPAssocArrayLiteralFieldSetterPHPConstDefineSetterJSVarAssignSetterJSObjFieldAssignSetterJSObjLiteralFieldSetterGoVarAssignSetterGoHashFieldAssignSetterGoHashLiteralFieldSetterGoFlagDefaultValSetterRubyVarAssignSetterRubyHashFieldAssignSetterRubyArrowParamSetterRubyColonParamSetterJVMFieldAssignSetterJVMAnnotationValueDefaultSetterJVMConfigPropertyDefaultSetterCSharpVarAssignSetterCSharpPropertyInitializerSetterDotNetConfigAppSettingSetterAppSettingsJSONFieldSetterPowerShellVarAssignSetterConfParamSystemdServiceEnvVarSetterConfParamLogstashStyleSetterConfParamLogstashStyleEnvVarDefaultSetterPropertiesFieldSetterHCLAttrAssignSetterHCLVariableDefaultSetterDockerfileEnvSetterDockerfileArgDefaultSetterShellScriptVarAssignSetterShellCmdParamValSetterYAMLDictFieldValSetterJSONObjFieldValSetterXMLTagValSetterXMLTagValKeyAsAttrSetterXMLAttrValSetterHTMLTableRowValSetterGenericSetterRSAPrivateKeyPEMOpenSSHPrivateKeyPEMECPrivateKeyPEMPGPPrivateKeyBlockPEMPKCS8PrivateKeyPEMEncryptedPrivateKeyPEMDSAPrivateKeyPEMPuTTYPrivateKeyKeystoreJavaKeystorePKCS12KeystoreJSONWebTokenJWTConnectionStringDSNKubernetesSecretManifestStructuredConfigFileNetrcDotfileNpmrcDotfilePypircDotfileDockerConfigDotfileGitCredentialsDotfileHtpasswdDotfileTerraformStateIaCCloudFormationNoEchoIaCJupyterNotebookCellsSlackTokenRegexFacebookOAuthRegexGoogleOAuthRegexTwitterRegexHerokuAPIKeyRegexSlackWebhookRegexGCPServiceAccountRegexTwilioAPIKeyRegexAWSAccessKeyIDRegexAWSSecretAccessKeyRegexGitHubTokenRegexGitHubFineGrainedTokenRegexStripeSecretKeyRegexSendGridAPIKeyRegexNPMTokenRegexPyPITokenRegexDatadogAPIKeyRegexDatadogAppKeyRegexAzureStorageAccountKeyRegexURLPasswordRegexGenericSecretRegexBase64EntropyHexEntropy"
 
-var _ProcessorName_index = [...]uint16{0, 21, 49, 66, 89, 113, 126, 144, 174, 205, 225, 242, 264, 287, 304, 327, 351, 373, 392, 417, 437, 457, 492, 520, 561, 587, 609, 631, 652, 667, 691, 707, 728, 741, 757, 777, 792, 813, 828, 846, 862, 874, 891, 908, 930, 947, 963, 981, 994, 1004}
+var _ProcessorName_index = [...]uint16{0, 21, 49, 66, 89, 113, 126, 144, 174, 205, 225, 242, 264, 287, 304, 327, 351, 373, 392, 417, 437, 457, 477, 508, 538, 559, 590, 618, 644, 669, 704, 732, 773, 794, 813, 837, 856, 882, 908, 930, 952, 973, 988, 1012, 1028, 1049, 1062, 1078, 1098, 1113, 1134, 1152, 1174, 1190, 1213, 1225, 1239, 1254, 1273, 1297, 1317, 1329, 1341, 1354, 1373, 1394, 1409, 1426, 1449, 1469, 1484, 1502, 1518, 1530, 1547, 1564, 1586, 1603, 1622, 1645, 1661, 1688, 1708, 1727, 1740, 1754, 1772, 1790, 1817, 1833, 1851, 1864, 1874}
 
 func (i ProcessorName) String() string {
 	if i < 0 || i >= ProcessorName(len(_ProcessorName_index)-1) {
@@ -2,6 +2,8 @@ package database
 
 import (
 	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
 )
 
 type (
@@ -21,19 +23,21 @@ type (
 
 	// Finding
 	Finding struct {
-		ID           string
-		CommitID     string
-		SecretID     string
-		Processor    string
-		Path         string
-		StartLineNum int
-		StartIndex   int
-		EndLineNum   int
-		EndIndex     int
-		BeforeCode   string
-		Code         string
-		AfterCode    string
-		FileBasename string
+		ID             string
+		CommitID       string
+		SecretID       string
+		Processor      string
+		Path           string
+		StartLineNum   int
+		StartIndex     int
+		EndLineNum     int
+		EndIndex       int
+		BeforeCode     string
+		Code           string
+		AfterCode      string
+		FileBasename   string
+		Section        string
+		SectionLineNum int
 	}
 	Findings      []*Finding
 	FindingGroups map[string]Findings
@@ -77,6 +81,22 @@ type (
 	SecretExtras      []*SecretExtra
 	SecretExtraGroups map[string]SecretExtras
 
+	// Certificate
+	Certificate struct {
+		ID          string
+		Fingerprint string
+		Subject     string
+		SANs        []string
+		Issuer      string
+		NotBefore   time.Time
+		NotAfter    time.Time
+		RepoName    string
+		CommitHash  string
+		Path        string
+	}
+	Certificates      []*Certificate
+	CertificateGroups map[string]Certificates
+
 	// Repo
 	Repo struct {
 		ID             string
@@ -86,4 +106,78 @@ type (
 	}
 	Repos      []*Repo
 	RepoGroups map[string]Repos
+
+	// ScanMark
+	// Where a repo's ref pointed when it was last searched successfully. Kept between runs.
+	ScanMark struct {
+		ID             string
+		RepoID         string
+		RepoName       string
+		Ref            string
+		CommitHash     string
+		RulesetVersion string
+		Date           time.Time
+	}
+	ScanMarks      []*ScanMark
+	ScanMarkGroups map[string]ScanMarks
+
+	// Checkpoint
+	// A search job (a chunk of a repo's commits) that was completed, so a resumed search can skip it
+	Checkpoint struct {
+		ID          string
+		RepoID      string
+		RepoName    string
+		JobName     string
+		CommitCount int
+		Date        time.Time
+	}
+
+	// Skip
+	// A file change that wasn't searched completely because it hit a resource limit
+	Skip struct {
+		ID         string
+		RepoName   string
+		CommitHash string
+		Path       string
+		Reason     string
+		Detail     string
+	}
+	Skips []*Skip
+
+	// BlobScan
+	// What the processors submitted for a blob, so it doesn't need to be searched again.
+	// Kept between runs, the path and ruleset version are part of the ID.
+	BlobScan struct {
+		ID             string
+		BlobHash       string
+		Path           string
+		RulesetVersion string
+		WholeBlob      bool
+		SearchedLines  []int
+		Events         []*BlobScanEvent
+	}
+	BlobScanEvent struct {
+		Processor        string
+		Ignore           bool
+		FileRange        *manip.FileRange
+		ContextFileRange *manip.FileRange
+		SecretValue      string
+		FileBasename     string
+		SecretExtras     []*BlobScanExtra
+		FindingExtras    []*BlobScanExtra
+		Section          *BlobScanSection
+	}
+	BlobScanExtra struct {
+		Key    string
+		Header string
+		Value  string
+		Code   bool
+		URL    string
+		Debug  bool
+	}
+	BlobScanSection struct {
+		Name      string
+		Contents  string
+		FileRange *manip.FileRange
+	}
 )
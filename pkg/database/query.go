@@ -10,20 +10,28 @@ import (
 )
 
 const (
+	blobScanTable     = "blob-scan"
+	certificateTable  = "certificate"
+	checkpointTable   = "checkpoint"
 	commitTable       = "commit"
 	findingTable      = "finding"
 	findingExtraTable = "finding-extra"
 	repoTable         = "repo"
+	scanMarkTable     = "scan-mark"
 	secretTable       = "secret"
 	secretExtraTable  = "secret-extra"
+	skipTable         = "skip"
 )
 
 var searchTables = []string{
+	certificateTable,
+	checkpointTable,
 	commitTable,
 	findingTable,
 	findingExtraTable,
 	secretTable,
 	secretExtraTable,
+	skipTable,
 }
 
 func (d *Database) DeleteSearchTables() (err error) {
@@ -196,6 +204,182 @@ func (d *Database) SortRepos(objs Repos) {
 	sort.Slice(objs, func(i, j int) bool { return strings.ToLower(objs[i].Name) < strings.ToLower(objs[j].Name) })
 }
 
+// Scan mark
+
+func (d *Database) GetScanMarks() (result ScanMarks, err error) {
+	var lines []string
+	lines, err = d.readAll(scanMarkTable)
+	if err != nil {
+		err = errors.WithMessage(err, "unable to get scan marks")
+		return
+	}
+
+	result = make(ScanMarks, len(lines))
+	for i, line := range lines {
+		var obj *ScanMark
+		if err = json.Unmarshal([]byte(line), &obj); err != nil {
+			return
+		}
+		result[i] = obj
+	}
+
+	return
+}
+
+func (d *Database) GetScanMarksGroupedByRepoID() (result ScanMarkGroups, err error) {
+	var objs ScanMarks
+	objs, err = d.GetScanMarks()
+	if err != nil {
+		err = errors.WithMessage(err, "unable to get grouped scan marks")
+		return
+	}
+
+	result = make(ScanMarkGroups)
+	for _, obj := range objs {
+		result[obj.RepoID] = append(result[obj.RepoID], obj)
+	}
+
+	return
+}
+
+func (d *Database) WriteScanMark(obj *ScanMark) (err error) {
+	return d.write(scanMarkTable, obj.ID, obj)
+}
+
+func (d *Database) DeleteScanMark(id string) (err error) {
+	return d.delete(scanMarkTable, id)
+}
+
+// Checkpoint
+
+func (d *Database) CheckpointExists(id string) bool {
+	return d.exists(checkpointTable, id)
+}
+
+func (d *Database) WriteCheckpoint(obj *Checkpoint) (err error) {
+	return d.write(checkpointTable, obj.ID, obj)
+}
+
+// Skip
+
+func (d *Database) GetSkips() (result Skips, err error) {
+	var lines []string
+	lines, err = d.readAll(skipTable)
+	if err != nil {
+		err = errors.WithMessage(err, "unable to get skips")
+		return
+	}
+
+	result = make(Skips, len(lines))
+	for i, line := range lines {
+		var obj *Skip
+		if err = json.Unmarshal([]byte(line), &obj); err != nil {
+			return
+		}
+		result[i] = obj
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].RepoName != result[j].RepoName {
+			return result[i].RepoName < result[j].RepoName
+		}
+		if result[i].CommitHash != result[j].CommitHash {
+			return result[i].CommitHash < result[j].CommitHash
+		}
+		return result[i].Path < result[j].Path
+	})
+
+	return
+}
+
+func (d *Database) WriteSkip(obj *Skip) (err error) {
+	return d.write(skipTable, obj.ID, obj)
+}
+
+// Blob scan
+
+func (d *Database) BlobScanExists(id string) bool {
+	return d.exists(blobScanTable, id)
+}
+
+// Blob scans from other ruleset versions can't be replayed anymore
+func (d *Database) DeleteBlobScansExcept(rulesetVersion string) (deleted int, err error) {
+	var lines []string
+	lines, err = d.readAll(blobScanTable)
+	if err != nil {
+		err = errors.WithMessage(err, "unable to get blob scans")
+		return
+	}
+
+	for _, line := range lines {
+		var obj *BlobScan
+		if err = json.Unmarshal([]byte(line), &obj); err != nil {
+			return
+		}
+		if obj.RulesetVersion == rulesetVersion {
+			continue
+		}
+		if err = d.delete(blobScanTable, obj.ID); err != nil {
+			err = errors.Wrapv(err, "unable to delete blob scan", obj.ID)
+			return
+		}
+		deleted++
+	}
+
+	return
+}
+
+func (d *Database) GetBlobScan(id string) (result *BlobScan, err error) {
+	err = d.read(blobScanTable, id, &result)
+	return
+}
+
+func (d *Database) WriteBlobScan(obj *BlobScan) (err error) {
+	return d.write(blobScanTable, obj.ID, obj)
+}
+
+// Certificate
+
+func (d *Database) GetCertificates() (result Certificates, err error) {
+	var lines []string
+	lines, err = d.readAll(certificateTable)
+	if err != nil {
+		err = errors.WithMessage(err, "unable to get certificates")
+		return
+	}
+
+	result = make(Certificates, len(lines))
+	for i, line := range lines {
+		var obj *Certificate
+		if err = json.Unmarshal([]byte(line), &obj); err != nil {
+			return
+		}
+		result[i] = obj
+	}
+
+	return
+}
+
+func (d *Database) GetCertificatesGroupedByFingerprint() (result CertificateGroups, err error) {
+	var objs Certificates
+	objs, err = d.GetCertificates()
+	if err != nil {
+		err = errors.WithMessage(err, "unable to get grouped certificates")
+		return
+	}
+
+	result = make(CertificateGroups)
+	for _, obj := range objs {
+		result[obj.Fingerprint] = append(result[obj.Fingerprint], obj)
+	}
+
+	return
+}
+
+func (d *Database) WriteCertificateIfNotExists(obj *Certificate) (created bool, err error) {
+	return d.writeIfNotExists(certificateTable, obj.ID, obj)
+}
+
 // Commit
 
 func (d *Database) CommitTableExists() bool {
@@ -0,0 +1,275 @@
+package git
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	"golang.org/x/crypto/ssh"
+	gittransport "gopkg.in/src-d/go-git.v4/plumbing/transport"
+	githttp "gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+	gitssh "gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
+)
+
+// How SSH host keys are checked
+const (
+	HostKeyCheckStrict = "strict"
+	HostKeyCheckIgnore = "ignore"
+)
+
+// Environment variables the askpass script answers git and ssh prompts with
+const (
+	askPassUsernameEnvVar = "SECRETS_SEARCHER_GIT_USERNAME"
+	askPassPasswordEnvVar = "SECRETS_SEARCHER_GIT_PASSWORD"
+)
+
+// Credentials are handed to the git CLI through the environment instead of its arguments,
+// so they don't show up in process lists
+const askPassScript = `#!/bin/sh
+case "$1" in
+Username*) printf '%s\n' "$` + askPassUsernameEnvVar + `" ;;
+*) printf '%s\n' "$` + askPassPasswordEnvVar + `" ;;
+esac
+`
+
+type (
+	// Credentials for cloning and fetching from a host
+	Auth struct {
+		// The SSH agent is used when there's no key
+		SSHKeyPath       string
+		SSHKeyPassphrase string
+
+		// The user's known_hosts files are used when there's no path
+		SSHKnownHostsPath string
+		SSHHostKeyCheck   string
+
+		HTTPSUsername string
+		HTTPSPassword string
+	}
+
+	// Auth by host, and the auth for any other host
+	Credentials struct {
+		defaultAuth *Auth
+		hostAuths   map[string]*Auth
+	}
+)
+
+// Default auth is optional, hosts without their own auth use none when it's nil
+func NewCredentials(defaultAuth *Auth, hostAuths map[string]*Auth) *Credentials {
+	return &Credentials{
+		defaultAuth: defaultAuth,
+		hostAuths:   hostAuths,
+	}
+}
+
+// The URL without credentials, and the auth to use with it. Credentials in an HTTPS URL
+// are used when none are configured for its host.
+func (c *Credentials) forURL(rawURL string) (cleanURL string, auth *Auth, endpoint *gittransport.Endpoint) {
+	var err error
+	if endpoint, err = gittransport.NewEndpoint(rawURL); err != nil {
+		return rawURL, nil, nil
+	}
+
+	var urlAuth *Auth
+	if isHTTP(endpoint) && (endpoint.User != "" || endpoint.Password != "") {
+		urlAuth = &Auth{HTTPSUsername: endpoint.User, HTTPSPassword: endpoint.Password}
+	}
+	cleanURL = RedactURL(rawURL)
+	if endpoint, err = gittransport.NewEndpoint(cleanURL); err != nil {
+		return cleanURL, nil, nil
+	}
+
+	if c != nil {
+		auth = c.forHost(endpoint)
+	}
+	if urlAuth != nil && !auth.hasHTTPS() {
+		auth = urlAuth
+	}
+
+	return
+}
+
+// Auth for the host with its port is used before auth for the host
+func (c *Credentials) forHost(endpoint *gittransport.Endpoint) *Auth {
+	if endpoint.Port != 0 {
+		if auth, ok := c.hostAuths[fmt.Sprintf("%s:%d", endpoint.Host, endpoint.Port)]; ok {
+			return auth
+		}
+	}
+	if auth, ok := c.hostAuths[endpoint.Host]; ok {
+		return auth
+	}
+
+	return c.defaultAuth
+}
+
+// The URL without any password, or any user for HTTP URLs, where the user can be a token.
+// It's safe to log and to save.
+func RedactURL(rawURL string) string {
+	endpoint, err := gittransport.NewEndpoint(rawURL)
+	if err != nil || (endpoint.Password == "" && (!isHTTP(endpoint) || endpoint.User == "")) {
+		return rawURL
+	}
+
+	endpoint.Password = ""
+	if isHTTP(endpoint) {
+		endpoint.User = ""
+	}
+
+	return endpoint.String()
+}
+
+// Nil for the transport's defaults
+func (a *Auth) goGitAuth(endpoint *gittransport.Endpoint) (result gittransport.AuthMethod, err error) {
+	if a == nil || endpoint == nil {
+		return
+	}
+
+	if isHTTP(endpoint) {
+		if a.hasHTTPS() {
+			result = &githttp.BasicAuth{Username: a.HTTPSUsername, Password: a.HTTPSPassword}
+		}
+		return
+	}
+
+	if endpoint.Protocol != "ssh" {
+		return
+	}
+
+	user := endpoint.User
+	if user == "" {
+		user = gitssh.DefaultUsername
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	if a.SSHHostKeyCheck == HostKeyCheckIgnore {
+		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	} else {
+		var knownHostsPaths []string
+		if a.SSHKnownHostsPath != "" {
+			knownHostsPaths = []string{a.SSHKnownHostsPath}
+		}
+		if hostKeyCallback, err = gitssh.NewKnownHostsCallback(knownHostsPaths...); err != nil {
+			err = errors.Wrap(err, "unable to load known hosts")
+			return
+		}
+	}
+
+	if a.SSHKeyPath == "" {
+		var agentAuth *gitssh.PublicKeysCallback
+		if agentAuth, err = gitssh.NewSSHAgentAuth(user); err != nil {
+			err = errors.Wrap(err, "unable to use SSH agent")
+			return
+		}
+		agentAuth.HostKeyCallback = hostKeyCallback
+		return agentAuth, nil
+	}
+
+	var keyAuth *gitssh.PublicKeys
+	if keyAuth, err = gitssh.NewPublicKeysFromFile(user, a.SSHKeyPath, a.SSHKeyPassphrase); err != nil {
+		err = errors.Wrapv(err, "unable to load SSH key", a.SSHKeyPath)
+		return
+	}
+	keyAuth.HostKeyCallback = hostKeyCallback
+
+	return keyAuth, nil
+}
+
+// Environment for the git CLI, and a func that removes the askpass script
+func (a *Auth) cliEnv(endpoint *gittransport.Endpoint) (result []string, cleanup func(), err error) {
+	cleanup = func() {}
+	if a == nil || endpoint == nil {
+		return
+	}
+
+	var password string
+	if isHTTP(endpoint) {
+		if !a.hasHTTPS() {
+			return
+		}
+		password = a.HTTPSPassword
+		result = append(result, askPassUsernameEnvVar+"="+a.HTTPSUsername)
+	} else if endpoint.Protocol == "ssh" {
+		result = append(result, "GIT_SSH_COMMAND="+a.sshCommand())
+		if a.SSHKeyPassphrase == "" {
+			return
+		}
+		password = a.SSHKeyPassphrase
+	} else {
+		return
+	}
+
+	var scriptPath string
+	if scriptPath, err = writeAskPassScript(); err != nil {
+		err = errors.WithMessage(err, "unable to write askpass script")
+		return
+	}
+	cleanup = func() { _ = os.Remove(scriptPath) }
+
+	result = append(result,
+		askPassPasswordEnvVar+"="+password,
+		"GIT_ASKPASS="+scriptPath,
+		"SSH_ASKPASS="+scriptPath,
+		"SSH_ASKPASS_REQUIRE=force",
+	)
+
+	// Older versions of ssh only use the askpass program when there's a display
+	if os.Getenv("DISPLAY") == "" {
+		result = append(result, "DISPLAY=:0")
+	}
+
+	return
+}
+
+func (a *Auth) hasHTTPS() bool {
+	return a != nil && (a.HTTPSUsername != "" || a.HTTPSPassword != "")
+}
+
+func (a *Auth) sshCommand() string {
+	args := []string{"ssh"}
+
+	if a.SSHKeyPath != "" {
+		args = append(args, "-i", shellQuote(a.SSHKeyPath), "-o", "IdentitiesOnly=yes")
+	}
+
+	if a.SSHHostKeyCheck == HostKeyCheckIgnore {
+		args = append(args, "-o", "StrictHostKeyChecking=no", "-o", "UserKnownHostsFile=/dev/null")
+	} else {
+		args = append(args, "-o", "StrictHostKeyChecking=yes")
+		if a.SSHKnownHostsPath != "" {
+			args = append(args, "-o", "UserKnownHostsFile="+shellQuote(a.SSHKnownHostsPath))
+		}
+	}
+
+	return strings.Join(args, " ")
+}
+
+func writeAskPassScript() (result string, err error) {
+	var file *os.File
+	if file, err = ioutil.TempFile("", "secrets-searcher-askpass-"); err != nil {
+		err = errors.Wrap(err, "unable to create file")
+		return
+	}
+	defer file.Close()
+
+	if _, err = file.WriteString(askPassScript); err != nil {
+		err = errors.Wrap(err, "unable to write file")
+		return
+	}
+	if err = file.Chmod(0700); err != nil {
+		err = errors.Wrap(err, "unable to make file executable")
+		return
+	}
+
+	return file.Name(), nil
+}
+
+func isHTTP(endpoint *gittransport.Endpoint) bool {
+	return endpoint.Protocol == "http" || endpoint.Protocol == "https"
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
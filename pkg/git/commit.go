@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	gitplumbing "gopkg.in/src-d/go-git.v4/plumbing"
 	gitobject "gopkg.in/src-d/go-git.v4/plumbing/object"
 )
 
@@ -18,7 +19,11 @@ type (
 		AuthorEmail string
 		Oldest      bool
 		Tree        *Tree
-		gitCommit   *gitobject.Commit
+
+		// At the edge of a shallow clone, so its parents weren't fetched and it's treated as fully added
+		Shallow bool
+
+		gitCommit *gitobject.Commit
 		commitState
 	}
 	commitState struct {
@@ -44,6 +49,7 @@ func newCommit(repository *Repository, gitCommit *gitobject.Commit) (result *Com
 		AuthorName:  gitCommit.Author.Name,
 		AuthorEmail: gitCommit.Author.Email,
 		Tree:        tree,
+		Shallow:     repository.shallow[gitCommit.Hash],
 		gitCommit:   gitCommit,
 		commitState: commitState{
 			fileContentIndex: map[string]string{},
@@ -57,7 +63,7 @@ func newCommit(repository *Repository, gitCommit *gitobject.Commit) (result *Com
 func (c *Commit) Parents() (result []*Commit, err error) {
 	defer errors.CatchPanicSetErr(&err, "unable to retrieve parent commits")
 
-	if c.parentsCache != nil {
+	if c.parentsCache != nil || c.Shallow {
 		result = c.parentsCache
 		return
 	}
@@ -89,7 +95,7 @@ func (c *Commit) IsMergeCommit() (result bool, err error) {
 }
 
 func (c *Commit) CanDiff() (result bool) {
-	if c.Oldest {
+	if c.Oldest || c.Shallow {
 		return true
 	}
 
@@ -107,6 +113,15 @@ func (c *Commit) CanDiff() (result bool) {
 }
 
 func (c *Commit) FileChanges(filter *FileChangeFilter) (result []*FileChange, err error) {
+	err = c.EachFileChange(filter, func(fileChange *FileChange) error {
+		result = append(result, fileChange)
+		return nil
+	})
+	return
+}
+
+// File changes are built one at a time, so a huge commit doesn't have to be held in memory at once
+func (c *Commit) EachFileChange(filter *FileChangeFilter, fn func(fileChange *FileChange) error) (err error) {
 
 	// Are we going to get
 	if !c.CanDiff() {
@@ -126,7 +141,7 @@ func (c *Commit) FileChanges(filter *FileChangeFilter) (result []*FileChange, er
 	var parentCommitTree *Tree
 	if parentsLen == 1 {
 		parentCommitTree = parents[0].Tree
-	} else if c.Oldest {
+	} else if c.Oldest || c.Shallow {
 		parentCommitTree = c.repository.EmptyTree()
 	} else {
 		err = errors.New("unable to find parent (we shouldn't be here since we're calling CanDiff above)")
@@ -135,7 +150,7 @@ func (c *Commit) FileChanges(filter *FileChangeFilter) (result []*FileChange, er
 
 	// Diff them together
 	var gitFileChanges gitobject.Changes
-	gitFileChanges, err = parentCommitTree.wrapDiff(c.Tree)
+	gitFileChanges, err = c.repository.diffTrees(parentCommitTree, c.Tree)
 	if err != nil {
 		err = errors.WithMessage(err, "unable to diff")
 		return
@@ -144,26 +159,48 @@ func (c *Commit) FileChanges(filter *FileChangeFilter) (result []*FileChange, er
 	// For each file change, build a FileChange object
 	for _, gitFileChange := range gitFileChanges {
 		var fileChange *FileChange
-		if fileChange, err = NewFileChange(c, gitFileChange); err != nil {
+
+		// Too large to diff
+		if filter != nil && filter.MaxBlobSize > 0 {
+			fileChange, err = newTooLargeFileChange(c, gitFileChange, filter.MaxBlobSize)
+		}
+		if fileChange == nil && err == nil {
+			fileChange, err = NewFileChange(c, gitFileChange)
+		}
+
+		// Left out of a partial clone
+		if errors.WasCausedBy(err, gitplumbing.ErrObjectNotFound) {
+			fileChange, err = newNotFetchedFileChange(c, gitFileChange), nil
+		}
+		if err != nil {
 			err = errors.WithMessage(err, "unable build file change")
 			return
 		}
 		if filter != nil && !filter.Includes(fileChange) {
 			continue
 		}
-		result = append(result, fileChange)
+		if err = fn(fileChange); err != nil {
+			return
+		}
 	}
 
 	return
 }
 
+// File changes of a commit can be searched concurrently
 func (c *Commit) FileContents(path string) (result string, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
 	var ok bool
 	result, ok = c.fileContentIndex[path]
 	if ok {
 		return
 	}
 
+	c.repository.mutex.Lock()
+	defer c.repository.mutex.Unlock()
+
 	var file *gitobject.File
 	file, err = c.gitCommit.File(path)
 	if err != nil {
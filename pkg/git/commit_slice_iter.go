@@ -0,0 +1,47 @@
+package git
+
+import (
+	"io"
+
+	gitobject "gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+)
+
+// Commits that are already loaded, as a go-git commit iterator
+type commitSliceIter struct {
+	commits []*gitobject.Commit
+	pos     int
+}
+
+func newCommitSliceIter(commits []*gitobject.Commit) *commitSliceIter {
+	return &commitSliceIter{commits: commits}
+}
+
+func (i *commitSliceIter) Next() (result *gitobject.Commit, err error) {
+	if i.pos >= len(i.commits) {
+		return nil, io.EOF
+	}
+
+	result = i.commits[i.pos]
+	i.pos++
+
+	return
+}
+
+func (i *commitSliceIter) ForEach(fn func(*gitobject.Commit) error) (err error) {
+	for {
+		var commit *gitobject.Commit
+		if commit, err = i.Next(); err == io.EOF {
+			return nil
+		}
+		if err = fn(commit); err == storer.ErrStop {
+			return nil
+		} else if err != nil {
+			return
+		}
+	}
+}
+
+func (i *commitSliceIter) Close() {
+	i.pos = len(i.commits)
+}
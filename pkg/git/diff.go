@@ -66,6 +66,18 @@ func (d *Diff) String() (result string) {
 	return strings.Join(d.lineStrings, "\n")
 }
 
+// File line numbers of the added lines, without moving the current line
+func (d *Diff) AddedLineNums() (result map[int]bool) {
+	result = map[int]bool{}
+	for lineNum := 1; lineNum <= d.lineStringsLen; lineNum++ {
+		if line := d.getLineObject(lineNum); line.IsAdd {
+			result[line.NumInFile] = true
+		}
+	}
+
+	return
+}
+
 // Navigation
 
 func (d *Diff) Incr() (ok bool) {
@@ -0,0 +1,86 @@
+package git
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Chunks of the diffs between blobs, least recently used ones are dropped first.
+// A repository's workers share it, so it's safe for concurrent use.
+type diffCache struct {
+	mutex   *sync.Mutex
+	maxSize int
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type diffCacheEntry struct {
+	key             string
+	chunks          []*Chunk
+	isBinaryOrEmpty bool
+	size            int
+}
+
+func newDiffCache(maxSize int) *diffCache {
+	return &diffCache{
+		mutex:   &sync.Mutex{},
+		maxSize: maxSize,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Chunks are copies, so the caller can change them
+func (c *diffCache) get(key string) (chunks []*Chunk, isBinaryOrEmpty bool, ok bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var element *list.Element
+	if element, ok = c.entries[key]; !ok {
+		return
+	}
+	c.order.MoveToFront(element)
+
+	entry := element.Value.(*diffCacheEntry)
+
+	return copyChunks(entry.chunks), entry.isBinaryOrEmpty, true
+}
+
+func (c *diffCache) add(key string, chunks []*Chunk, isBinaryOrEmpty bool) {
+	size := 0
+	for _, chunk := range chunks {
+		size += len(chunk.Content)
+	}
+	if size > c.maxSize {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+
+	entry := &diffCacheEntry{key: key, chunks: copyChunks(chunks), isBinaryOrEmpty: isBinaryOrEmpty, size: size}
+	c.entries[key] = c.order.PushFront(entry)
+	c.size += size
+
+	for c.size > c.maxSize {
+		oldest := c.order.Back()
+		oldestEntry := oldest.Value.(*diffCacheEntry)
+		c.order.Remove(oldest)
+		delete(c.entries, oldestEntry.key)
+		c.size -= oldestEntry.size
+	}
+}
+
+func copyChunks(chunks []*Chunk) (result []*Chunk) {
+	result = make([]*Chunk, len(chunks))
+	for i, chunk := range chunks {
+		chunkCopy := *chunk
+		result[i] = &chunkCopy
+	}
+	return
+}
@@ -0,0 +1,57 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	subject := newDiffCache(250)
+	subject.add("a", []*Chunk{{Operation: Add, Content: strings.Repeat("a", 100)}}, false)
+	subject.add("b", []*Chunk{{Operation: Add, Content: strings.Repeat("b", 100)}}, false)
+	_, _, ok := subject.get("a")
+	require.True(t, ok)
+
+	// Fire
+	subject.add("c", []*Chunk{{Operation: Add, Content: strings.Repeat("c", 100)}}, false)
+
+	_, _, ok = subject.get("b")
+	require.False(t, ok)
+	_, _, ok = subject.get("a")
+	require.True(t, ok)
+	_, _, ok = subject.get("c")
+	require.True(t, ok)
+	require.Equal(t, 200, subject.size)
+}
+
+func TestDiffCache_TooLarge(t *testing.T) {
+	subject := newDiffCache(250)
+	subject.add("a", []*Chunk{{Operation: Add, Content: strings.Repeat("a", 100)}}, false)
+
+	// Fire
+	subject.add("b", []*Chunk{{Operation: Add, Content: strings.Repeat("b", 300)}}, false)
+
+	_, _, ok := subject.get("b")
+	require.False(t, ok)
+	_, _, ok = subject.get("a")
+	require.True(t, ok)
+}
+
+func TestDiffCache_GetCopies(t *testing.T) {
+	chunks := []*Chunk{{Operation: Equal, Content: "same\n"}, {Operation: Add, Content: "added\n"}}
+	subject := newDiffCache(250)
+	subject.add("a", chunks, true)
+	chunks[1].Content = "changed by the adder\n"
+
+	// Fire
+	result, isBinaryOrEmpty, ok := subject.get("a")
+
+	require.True(t, ok)
+	require.True(t, isBinaryOrEmpty)
+	require.Equal(t, []*Chunk{{Operation: Equal, Content: "same\n"}, {Operation: Add, Content: "added\n"}}, result)
+	result[1].Content = "changed by the getter\n"
+	result, _, _ = subject.get("a")
+	require.Equal(t, "added\n", result[1].Content)
+}
@@ -5,6 +5,7 @@ package git
 import (
 	"fmt"
 
+	"github.com/sergi/go-diff/diffmatchpatch"
 	gitdiff "gopkg.in/src-d/go-git.v4/plumbing/format/diff"
 )
 
@@ -29,6 +30,19 @@ func NewDiffOperationFromGit(gdo gitdiff.Operation) DiffOperation {
 	}
 }
 
+func newDiffOperationFromDMP(op diffmatchpatch.Operation) DiffOperation {
+	switch op {
+	case diffmatchpatch.DiffEqual:
+		return Equal
+	case diffmatchpatch.DiffDelete:
+		return Delete
+	case diffmatchpatch.DiffInsert:
+		return Add
+	default:
+		panic("unknown diff operation")
+	}
+}
+
 func (i DiffOperation) Prefix() string {
 	switch i {
 	case Equal:
@@ -0,0 +1,116 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+)
+
+// How much of a repo is cloned or fetched. A nil or zero value fetches everything.
+// Clones that only limit the depth are done by go-git, anything else needs the git CLI.
+type FetchOptions struct {
+	// Commits of history on each branch, 0 for all of them
+	Depth int
+
+	// Only commits since the date
+	ShallowSince time.Time
+
+	// Blobs larger than this, in bytes, aren't fetched (a partial clone), 0 for no limit
+	MaxBlobSize int64
+
+	// Sparse checkout patterns left out of the worktree. Their history is still fetched, so it's still searched.
+	CheckoutExcludePaths []string
+}
+
+func NewFetchOptions(depth int, shallowSince time.Time, maxBlobSize int64, checkoutExcludePaths []string) *FetchOptions {
+	return &FetchOptions{
+		Depth:                depth,
+		ShallowSince:         shallowSince,
+		MaxBlobSize:          maxBlobSize,
+		CheckoutExcludePaths: checkoutExcludePaths,
+	}
+}
+
+func (o *FetchOptions) depth() int {
+	if o == nil {
+		return 0
+	}
+	return o.Depth
+}
+
+func (o *FetchOptions) needsGitCLI() bool {
+	return o != nil && (!o.ShallowSince.IsZero() || o.MaxBlobSize > 0 || len(o.CheckoutExcludePaths) > 0)
+}
+
+// Arguments for both git clone and git fetch
+func (o *FetchOptions) cliArgs() (result []string) {
+	if o.Depth > 0 {
+		result = append(result, fmt.Sprintf("--depth=%d", o.Depth))
+	}
+	if !o.ShallowSince.IsZero() {
+		result = append(result, "--shallow-since="+o.ShallowSince.Format(time.RFC3339))
+	}
+	if o.MaxBlobSize > 0 {
+		result = append(result, fmt.Sprintf("--filter=blob:limit=%d", o.MaxBlobSize))
+	}
+
+	return
+}
+
+// Patterns for git sparse-checkout, everything but the excluded paths
+func (o *FetchOptions) sparseCheckoutPatterns() (result []string) {
+	result = []string{"/*"}
+	for _, path := range o.CheckoutExcludePaths {
+		result = append(result, "!"+path)
+	}
+
+	return
+}
+
+// "git sparse-checkout set --no-cone" was added in 2.35
+var sparseCheckoutMinVersion = [2]int{2, 35}
+
+var gitVersionRe = regexp.MustCompile(`^git version (\d+)\.(\d+)`)
+
+// Fails when the git CLI is older than the major and minor version
+func requireGitCLIVersion(minVersion [2]int) (err error) {
+	output, err := exec.Command("git", "version").Output()
+	if err != nil {
+		err = errors.Wrap(err, "unable to get git version")
+		return
+	}
+
+	match := gitVersionRe.FindStringSubmatch(strings.TrimSpace(string(output)))
+	if match == nil {
+		err = errors.Errorv("unable to parse git version", strings.TrimSpace(string(output)))
+		return
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+
+	if major < minVersion[0] || (major == minVersion[0] && minor < minVersion[1]) {
+		err = errors.Errorf("git %d.%d or later is needed, found %d.%d", minVersion[0], minVersion[1], major, minor)
+	}
+
+	return
+}
+
+// Output is included in the error. Git never prompts for credentials, it fails instead,
+// and credential helpers aren't used so the ones we pass in aren't stored.
+func runGitCLI(dir string, env []string, args ...string) (err error) {
+	cmd := exec.Command("git", append([]string{"-c", "credential.helper="}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(append(os.Environ(), "GIT_TERMINAL_PROMPT=0"), env...)
+
+	if output, runErr := cmd.CombinedOutput(); runErr != nil {
+		err = errors.Wrapf(runErr, "git %s failed: %s", args[0], strings.TrimSpace(string(output)))
+	}
+
+	return
+}
@@ -5,16 +5,25 @@ import (
 	"strings"
 
 	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
-	gitdiff "gopkg.in/src-d/go-git.v4/plumbing/format/diff"
 	gitobject "gopkg.in/src-d/go-git.v4/plumbing/object"
+	gitutildiff "gopkg.in/src-d/go-git.v4/utils/diff"
 )
 
 type (
 	FileChange struct {
 		Commit          *Commit
 		Path            string
+		BlobHash        string
 		Chunks          []*Chunk
 		IsBinaryOrEmpty bool
+
+		// Larger than the filter's max blob size, so it wasn't diffed and has no chunks
+		TooLarge bool
+		BlobSize int64
+
+		// Left out of a partial clone, so it wasn't diffed and has no chunks
+		NotFetched bool
+
 		fileChangeMemo
 	}
 	fileChangeMemo struct {
@@ -35,9 +44,16 @@ func NewFileChange(commit *Commit, gitFileChange *gitobject.Change) (result *Fil
 		return
 	}
 
+	// Deletions have no blob
+	var blobHash string
+	if gitFileChange.To.Name != "" {
+		blobHash = gitFileChange.To.TreeEntry.Hash.String()
+	}
+
 	result = &FileChange{
 		Commit:          commit,
 		Path:            gitFileChange.To.Name,
+		BlobHash:        blobHash,
 		Chunks:          chunks,
 		IsBinaryOrEmpty: isBinaryOrEmpty,
 		fileChangeMemo:  fileChangeMemo{},
@@ -96,57 +112,136 @@ func (fcc *FileChange) FileType() (result string) {
 	return
 }
 
+// Nil when the blob isn't larger than the max size, or when the file was deleted
+func newTooLargeFileChange(commit *Commit, gitFileChange *gitobject.Change, maxBlobSize int64) (result *FileChange, err error) {
+	if gitFileChange.To.Name == "" {
+		return
+	}
+
+	blobHash := gitFileChange.To.TreeEntry.Hash
+
+	var blobSize int64
+	if blobSize, err = commit.repository.blobSize(blobHash); err != nil {
+		err = errors.WithMessagev(err, "unable to get blob size", blobHash.String())
+		return
+	}
+	if blobSize <= maxBlobSize {
+		return
+	}
+
+	// Only the start of the blob is read, so binary ones can still be filtered out
+	var isBinary bool
+	if isBinary, err = commit.repository.blobIsBinary(blobHash); err != nil {
+		err = errors.WithMessagev(err, "unable to check if blob is binary", blobHash.String())
+		return
+	}
+
+	result = &FileChange{
+		Commit:          commit,
+		Path:            gitFileChange.To.Name,
+		BlobHash:        blobHash.String(),
+		IsBinaryOrEmpty: isBinary,
+		TooLarge:        true,
+		BlobSize:        blobSize,
+		fileChangeMemo:  fileChangeMemo{},
+	}
+	return
+}
+
+func newNotFetchedFileChange(commit *Commit, gitFileChange *gitobject.Change) (result *FileChange) {
+	var blobHash string
+	if gitFileChange.To.Name != "" {
+		blobHash = gitFileChange.To.TreeEntry.Hash.String()
+	}
+
+	return &FileChange{
+		Commit:         commit,
+		Path:           gitFileChange.To.Name,
+		BlobHash:       blobHash,
+		NotFetched:     true,
+		fileChangeMemo: fileChangeMemo{},
+	}
+}
+
+// Diffs are cached by the blobs on both sides, and are worked out without holding the
+// repository's lock so workers searching the same clone can diff at the same time
 func gatherPatchData(commit *Commit, gitFileChange *gitobject.Change) (chunks []*Chunk, isBinaryOrEmpty bool, err error) {
-	var filePatch gitdiff.FilePatch
-	filePatch, err = getFilePatch(commit, gitFileChange)
+	repository := commit.repository
+	key := gitFileChange.From.TreeEntry.Hash.String() + ".." + gitFileChange.To.TreeEntry.Hash.String()
+
+	var ok bool
+	if chunks, isBinaryOrEmpty, ok = repository.diffCache.get(key); ok {
+		return
+	}
+
+	var fromContent, toContent string
+	var isBinary bool
+	fromContent, toContent, isBinary, err = getChangeContents(commit, gitFileChange)
 	if err != nil {
-		err = errors.WithMessage(err, "unable to get file patch")
+		err = errors.WithMessage(err, "unable to get file contents")
 		return
 	}
 
-	// Get chunks
-	gitChunks := filePatch.Chunks()
-	chunks = make([]*Chunk, len(gitChunks))
-	for i, gitChunk := range gitChunks {
-		chunks[i] = &Chunk{
-			Operation: NewDiffOperationFromGit(gitChunk.Type()),
-			Content:   gitChunk.Content(),
+	// The same as the chunks of go-git's file patch
+	chunks = []*Chunk{}
+	if !isBinary {
+		for _, d := range gitutildiff.Do(fromContent, toContent) {
+			chunks = append(chunks, &Chunk{Operation: newDiffOperationFromDMP(d.Type), Content: d.Text})
 		}
 	}
 
-	// Get binary flag
-	isBinaryOrEmpty = filePatch.IsBinary()
+	// Like go-git's FilePatch.IsBinary(), which is also true when neither side has content
+	isBinaryOrEmpty = len(chunks) == 0
+
+	repository.diffCache.add(key, chunks, isBinaryOrEmpty)
 
 	return
 }
 
-func getFilePatch(commit *Commit, gitFileChange *gitobject.Change) (result gitdiff.FilePatch, err error) {
+func getChangeContents(commit *Commit, gitFileChange *gitobject.Change) (fromContent, toContent string, isBinary bool, err error) {
 	commit.repository.mutex.Lock()
 	defer commit.repository.mutex.Unlock()
 
-	defer errors.CatchPanicSetErr(&err, "panic getting file patch")
+	defer errors.CatchPanicSetErr(&err, "panic getting file contents")
 
-	// Get file patch
-	var patch *gitobject.Patch
-	patch, err = gitFileChange.Patch()
-	if err != nil {
-		err = errors.WithMessage(err, "unable to get file patch")
+	var from, to *gitobject.File
+	if from, to, err = gitFileChange.Files(); err != nil {
+		err = errors.Wrap(err, "unable to get files")
 		return
 	}
-	if patch == nil {
-		err = errors.New("patch is nil")
+
+	var fromIsBinary, toIsBinary bool
+	if fromContent, fromIsBinary, err = fileContent(from); err != nil {
+		err = errors.WithMessage(err, "unable to get contents of file before change")
 		return
 	}
-	filePatches := patch.FilePatches()
-	if filePatches == nil {
-		err = errors.New("file patches is nil")
+	if toContent, toIsBinary, err = fileContent(to); err != nil {
+		err = errors.WithMessage(err, "unable to get contents of file after change")
 		return
 	}
-	if filePatches[0] == nil {
-		err = errors.New("file patch is nil")
+
+	isBinary = fromIsBinary || toIsBinary
+
+	return
+}
+
+// Empty for a nil file, which is the missing side of an addition or deletion
+func fileContent(file *gitobject.File) (content string, isBinary bool, err error) {
+	if file == nil {
+		return
+	}
+
+	if isBinary, err = file.IsBinary(); err != nil {
+		err = errors.Wrap(err, "unable to check if file is binary")
+		return
+	}
+	if isBinary {
 		return
 	}
-	result = filePatches[0]
+
+	if content, err = file.Contents(); err != nil {
+		err = errors.Wrap(err, "unable to read file")
+	}
 
 	return
 }
@@ -9,6 +9,12 @@ type FileChangeFilter struct {
 	ExcludeFileDeletions         bool
 	ExcludeBinaryOrEmpty         bool
 	ExcludeOnesWithNoCodeChanges bool
+
+	// Binary files that are included anyway, because a processor can read them
+	BinaryPathFilter *manip.RegexpFilter
+
+	// Larger blobs aren't diffed, they're included as too large so they can be reported. 0 for no limit.
+	MaxBlobSize int64
 }
 
 func NewFileChangeFilter(
@@ -16,12 +22,16 @@ func NewFileChangeFilter(
 	excludeFileDeletions bool,
 	excludeBinaryOrEmpty bool,
 	excludeOnesWithNoCodeChanges bool,
+	binaryPathFilter *manip.RegexpFilter,
+	maxBlobSize int64,
 ) (result *FileChangeFilter) {
 	return &FileChangeFilter{
 		PathFilter:                   pathFilter,
 		ExcludeFileDeletions:         excludeFileDeletions,
 		ExcludeBinaryOrEmpty:         excludeBinaryOrEmpty,
 		ExcludeOnesWithNoCodeChanges: excludeOnesWithNoCodeChanges,
+		BinaryPathFilter:             binaryPathFilter,
+		MaxBlobSize:                  maxBlobSize,
 	}
 }
 
@@ -37,6 +47,21 @@ func (cf *FileChangeFilter) Includes(input interface{}) (result bool) {
 		return false
 	}
 
+	binaryIncluded := fileChange.IsBinaryOrEmpty && cf.BinaryPathFilter != nil && cf.BinaryPathFilter.Includes(fileChange.Path)
+
+	// Never diffed, so there's nothing else to check. Too large binary files are only
+	// included when they'd have been searched, so they aren't recorded as skipped for nothing.
+	if fileChange.TooLarge {
+		return !cf.ExcludeBinaryOrEmpty || !fileChange.IsBinaryOrEmpty || binaryIncluded
+	}
+	if fileChange.NotFetched {
+		return true
+	}
+
+	if binaryIncluded {
+		return true
+	}
+
 	// Filter out ones with no code changes
 	if cf.ExcludeOnesWithNoCodeChanges && !fileChange.HasCodeChanges() {
 		return false
@@ -0,0 +1,104 @@
+package git_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	gitvendor "gopkg.in/src-d/go-git.v4"
+	gitobject "gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// Chunks are worked out without go-git's Patch(), so they're checked against it
+func TestCommit_FileChanges_MatchesPatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-change-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	gitRepo, err := gitvendor.PlainInit(dir, false)
+	require.NoError(t, err)
+	worktree, err := gitRepo.Worktree()
+	require.NoError(t, err)
+	commit := func(files map[string]string, removes ...string) (result *gitobject.Commit) {
+		for name, contents := range files {
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0600))
+			_, err = worktree.Add(name)
+			require.NoError(t, err)
+		}
+		for _, name := range removes {
+			_, err = worktree.Remove(name)
+			require.NoError(t, err)
+		}
+		hash, err := worktree.Commit("commit", &gitvendor.CommitOptions{
+			Author: &gitobject.Signature{Name: "name", Email: "name@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		result, err = gitRepo.CommitObject(hash)
+		require.NoError(t, err)
+		return
+	}
+	parentCommit := commit(map[string]string{
+		"modified.txt": "one\ntwo\nthree\nfour\n",
+		"deleted.txt":  "gone\n",
+		"binary.bin":   "\x00one",
+	})
+	gitCommit := commit(map[string]string{
+		"modified.txt": "one\n2\nthree\nfour\nfive\n",
+		"added.txt":    "new\n",
+		"empty.txt":    "",
+		"binary.bin":   "\x00two",
+	}, "deleted.txt")
+
+	// Expected, from go-git's patches
+	parentTree, err := parentCommit.Tree()
+	require.NoError(t, err)
+	tree, err := gitCommit.Tree()
+	require.NoError(t, err)
+	gitChanges, err := parentTree.Diff(tree)
+	require.NoError(t, err)
+
+	// By path after the change, a deletion's is empty
+	expChunks := map[string][]*Chunk{}
+	expIsBinaryOrEmpty := map[string]bool{}
+	for _, gitChange := range gitChanges {
+		patch, err := gitChange.Patch()
+		require.NoError(t, err)
+		filePatch := patch.FilePatches()[0]
+		chunks := []*Chunk{}
+		for _, gitChunk := range filePatch.Chunks() {
+			chunks = append(chunks, &Chunk{Operation: NewDiffOperationFromGit(gitChunk.Type()), Content: gitChunk.Content()})
+		}
+		expChunks[gitChange.To.Name] = chunks
+		expIsBinaryOrEmpty[gitChange.To.Name] = filePatch.IsBinary()
+	}
+	require.Len(t, expChunks, 5)
+	require.True(t, expIsBinaryOrEmpty["empty.txt"])
+	require.True(t, expIsBinaryOrEmpty["binary.bin"])
+
+	repository, err := New(nil, logg.NewLogrusLogg(logrus.New())).OpenRepository(dir)
+	require.NoError(t, err)
+	subject, err := repository.Commit(gitCommit.Hash.String())
+	require.NoError(t, err)
+
+	// The second time, they come from the diff cache
+	for i := 0; i < 2; i++ {
+
+		// Fire
+		fileChanges, err := subject.FileChanges(nil)
+
+		require.NoError(t, err)
+		chunks := map[string][]*Chunk{}
+		isBinaryOrEmpty := map[string]bool{}
+		for _, fileChange := range fileChanges {
+			chunks[fileChange.Path] = fileChange.Chunks
+			isBinaryOrEmpty[fileChange.Path] = fileChange.IsBinaryOrEmpty
+		}
+		require.Equal(t, expChunks, chunks)
+		require.Equal(t, expIsBinaryOrEmpty, isBinaryOrEmpty)
+	}
+}
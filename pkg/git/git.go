@@ -4,16 +4,20 @@ import (
 	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
 	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
 	gitvendor "gopkg.in/src-d/go-git.v4"
+	gitplumbing "gopkg.in/src-d/go-git.v4/plumbing"
 	gittransport "gopkg.in/src-d/go-git.v4/plumbing/transport"
 )
 
 type Git struct {
-	log logg.Logg
+	credentials *Credentials
+	log         logg.Logg
 }
 
-func New(log logg.Logg) *Git {
+// Credentials are optional, remotes are accessed without any when they're nil
+func New(credentials *Credentials, log logg.Logg) *Git {
 	return &Git{
-		log: log,
+		credentials: credentials,
+		log:         log,
 	}
 }
 
@@ -28,9 +32,22 @@ func (g *Git) OpenRepository(cloneDir string) (result *Repository, err error) {
 	return newRepository(g, gitRepo, cloneDir, g.log), nil
 }
 
-func (g *Git) Clone(url, cloneDir string) (result *Repository, err error) {
+// Fetch options are optional, the whole repo is cloned when they're nil
+// Credentials in the URL are used but not stored in the clone.
+func (g *Git) Clone(url, cloneDir string, fetchOptions *FetchOptions) (result *Repository, err error) {
+	url, auth, endpoint := g.credentials.forURL(url)
+
+	if fetchOptions.needsGitCLI() {
+		return g.cloneWithCLI(url, auth, endpoint, cloneDir, fetchOptions)
+	}
+
+	co := &gitvendor.CloneOptions{URL: url, Depth: fetchOptions.depth()}
+	if co.Auth, err = auth.goGitAuth(endpoint); err != nil {
+		err = errors.WithMessagev(err, "unable to set up auth", url)
+		return
+	}
+
 	var gitRepo *gitvendor.Repository
-	co := &gitvendor.CloneOptions{URL: url}
 	if gitRepo, err = gitvendor.PlainClone(cloneDir, false, co); err != nil {
 		err = errors.Wrapf(err, "unable to clone from %s to %s", url, cloneDir)
 		return
@@ -39,6 +56,62 @@ func (g *Git) Clone(url, cloneDir string) (result *Repository, err error) {
 	return newRepository(g, gitRepo, cloneDir, g.log), nil
 }
 
+func (g *Git) cloneWithCLI(url string, auth *Auth, endpoint *gittransport.Endpoint, cloneDir string, fetchOptions *FetchOptions) (result *Repository, err error) {
+	env, cleanup, err := auth.cliEnv(endpoint)
+	if err != nil {
+		err = errors.WithMessagev(err, "unable to set up auth", url)
+		return
+	}
+	defer cleanup()
+
+	// Checked before cloning, so an old git doesn't leave a clone behind
+	if len(fetchOptions.CheckoutExcludePaths) > 0 {
+		if err = requireGitCLIVersion(sparseCheckoutMinVersion); err != nil {
+			err = errors.WithMessage(err, "unable to exclude paths from checkout")
+			return
+		}
+	}
+
+	args := append([]string{"clone", "--quiet", "--no-checkout"}, fetchOptions.cliArgs()...)
+
+	// Shallow clones only get the default branch otherwise
+	if fetchOptions.Depth > 0 || !fetchOptions.ShallowSince.IsZero() {
+		args = append(args, "--no-single-branch")
+	}
+
+	if err = runGitCLI("", env, append(args, "--", url, cloneDir)...); err != nil {
+		err = errors.WithMessagef(err, "unable to clone from %s to %s", url, cloneDir)
+		return
+	}
+
+	var gitRepo *gitvendor.Repository
+	if gitRepo, err = gitvendor.PlainOpen(cloneDir); err != nil {
+		err = errors.Wrapv(err, "unable to open clone", cloneDir)
+		return
+	}
+
+	// The git CLI clones empty repos without complaining
+	if _, headErr := gitRepo.Head(); headErr == gitplumbing.ErrReferenceNotFound {
+		err = gittransport.ErrEmptyRemoteRepository
+		return
+	}
+
+	if len(fetchOptions.CheckoutExcludePaths) > 0 {
+		sparseArgs := append([]string{"sparse-checkout", "set", "--no-cone"}, fetchOptions.sparseCheckoutPatterns()...)
+		if err = runGitCLI(cloneDir, env, sparseArgs...); err != nil {
+			err = errors.WithMessage(err, "unable to exclude paths from checkout")
+			return
+		}
+	}
+
+	if err = runGitCLI(cloneDir, env, "checkout", "--quiet"); err != nil {
+		err = errors.WithMessage(err, "unable to check out clone")
+		return
+	}
+
+	return newRepository(g, gitRepo, cloneDir, g.log), nil
+}
+
 func (g *Git) ValidateClone(cloneDir string) (err error) {
 	var repo *Repository
 	repo, err = g.OpenRepository(cloneDir)
@@ -2,6 +2,7 @@ package git
 
 import (
 	"io"
+	"sort"
 	"sync"
 
 	"gopkg.in/src-d/go-git.v4/config"
@@ -11,27 +12,77 @@ import (
 	gitvendor "gopkg.in/src-d/go-git.v4"
 	gitplumbing "gopkg.in/src-d/go-git.v4/plumbing"
 	gitobject "gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	gittransport "gopkg.in/src-d/go-git.v4/plumbing/transport"
+	"gopkg.in/src-d/go-git.v4/utils/binary"
 )
 
+// Diffs kept per repository, in bytes of chunk content
+const diffCacheSize = 32 << 20
+
+// Can be shared by workers searching the same clone, they share its object and diff caches.
+// Reads from the clone are serialized by the mutex.
 type Repository struct {
-	git      *Git
-	gitRepo  *gitvendor.Repository
-	cloneDir string
-	mutex    *sync.Mutex
-	log      logg.Logg
+	git       *Git
+	gitRepo   *gitvendor.Repository
+	cloneDir  string
+	mutex     *sync.Mutex
+	diffCache *diffCache
+	log       logg.Logg
+
+	// Commits at the edge of a shallow clone, and their parents that weren't fetched
+	shallow        map[gitplumbing.Hash]bool
+	shallowParents []gitplumbing.Hash
 }
 
 func newRepository(git *Git, gitRepo *gitvendor.Repository, cloneDir string, log logg.Logg) (result *Repository) {
-	return &Repository{
-		git:      git,
-		gitRepo:  gitRepo,
-		cloneDir: cloneDir,
-		mutex:    &sync.Mutex{},
-		log:      log,
+	result = &Repository{
+		git:       git,
+		gitRepo:   gitRepo,
+		cloneDir:  cloneDir,
+		mutex:     &sync.Mutex{},
+		diffCache: newDiffCache(diffCacheSize),
+		log:       log,
 	}
+	result.loadShallow()
+
+	return
+}
+
+// Without this, walking history stops with an error at the parents a shallow clone doesn't have
+func (r *Repository) loadShallow() {
+	r.shallow = map[gitplumbing.Hash]bool{}
+
+	shallowHashes, err := r.gitRepo.Storer.Shallow()
+	if err != nil {
+		errors.ErrLog(r.log, err).Warn("unable to read shallow commits, treating clone as complete")
+		return
+	}
+
+	for _, hash := range shallowHashes {
+		r.shallow[hash] = true
+
+		gitCommit, commitErr := r.gitRepo.CommitObject(hash)
+		if commitErr != nil {
+			continue
+		}
+		r.shallowParents = append(r.shallowParents, gitCommit.ParentHashes...)
+	}
+}
+
+func (r *Repository) IsShallow() bool {
+	return len(r.shallow) > 0
 }
 
-func (r *Repository) FetchAll(url string) (err error) {
+// Fetch options are optional, everything is fetched when they're nil.
+// Credentials in the URL are used but not stored in the remote.
+func (r *Repository) FetchAll(url string, fetchOptions *FetchOptions) (err error) {
+	url, auth, endpoint := r.git.credentials.forURL(url)
+
+	if fetchOptions.needsGitCLI() {
+		return r.fetchAllWithCLI(url, auth, endpoint, fetchOptions)
+	}
+
 	remoteConfig := &config.RemoteConfig{Name: "origin", URLs: []string{url}}
 
 	var remoteExists = true
@@ -56,7 +107,12 @@ func (r *Repository) FetchAll(url string) (err error) {
 		return
 	}
 
-	if fetchErr := r.gitRepo.Fetch(&gitvendor.FetchOptions{}); fetchErr != nil && fetchErr != gitvendor.NoErrAlreadyUpToDate {
+	fo := &gitvendor.FetchOptions{Depth: fetchOptions.depth()}
+	if fo.Auth, err = auth.goGitAuth(endpoint); err != nil {
+		err = errors.WithMessagev(err, "unable to set up auth", url)
+		return
+	}
+	if fetchErr := r.gitRepo.Fetch(fo); fetchErr != nil && fetchErr != gitvendor.NoErrAlreadyUpToDate {
 		err = errors.Wrap(fetchErr, "unable to fetch all")
 		return
 	}
@@ -64,6 +120,35 @@ func (r *Repository) FetchAll(url string) (err error) {
 	return
 }
 
+// The remote is updated in place, recreating it would lose what a partial clone knows about it
+func (r *Repository) fetchAllWithCLI(url string, auth *Auth, endpoint *gittransport.Endpoint, fetchOptions *FetchOptions) (err error) {
+	remoteArgs := []string{"remote", "set-url", "origin", url}
+	if _, remErr := r.gitRepo.Remote("origin"); remErr == gitvendor.ErrRemoteNotFound {
+		remoteArgs = []string{"remote", "add", "origin", url}
+	}
+	if err = runGitCLI(r.cloneDir, nil, remoteArgs...); err != nil {
+		err = errors.WithMessage(err, "unable to set remote")
+		return
+	}
+
+	env, cleanup, err := auth.cliEnv(endpoint)
+	if err != nil {
+		err = errors.WithMessagev(err, "unable to set up auth", url)
+		return
+	}
+	defer cleanup()
+
+	args := append([]string{"fetch", "--quiet"}, fetchOptions.cliArgs()...)
+	if err = runGitCLI(r.cloneDir, env, append(args, "origin")...); err != nil {
+		err = errors.WithMessage(err, "unable to fetch all")
+		return
+	}
+
+	r.loadShallow()
+
+	return
+}
+
 func (r *Repository) Log(commitFilter *CommitFilter) (result []*Commit, err error) {
 	if commitFilter == nil {
 		commitFilter = NewEmptyCommitFilter()
@@ -94,7 +179,11 @@ func (r *Repository) Log(commitFilter *CommitFilter) (result []*Commit, err erro
 	logOptions.All = true
 
 	var fromCommits gitobject.CommitIter
-	fromCommits, err = r.gitRepo.Log(logOptions)
+	if r.IsShallow() {
+		fromCommits, err = r.shallowLog()
+	} else {
+		fromCommits, err = r.gitRepo.Log(logOptions)
+	}
 	if err != nil {
 		err = errors.Wrap(err, "unable to get git log")
 		return
@@ -103,6 +192,7 @@ func (r *Repository) Log(commitFilter *CommitFilter) (result []*Commit, err erro
 	var gitCommit *gitobject.Commit
 	var commit *Commit
 	var lastCommit *Commit
+	var lastIncluded bool
 	var iterErr error
 
 	for {
@@ -113,9 +203,9 @@ func (r *Repository) Log(commitFilter *CommitFilter) (result []*Commit, err erro
 			if lastCommit != nil && (commitFilter == nil || commitFilter.OldestCommitIsIncluded()) {
 				lastCommit.Oldest = true
 
-				// Try again with the new knighthood
-				included := true
-				if commitFilter != nil {
+				// Try again with the new knighthood, unless it was already included
+				included := !lastIncluded
+				if included && commitFilter != nil {
 					included, _ = commitFilter.IsIncludedInLogResults(commit)
 				}
 				if included {
@@ -149,6 +239,7 @@ func (r *Repository) Log(commitFilter *CommitFilter) (result []*Commit, err erro
 		}
 
 		lastCommit = commit
+		lastIncluded = included
 	}
 
 	return
@@ -173,20 +264,148 @@ func (r *Repository) GetOldestCommit() (result *Commit, err error) {
 }
 
 func (r *Repository) Commit(hashString string) (result *Commit, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
 	var gitCommit *gitobject.Commit
-	gitCommit, err = r.wrapCommitObject(gitplumbing.NewHash(hashString))
+	gitCommit, err = r.gitRepo.CommitObject(gitplumbing.NewHash(hashString))
 	if err != nil {
-		err = errors.WithMessage(err, "unable to get commit")
+		err = errors.Wrap(err, "unable to get commit")
 		return
 	}
 
+	// The tree is read from the clone too
 	result, err = newCommit(r, gitCommit)
 
 	return
 }
 
-func (r *Repository) Spawn() (result *Repository, err error) {
-	return r.git.OpenRepository(r.cloneDir)
+func (r *Repository) Head() (result *Commit, err error) {
+	var ref *gitplumbing.Reference
+	ref, err = r.gitRepo.Head()
+	if err != nil {
+		err = errors.Wrap(err, "unable to get HEAD reference")
+		return
+	}
+
+	result, err = r.Commit(ref.Hash().String())
+
+	return
+}
+
+// Commit hash each branch, tag and remote ref points to, by ref name
+func (r *Repository) RefTips() (result map[string]string, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var refs storer.ReferenceIter
+	if refs, err = r.gitRepo.References(); err != nil {
+		err = errors.Wrap(err, "unable to get references")
+		return
+	}
+
+	result = map[string]string{}
+	err = refs.ForEach(func(ref *gitplumbing.Reference) error {
+		if ref.Type() != gitplumbing.HashReference {
+			return nil
+		}
+
+		hash := ref.Hash()
+
+		// Annotated tags point to a tag object
+		if tag, tagErr := r.gitRepo.TagObject(hash); tagErr == nil {
+			tagCommit, commitErr := tag.Commit()
+			if commitErr != nil {
+				return nil
+			}
+			hash = tagCommit.Hash
+		} else if _, commitErr := r.gitRepo.CommitObject(hash); commitErr != nil {
+			return nil
+		}
+
+		result[ref.Name().String()] = hash.String()
+
+		return nil
+	})
+	if err != nil {
+		err = errors.Wrap(err, "unable to iterate references")
+	}
+
+	return
+}
+
+// Hashes of the commits and all of their ancestors. Commits that aren't in the repo anymore are skipped.
+func (r *Repository) AncestorHashes(commitHashes []string) (result map[string]bool, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	seen := map[gitplumbing.Hash]bool{}
+	for _, commitHash := range commitHashes {
+		gitCommit, commitErr := r.gitRepo.CommitObject(gitplumbing.NewHash(commitHash))
+		if commitErr != nil {
+			r.log.WithError(commitErr).Debugf("commit %s not found, skipping its ancestors", commitHash)
+			continue
+		}
+
+		iter := gitobject.NewCommitPreorderIter(gitCommit, seen, r.shallowParents)
+		err = iter.ForEach(func(c *gitobject.Commit) error {
+			seen[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			err = errors.Wrapv(err, "unable to walk ancestors of commit", commitHash)
+			return
+		}
+	}
+
+	result = make(map[string]bool, len(seen))
+	for hash := range seen {
+		result[hash.String()] = true
+	}
+
+	return
+}
+
+// Commits reachable from every ref, newest first, like go-git's log of a shallow clone would be if it could
+func (r *Repository) shallowLog() (result gitobject.CommitIter, err error) {
+	var tips map[string]string
+	if tips, err = r.RefTips(); err != nil {
+		err = errors.WithMessage(err, "unable to get ref tips")
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	seen := map[gitplumbing.Hash]bool{}
+	var gitCommits []*gitobject.Commit
+	for _, tip := range tips {
+		var gitCommit *gitobject.Commit
+		if gitCommit, err = r.gitRepo.CommitObject(gitplumbing.NewHash(tip)); err != nil {
+			err = errors.Wrapv(err, "unable to get commit", tip)
+			return
+		}
+
+		iter := gitobject.NewCommitPreorderIter(gitCommit, seen, r.shallowParents)
+		err = iter.ForEach(func(c *gitobject.Commit) error {
+			seen[c.Hash] = true
+			gitCommits = append(gitCommits, c)
+			return nil
+		})
+		if err != nil {
+			err = errors.Wrapv(err, "unable to walk ancestors of commit", tip)
+			return
+		}
+	}
+
+	sort.Slice(gitCommits, func(i, j int) bool {
+		if !gitCommits[i].Committer.When.Equal(gitCommits[j].Committer.When) {
+			return gitCommits[i].Committer.When.After(gitCommits[j].Committer.When)
+		}
+		return gitCommits[i].Hash.String() < gitCommits[j].Hash.String()
+	})
+
+	return newCommitSliceIter(gitCommits), nil
 }
 
 func (r *Repository) newCommitsFromIter(iter gitobject.CommitIter) (result []*Commit, err error) {
@@ -203,11 +422,42 @@ func (r *Repository) newCommitsFromIter(iter gitobject.CommitIter) (result []*Co
 	return
 }
 
-func (r *Repository) wrapCommitObject(h gitplumbing.Hash) (*gitobject.Commit, error) {
+func (r *Repository) blobSize(h gitplumbing.Hash) (result int64, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var blob *gitobject.Blob
+	if blob, err = r.gitRepo.BlobObject(h); err != nil {
+		return
+	}
+
+	return blob.Size, nil
+}
+
+// Like git, a blob is binary if its first 8000 bytes have a null byte in them
+func (r *Repository) blobIsBinary(h gitplumbing.Hash) (result bool, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var blob *gitobject.Blob
+	if blob, err = r.gitRepo.BlobObject(h); err != nil {
+		return
+	}
+
+	var reader io.ReadCloser
+	if reader, err = blob.Reader(); err != nil {
+		return
+	}
+	defer reader.Close()
+
+	return binary.IsBinary(reader)
+}
+
+func (r *Repository) diffTrees(from, to *Tree) (gitobject.Changes, error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	return r.gitRepo.CommitObject(h)
+	return from.wrapDiff(to)
 }
 
 func (r *Repository) EmptyTree() *Tree {
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const squashFlag = "squash"
@@ -168,12 +169,14 @@ func (p *Param) SetLeafFieldValueFromString(value interface{}) (err error) {
 	var convertedValue interface{}
 
 	kind := fieldPtrVal.Elem().Type().Kind()
-	switch kind {
-	case reflect.Bool:
+	switch {
+	case fieldPtrVal.Elem().Type() == reflect.TypeOf(time.Duration(0)):
+		convertedValue, err = time.ParseDuration(stringValue)
+	case kind == reflect.Bool:
 		convertedValue, _ = strconv.ParseBool(stringValue)
-	case reflect.String:
+	case kind == reflect.String:
 		convertedValue = stringValue
-	case reflect.Int:
+	case kind == reflect.Int:
 		convertedValue, err = strconv.Atoi(stringValue)
 	default:
 		panic(fmt.Sprintf("unsupported kind: %s; value: %v", kind, value))
@@ -13,7 +13,9 @@ import (
 	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
 	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
 	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/risk"
 	"github.com/pantheon-systems/secrets-searcher/pkg/source"
+	"github.com/pantheon-systems/secrets-searcher/pkg/verify"
 )
 
 const (
@@ -28,6 +30,7 @@ type (
 		secretsDir        string
 		groupBy           SecretGrouper
 		filter            SecretFilter
+		riskScorer        *riskScorer
 		sourceProvider    source.ProviderI
 		stats             *stats.Stats
 		db                *database.Database
@@ -38,16 +41,32 @@ type (
 		AppLink           linkData
 		Repos             []string
 		DbgEnabled        bool
-		Secrets           map[string][]*SecretData
+		Secrets           []*secretGroupData
 		EnableDebugOutput bool
 		SecretCountMsg    string
 		DefaultGroup      string
+		Skipped           []*skippedData
+	}
+	// A file change that hit a resource limit, so it wasn't searched completely
+	skippedData struct {
+		RepoName            string
+		CommitHashLinkShort linkData
+		FilePath            string
+		Reason              string
+		Detail              string
+	}
+	secretGroupData struct {
+		Name    string
+		Secrets []*SecretData
 	}
 	SecretData struct {
 		ID            string         `yaml:"secret-id"`
 		Value         string         `yaml:"value"`
 		ValueLen      int            `yaml:"-"`
 		ValueFilePath string         `yaml:"-"`
+		Risk          *risk.Score    `yaml:"risk"`
+		Verification  string         `yaml:"verification,omitempty"`
+		UnexpiredCert bool           `yaml:"unexpired-certificate,omitempty"`
 		Extras        []*extraData   `yaml:"extras"`
 		Finding       *findingData   `yaml:"-"`
 		Findings      []*findingData `yaml:"findings"`
@@ -94,7 +113,7 @@ type (
 	}
 )
 
-func newBuilder(appURL string, enableDebugOutput bool, reportDir, secretsDir string, groupBy SecretGrouper, filter SecretFilter, sourceProvider source.ProviderI, stats *stats.Stats, db *database.Database, log logg.Logg) *builder {
+func newBuilder(appURL string, enableDebugOutput bool, reportDir, secretsDir string, groupBy SecretGrouper, filter SecretFilter, riskScorer *riskScorer, sourceProvider source.ProviderI, stats *stats.Stats, db *database.Database, log logg.Logg) *builder {
 	return &builder{
 		appURL:            appURL,
 		enableDebugOutput: enableDebugOutput,
@@ -102,6 +121,7 @@ func newBuilder(appURL string, enableDebugOutput bool, reportDir, secretsDir str
 		secretsDir:        secretsDir,
 		groupBy:           groupBy,
 		filter:            filter,
+		riskScorer:        riskScorer,
 		sourceProvider:    sourceProvider,
 		stats:             stats,
 		db:                db,
@@ -109,6 +129,29 @@ func newBuilder(appURL string, enableDebugOutput bool, reportDir, secretsDir str
 	}
 }
 
+func (s *SecretData) RiskBadgeClass() string {
+	switch {
+	case s.Risk.Total >= 70:
+		return "badge-danger"
+	case s.Risk.Total >= 40:
+		return "badge-warning"
+	default:
+		return "badge-secondary"
+	}
+}
+
+// A live credential is the worst case, so it gets the loudest badge
+func (s *SecretData) VerificationBadgeClass() string {
+	switch s.Verification {
+	case verify.Valid.Value():
+		return "badge-danger"
+	case verify.Invalid.Value():
+		return "badge-success"
+	default:
+		return "badge-light"
+	}
+}
+
 func (b *builder) groupedReportData() (secrets database.Secrets, findingsBySecret database.FindingGroups, findingExtrasByFindingID database.FindingExtraGroups, secretExtrasBySecretID database.SecretExtraGroups, err error) {
 	var reportData *database.ReportData
 	reportData, err = b.db.GetBaseReportData()
@@ -150,6 +193,14 @@ func (b *builder) buildReportData() (result *reportData, err error) {
 	)
 	secrets, findingsBySecret, findingExtrasByFindingID, secretExtrasBySecretID, err = b.groupedReportData()
 
+	heads := b.riskScorer.newHeadIndex()
+
+	var certs *certificateIndex
+	if certs, err = newCertificateIndex(b.db, time.Now()); err != nil {
+		err = errors.WithMessage(err, "unable to get certificates")
+		return
+	}
+
 	var secretDatas []*SecretData
 	for _, secret := range secrets {
 		var findings []*database.Finding
@@ -173,22 +224,45 @@ func (b *builder) buildReportData() (result *reportData, err error) {
 			continue
 		}
 
+		// Certificates for leaked keys
+		secretCerts := certs.certificates(secretData)
+		secretData.UnexpiredCert = certs.hasUnexpired(secretCerts)
+		secretData.Extras = append(secretData.Extras, certs.buildExtras(secretCerts)...)
+
+		// Risk score
+		secretData.Risk = b.riskScorer.scoreSecret(secretData, heads)
+		secretData.Extras = append(b.riskScorer.buildExtras(secretData.Risk), secretData.Extras...)
+
 		secretDatas = append(secretDatas, secretData)
 	}
 	secretCount := len(secretDatas)
 
-	secretGroups := map[string][]*SecretData{}
+	// Riskiest secrets first
+	sort.SliceStable(secretDatas, func(i, j int) bool {
+		if secretDatas[i].Risk.Total != secretDatas[j].Risk.Total {
+			return secretDatas[i].Risk.Total > secretDatas[j].Risk.Total
+		}
+		return secretDatas[i].ID < secretDatas[j].ID
+	})
+
+	// Groups are ordered by their riskiest secret, since the secrets are already sorted
+	var secretGroups []*secretGroupData
+	secretGroupIndex := map[string]*secretGroupData{}
 	for _, secretData := range secretDatas {
 		group := b.groupBy(secretData)
-		secretGroups[group] = append(secretGroups[group], secretData)
+		groupData, ok := secretGroupIndex[group]
+		if !ok {
+			groupData = &secretGroupData{Name: group}
+			secretGroupIndex[group] = groupData
+			secretGroups = append(secretGroups, groupData)
+		}
+		groupData.Secrets = append(groupData.Secrets, secretData)
 	}
 
 	repos := manip.NewEmptyBasicSet()
-	for _, secrets := range secretGroups {
-		for _, reportSecret := range secrets {
-			for _, reportFinding := range reportSecret.Findings {
-				repos.Add(reportFinding.RepoName)
-			}
+	for _, reportSecret := range secretDatas {
+		for _, reportFinding := range reportSecret.Findings {
+			repos.Add(reportFinding.RepoName)
 		}
 	}
 	repoNames := repos.StringValues()
@@ -196,6 +270,12 @@ func (b *builder) buildReportData() (result *reportData, err error) {
 
 	var secretCountMsg = fmt.Sprintf("%d secrets", secretCount)
 
+	var skipped []*skippedData
+	if skipped, err = b.buildSkippedData(); err != nil {
+		err = errors.WithMessage(err, "unable to build skipped data")
+		return
+	}
+
 	result = &reportData{
 		ReportDate:        time.Now(),
 		AppLink:           linkData{URL: b.appURL, Label: b.appURL},
@@ -204,6 +284,28 @@ func (b *builder) buildReportData() (result *reportData, err error) {
 		Secrets:           secretGroups,
 		SecretCountMsg:    secretCountMsg,
 		DefaultGroup:      defaultGroup,
+		Skipped:           skipped,
+	}
+
+	return
+}
+
+func (b *builder) buildSkippedData() (result []*skippedData, err error) {
+	var skips database.Skips
+	if skips, err = b.db.GetSkips(); err != nil {
+		return
+	}
+
+	for _, skip := range skips {
+		commitURL := b.sourceProvider.GetCommitURL(skip.RepoName, skip.CommitHash)
+
+		result = append(result, &skippedData{
+			RepoName:            skip.RepoName,
+			CommitHashLinkShort: linkData{Label: skip.CommitHash[:7], URL: commitURL, Tooltip: skip.CommitHash},
+			FilePath:            skip.Path,
+			Reason:              skip.Reason,
+			Detail:              skip.Detail,
+		})
 	}
 
 	return
@@ -225,8 +327,12 @@ func (b *builder) buildSecretData(secret *database.Secret, secretExtras database
 		findingDatas = append(findingDatas, findingData)
 	}
 
+	var verification string
 	var secretExtraDatas []*extraData
 	for _, secretExtra := range secretExtras {
+		if secretExtra.Key == verify.StatusExtraKey {
+			verification = secretExtra.Value
+		}
 		if !b.enableDebugOutput && secretExtra.Debug {
 			continue
 		}
@@ -264,6 +370,7 @@ func (b *builder) buildSecretData(secret *database.Secret, secretExtras database
 		Value:         secret.Value,
 		ValueLen:      len(secret.Value),
 		ValueFilePath: filePath,
+		Verification:  verification,
 		Extras:        secretExtraDatas,
 		Finding:       findingDatas[0],
 		Findings:      findingDatas,
@@ -358,6 +465,11 @@ func (b *builder) getFileLineLabels(finding *database.Finding) (label, labelShor
 	// ", line 123, col 123"
 	lineColSuffix := fmt.Sprintf(", line %d, col %d", finding.StartLineNum, finding.StartIndex+1)
 
+	// "#cell-7:line-3"
+	if finding.Section != "" {
+		lineColSuffix = fmt.Sprintf("#%s:line-%d", finding.Section, finding.SectionLineNum)
+	}
+
 	// "path/to/file.go, line 123, col 123"
 	label = finding.Path + lineColSuffix
 
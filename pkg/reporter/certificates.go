@@ -0,0 +1,96 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/pem"
+)
+
+// Certificates seen during the search, by the fingerprint of their public key
+type certificateIndex struct {
+	byFingerprint database.CertificateGroups
+	now           time.Time
+}
+
+func newCertificateIndex(db *database.Database, now time.Time) (result *certificateIndex, err error) {
+	var byFingerprint database.CertificateGroups
+	if byFingerprint, err = db.GetCertificatesGroupedByFingerprint(); err != nil {
+		return
+	}
+
+	result = &certificateIndex{
+		byFingerprint: byFingerprint,
+		now:           now,
+	}
+
+	return
+}
+
+// Certificates for the secret's key, newest expiry first
+func (c *certificateIndex) certificates(secretData *SecretData) (result database.Certificates) {
+	for _, extra := range secretData.Extras {
+		if extra.Key == pem.FingerprintExtraKey {
+			result = append(result, c.byFingerprint[extra.Value]...)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].NotAfter.After(result[j].NotAfter) })
+
+	return
+}
+
+func (c *certificateIndex) hasUnexpired(certs database.Certificates) bool {
+	for _, cert := range certs {
+		if c.now.Before(cert.NotAfter) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *certificateIndex) buildExtras(certs database.Certificates) (result []*extraData) {
+	if len(certs) == 0 {
+		return
+	}
+
+	blocks := make([]string, len(certs))
+	for i, cert := range certs {
+		blocks[i] = c.describe(cert)
+	}
+
+	result = append(result, &extraData{
+		Key:    "paired-certificates",
+		Header: "Paired certificates",
+		Value:  strings.Join(blocks, "\n"),
+		Code:   true,
+	})
+
+	return
+}
+
+func (c *certificateIndex) describe(cert *database.Certificate) string {
+	status := "expired"
+	if c.now.Before(cert.NotAfter) {
+		status = "unexpired"
+	}
+
+	commitHash := cert.CommitHash
+	if len(commitHash) > 7 {
+		commitHash = commitHash[:7]
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Subject: %s\n", cert.Subject)
+	if len(cert.SANs) > 0 {
+		fmt.Fprintf(&sb, "SANs:    %s\n", strings.Join(cert.SANs, ", "))
+	}
+	fmt.Fprintf(&sb, "Issuer:  %s\n", cert.Issuer)
+	fmt.Fprintf(&sb, "Expires: %s (%s)\n", cert.NotAfter.Format("2006-01-02"), status)
+	fmt.Fprintf(&sb, "Found:   %s %s@%s\n", cert.RepoName, cert.Path, commitHash)
+
+	return sb.String()
+}
@@ -0,0 +1,118 @@
+package reporter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/pem"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateIndex(t *testing.T) {
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	current := &database.Certificate{
+		ID:          "current",
+		Fingerprint: "SHA256:api",
+		Subject:     "CN=api.example.com",
+		SANs:        []string{"api.example.com", "10.0.0.1"},
+		Issuer:      "CN=Example CA",
+		NotAfter:    now.AddDate(1, 0, 0),
+		RepoName:    "api",
+		CommitHash:  "2222222222222222222222222222222222222222",
+		Path:        "certs/api.crt",
+	}
+	expired := &database.Certificate{
+		ID:          "expired",
+		Fingerprint: "SHA256:api",
+		Subject:     "CN=api.example.com",
+		Issuer:      "CN=Example CA",
+		NotAfter:    now.AddDate(-1, 0, 0),
+		RepoName:    "api",
+		CommitHash:  "1111111111111111111111111111111111111111",
+		Path:        "certs/api.crt",
+	}
+	expiredOnly := &database.Certificate{
+		ID:          "expired-only",
+		Fingerprint: "SHA256:old",
+		Subject:     "CN=old.example.com",
+		Issuer:      "CN=old.example.com",
+		NotAfter:    now.AddDate(0, -1, 0),
+		RepoName:    "legacy",
+		CommitHash:  "3333333",
+		Path:        "old.crt",
+	}
+
+	for name, tt := range map[string]struct {
+		fingerprint  string
+		expIDs       []string
+		expUnexpired bool
+		expExtra     string
+	}{
+		"matched pair, seen in two commits": {
+			fingerprint:  "SHA256:api",
+			expIDs:       []string{"current", "expired"},
+			expUnexpired: true,
+			expExtra: "Subject: CN=api.example.com\n" +
+				"SANs:    api.example.com, 10.0.0.1\n" +
+				"Issuer:  CN=Example CA\n" +
+				"Expires: 2021-06-01 (unexpired)\n" +
+				"Found:   api certs/api.crt@2222222\n" +
+				"\n" +
+				"Subject: CN=api.example.com\n" +
+				"Issuer:  CN=Example CA\n" +
+				"Expires: 2019-06-01 (expired)\n" +
+				"Found:   api certs/api.crt@1111111\n",
+		},
+		"unmatched": {
+			fingerprint: "SHA256:other",
+		},
+		"expired": {
+			fingerprint: "SHA256:old",
+			expIDs:      []string{"expired-only"},
+			expExtra: "Subject: CN=old.example.com\n" +
+				"Issuer:  CN=old.example.com\n" +
+				"Expires: 2020-05-01 (expired)\n" +
+				"Found:   legacy old.crt@3333333\n",
+		},
+	} {
+		dir, err := ioutil.TempDir("", "certificates")
+		require.NoError(t, err)
+		db, err := database.New(filepath.Join(dir, "db"), logg.NewLogrusLogg(logrus.New()))
+		require.NoError(t, err)
+		for _, cert := range []*database.Certificate{expired, current, expiredOnly} {
+			_, err = db.WriteCertificateIfNotExists(cert)
+			require.NoError(t, err)
+		}
+		secretData := &SecretData{Extras: []*extraData{
+			{Key: "other", Value: tt.fingerprint},
+			{Key: pem.FingerprintExtraKey, Value: tt.fingerprint},
+		}}
+		subject, err := newCertificateIndex(db, now)
+		require.NoError(t, err, name)
+
+		// Fire
+		certs := subject.certificates(secretData)
+
+		var ids []string
+		for _, cert := range certs {
+			ids = append(ids, cert.ID)
+		}
+		require.Equal(t, tt.expIDs, ids, name)
+		require.Equal(t, tt.expUnexpired, subject.hasUnexpired(certs), name)
+		extras := subject.buildExtras(certs)
+		if tt.expExtra == "" {
+			require.Empty(t, extras, name)
+		} else {
+			require.Len(t, extras, 1, name)
+			require.Equal(t, tt.expExtra, extras[0].Value, name)
+		}
+
+		_ = os.RemoveAll(dir)
+	}
+}
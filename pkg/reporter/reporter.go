@@ -19,7 +19,9 @@ import (
 	"github.com/otiai10/copy"
 	"github.com/pantheon-systems/secrets-searcher/pkg/database"
 	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	gitpkg "github.com/pantheon-systems/secrets-searcher/pkg/git"
 	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/risk"
 	"github.com/pantheon-systems/secrets-searcher/pkg/source"
 	"gopkg.in/yaml.v2"
 )
@@ -54,13 +56,14 @@ type (
 	SecretFilter  func(secretData *SecretData) (result bool)
 )
 
-func New(reportDir, reportArchivesDir, appURL string, enableDebugOutput, enablePreReports bool, preReportInterval time.Duration, secretIDFilter *manip.SliceFilter, metadataProvider source.ProviderI, stats *stats.Stats, db *database.Database, log logg.Logg) *Reporter {
+func New(reportDir, reportArchivesDir, appURL string, enableDebugOutput, enablePreReports bool, preReportInterval time.Duration, secretIDFilter *manip.SliceFilter, scorer *risk.Scorer, severities map[string]risk.Severity, sourceDir string, git *gitpkg.Git, metadataProvider source.ProviderI, stats *stats.Stats, db *database.Database, log logg.Logg) *Reporter {
 	secretsDir := filepath.Join(reportDir, "secrets")
 	reportFilePath := filepath.Join(reportDir, "report.html")
 
 	builderGroupBy := defaultGroupBy
 	builderFilter := defaultFilter(secretIDFilter)
-	builder := newBuilder(appURL, enableDebugOutput, reportDir, secretsDir, builderGroupBy, builderFilter, metadataProvider, stats, db, log)
+	riskScorer := newRiskScorer(scorer, severities, sourceDir, git, log.AddPrefixPath("risk"))
+	builder := newBuilder(appURL, enableDebugOutput, reportDir, secretsDir, builderGroupBy, builderFilter, riskScorer, metadataProvider, stats, db, log)
 
 	return &Reporter{
 		ReportDir:         reportDir,
@@ -216,8 +219,8 @@ func (r *Reporter) createSecretFiles(data *reportData) (err error) {
 		return errors.Wrapv(err, "unable to create secrets directory", r.secretsDir)
 	}
 
-	for _, secrets := range data.Secrets {
-		for _, sData := range secrets {
+	for _, secretGroup := range data.Secrets {
+		for _, sData := range secretGroup.Secrets {
 
 			// Paths
 			secretDir := filepath.Join(r.secretsDir, sData.ID)
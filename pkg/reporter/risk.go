@@ -0,0 +1,191 @@
+package reporter
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	gitpkg "github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/risk"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/pem"
+	"github.com/pantheon-systems/secrets-searcher/pkg/verify"
+)
+
+type (
+	riskScorer struct {
+		scorer     *risk.Scorer
+		severities map[string]risk.Severity
+		sourceDir  string
+		git        *gitpkg.Git
+		log        logg.Logg
+	}
+
+	// HEAD commits of the cloned repos, opened lazily for one report build
+	headIndex struct {
+		sourceDir string
+		git       *gitpkg.Git
+		commits   map[string]*gitpkg.Commit
+		log       logg.Logg
+	}
+)
+
+func newRiskScorer(scorer *risk.Scorer, severities map[string]risk.Severity, sourceDir string, git *gitpkg.Git, log logg.Logg) *riskScorer {
+	return &riskScorer{
+		scorer:     scorer,
+		severities: severities,
+		sourceDir:  sourceDir,
+		git:        git,
+		log:        log,
+	}
+}
+
+func (r *riskScorer) newHeadIndex() *headIndex {
+	return &headIndex{
+		sourceDir: r.sourceDir,
+		git:       r.git,
+		commits:   map[string]*gitpkg.Commit{},
+		log:       r.log,
+	}
+}
+
+func (r *riskScorer) scoreSecret(secretData *SecretData, heads *headIndex) (result *risk.Score) {
+	input := &risk.Input{Severity: risk.Low}
+	var protectedCount, unprotectedCount int
+	repos := manip.NewEmptyBasicSet()
+	commits := manip.NewEmptyBasicSet()
+	paths := manip.NewEmptyBasicSet()
+
+	for _, finding := range secretData.Findings {
+		severity, ok := r.severities[finding.ProcessorName]
+		if !ok {
+			severity = risk.Medium
+		}
+		if severity > input.Severity {
+			input.Severity = severity
+		}
+
+		if finding.CommitDate.After(input.NewestFindingAt) {
+			input.NewestFindingAt = finding.CommitDate
+		}
+
+		if !input.PresentAtHead && heads.contains(finding.RepoName, finding.FilePath, secretData.Value) {
+			input.PresentAtHead = true
+		}
+
+		switch findingExtraValue(finding, pem.ProtectedExtraKey) {
+		case "yes":
+			protectedCount++
+		case "no":
+			unprotectedCount++
+		}
+
+		repos.Add(finding.RepoName)
+		commits.Add(finding.RepoName + ":" + finding.CommitHash)
+		paths.Add(finding.FilePath)
+	}
+
+	for _, extra := range secretData.Extras {
+		if extra.Key == "public-key-info" || extra.Key == pem.FingerprintExtraKey {
+			input.PEMParsed = true
+			break
+		}
+	}
+	input.UnexpiredCert = secretData.UnexpiredCert
+
+	// Only if no copy of the key was in the clear
+	input.Protected = protectedCount > 0 && unprotectedCount == 0
+
+	if secretData.Verification != "" {
+		input.Verification = verify.NewStatusFromValue(secretData.Verification)
+	}
+
+	input.RepoCount = repos.Len()
+	input.CommitCount = commits.Len()
+	input.Paths = paths.StringValues()
+
+	return r.scorer.Score(input)
+}
+
+func (r *riskScorer) buildExtras(score *risk.Score) []*extraData {
+	return []*extraData{
+		{
+			Key:    "risk-score",
+			Header: "Risk score",
+			Value:  score.Label(),
+		},
+		{
+			Key:    "risk-score-breakdown",
+			Header: "Risk score breakdown",
+			Value:  score.Breakdown(),
+			Code:   true,
+		},
+	}
+}
+
+func findingExtraValue(finding *findingData, key string) (result string) {
+	for _, extra := range finding.Extras {
+		if extra.Key == key {
+			return extra.Value
+		}
+	}
+	return
+}
+
+func (h *headIndex) contains(repoName, path, value string) (result bool) {
+	commit := h.headCommit(repoName)
+	if commit == nil {
+		return
+	}
+
+	contents, err := commit.FileContents(path)
+	if err != nil {
+		return
+	}
+
+	if strings.Contains(contents, value) {
+		return true
+	}
+
+	// Multi-line values (like keys) may be indented or escaped differently at HEAD, so check each line
+	valueLines := strings.Split(strings.TrimSpace(value), "\n")
+	if len(valueLines) < 2 {
+		return
+	}
+	for _, valueLine := range valueLines {
+		valueLine = strings.TrimSpace(valueLine)
+		if valueLine != "" && !strings.Contains(contents, valueLine) {
+			return
+		}
+	}
+
+	return true
+}
+
+func (h *headIndex) headCommit(repoName string) (result *gitpkg.Commit) {
+	var ok bool
+	if result, ok = h.commits[repoName]; ok {
+		return
+	}
+	defer func() { h.commits[repoName] = result }()
+
+	if h.git == nil {
+		return
+	}
+
+	cloneDir := filepath.Join(h.sourceDir, repoName)
+	repository, err := h.git.OpenRepository(cloneDir)
+	if err != nil {
+		errors.ErrLog(h.log, err).Debugf("unable to open %s to check HEAD", repoName)
+		return
+	}
+
+	result, err = repository.Head()
+	if err != nil {
+		errors.ErrLog(h.log, err).Debugf("unable to get HEAD commit of %s", repoName)
+		result = nil
+	}
+
+	return
+}
@@ -58,23 +58,23 @@ func template_reportTemplate() string {
 		"                <a href=\"javascript:\" class=\"collapse-all\">Collapse all</a>\n" +
 		"            </p>\n" +
 		"            {{$defaultGroup:=.DefaultGroup}}\n" +
-		"            {{range $groupName, $secrets := .Secrets}}\n" +
+		"            {{range $, $group := .Secrets}}\n" +
 		"\n" +
-		"                {{if eq $groupName $defaultGroup }}\n" +
-		"                    {{range $, $secret := $secrets}}\n" +
+		"                {{if eq $group.Name $defaultGroup }}\n" +
+		"                    {{range $, $secret := $group.Secrets}}\n" +
 		"                        {{template \"secret-rows\" $secret}}\n" +
 		"                    {{end}}\n" +
 		"                {{else}}\n" +
 		"                    <div class=\"group expander row\">\n" +
 		"                        <div class=\"col\">\n" +
 		"                            <a href=\"javascript:\" class=\"float-left expander-link material-icons\"></a>\n" +
-		"                            {{$groupName}}\n" +
-		"                            ({{ len $secrets }} secrets)\n" +
+		"                            {{$group.Name}}\n" +
+		"                            ({{ len $group.Secrets }} secrets)\n" +
 		"                        </div>\n" +
 		"                    </div>\n" +
 		"\n" +
 		"                    <div class=\"expander-target expander-collapsed\">\n" +
-		"                        {{range $, $secret := $secrets}}\n" +
+		"                        {{range $, $secret := $group.Secrets}}\n" +
 		"                            {{template \"secret-rows\" $secret}}\n" +
 		"                        {{end}}\n" +
 		"                    </div>\n" +
@@ -82,6 +82,29 @@ func template_reportTemplate() string {
 		"            {{end}}\n" +
 		"        </div>\n" +
 		"    {{end}}\n" +
+		"\n" +
+		"    {{if .Skipped}}\n" +
+		"        <h4 class=\"mt-4\">Skipped</h4>\n" +
+		"        <p>These file changes hit a resource limit, so they weren't searched completely.</p>\n" +
+		"        <table class=\"table table-sm skipped\">\n" +
+		"            <tr>\n" +
+		"                <th scope=\"col\">Repo</th>\n" +
+		"                <th scope=\"col\">Commit</th>\n" +
+		"                <th scope=\"col\">File</th>\n" +
+		"                <th scope=\"col\">Reason</th>\n" +
+		"                <th scope=\"col\">Detail</th>\n" +
+		"            </tr>\n" +
+		"            {{range $, $skip := .Skipped}}\n" +
+		"                <tr>\n" +
+		"                    <td>{{$skip.RepoName}}</td>\n" +
+		"                    <td>{{template \"link\" $skip.CommitHashLinkShort}}</td>\n" +
+		"                    <td>{{$skip.FilePath}}</td>\n" +
+		"                    <td>{{$skip.Reason}}</td>\n" +
+		"                    <td>{{$skip.Detail}}</td>\n" +
+		"                </tr>\n" +
+		"            {{end}}\n" +
+		"        </table>\n" +
+		"    {{end}}\n" +
 		"</div>\n" +
 		"\n" +
 		"<p class=\"footer\">Report generated by {{template \"link\" .AppLink}}</p>\n" +
@@ -105,6 +128,13 @@ func template_reportTemplate() string {
 		"        <div class=\"col col-5 label\">\n" +
 		"            <a href=\"javascript:\" class=\"float-left expander-link material-icons\"></a>\n" +
 		"            Secret {{.ID}}\n" +
+		"            <span class=\"badge {{.RiskBadgeClass}}\" title=\"Risk score\">Risk {{.Risk.Label}}</span>\n" +
+		"            {{if .Verification}}\n" +
+		"                <span class=\"badge {{.VerificationBadgeClass}}\" title=\"Verification\">{{.Verification}}</span>\n" +
+		"            {{end}}\n" +
+		"            {{if .UnexpiredCert}}\n" +
+		"                <span class=\"badge badge-danger\" title=\"Paired with a certificate that hasn't expired\">unexpired certificate</span>\n" +
+		"            {{end}}\n" +
 		"        </div>\n" +
 		"        <div class=\"col col-7\">\n" +
 		"            <pre><code>{{ .Finding.BeforeCode }}<span\n" +
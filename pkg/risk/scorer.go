@@ -0,0 +1,222 @@
+package risk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/verify"
+)
+
+const (
+	MaxScore = 100
+
+	day = 24 * time.Hour
+)
+
+var (
+	// Paths that look like they configure a production environment
+	DefaultProdPathMatchStrings = []string{
+		`(?i)(^|[/._-])(prod|production|live|prd)([/._-]|$)`,
+		`(?i)(^|/)\.env(\.[a-z]+)?$`,
+		`(?i)(^|/)(settings|secrets?|credentials)\.[a-z]+$`,
+	}
+
+	severityPoints = map[Severity]int{
+		Low:      5,
+		Medium:   15,
+		High:     25,
+		Critical: 35,
+	}
+)
+
+type (
+	Scorer struct {
+		prodPathRes *manip.RegexpSet
+		now         func() time.Time
+	}
+
+	// Everything we know about a secret that affects its score
+	Input struct {
+		Severity        Severity
+		PresentAtHead   bool
+		NewestFindingAt time.Time
+		RepoCount       int
+		CommitCount     int
+		Paths           []string
+		PEMParsed       bool
+		Protected       bool
+		UnexpiredCert   bool
+		Verification    verify.Status
+	}
+
+	Score struct {
+		Total   int       `yaml:"total"`
+		Factors []*Factor `yaml:"factors"`
+	}
+
+	Factor struct {
+		Name   string `yaml:"name"`
+		Points int    `yaml:"points"`
+		Reason string `yaml:"reason"`
+	}
+)
+
+func NewScorer(prodPathMatchStrings []string, now func() time.Time) *Scorer {
+	if prodPathMatchStrings == nil {
+		prodPathMatchStrings = DefaultProdPathMatchStrings
+	}
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Scorer{
+		prodPathRes: manip.NewRegexpSetFromStringsMustCompile(prodPathMatchStrings),
+		now:         now,
+	}
+}
+
+func (s *Scorer) Score(input *Input) (result *Score) {
+	result = &Score{}
+
+	result.add("severity", severityPoints[input.Severity], input.Severity.Value()+" severity processor")
+	result.add(s.headFactor(input))
+	result.add(s.ageFactor(input))
+	result.add(s.repoSpreadFactor(input))
+	result.add(s.commitSpreadFactor(input))
+	result.add(s.prodPathFactor(input))
+	result.add(s.pemFactor(input))
+	result.add(s.protectedFactor(input))
+	result.add(s.certificateFactor(input))
+	result.add(s.verificationFactor(input))
+
+	if result.Total > MaxScore {
+		result.Total = MaxScore
+	}
+	if result.Total < 0 {
+		result.Total = 0
+	}
+
+	return
+}
+
+// Like "72/100"
+func (s *Score) Label() string {
+	return fmt.Sprintf("%d/%d", s.Total, MaxScore)
+}
+
+// One factor per line, like "severity: +35 (critical severity processor)"
+func (s *Score) Breakdown() string {
+	var sb strings.Builder
+	for _, factor := range s.Factors {
+		fmt.Fprintf(&sb, "%s: %+d (%s)\n", factor.Name, factor.Points, factor.Reason)
+	}
+	return sb.String()
+}
+
+func (s *Score) add(name string, points int, reason string) {
+	s.Factors = append(s.Factors, &Factor{Name: name, Points: points, Reason: reason})
+	s.Total += points
+}
+
+func (s *Scorer) headFactor(input *Input) (name string, points int, reason string) {
+	name = "head"
+	if input.PresentAtHead {
+		return name, 20, "still present at HEAD"
+	}
+	return name, 0, "no longer present at HEAD"
+}
+
+func (s *Scorer) ageFactor(input *Input) (name string, points int, reason string) {
+	name = "age"
+	if input.NewestFindingAt.IsZero() {
+		return name, 0, "age unknown"
+	}
+
+	age := s.now().Sub(input.NewestFindingAt)
+	ageDays := int(age / day)
+	reason = fmt.Sprintf("newest finding is %d days old", ageDays)
+
+	switch {
+	case age <= 30*day:
+		points = 15
+	case age <= 180*day:
+		points = 10
+	case age <= 365*day:
+		points = 5
+	}
+
+	return
+}
+
+func (s *Scorer) repoSpreadFactor(input *Input) (name string, points int, reason string) {
+	points = (input.RepoCount - 1) * 5
+	if points < 0 {
+		points = 0
+	}
+	if points > 10 {
+		points = 10
+	}
+
+	return "repos", points, fmt.Sprintf("found in %d repos", input.RepoCount)
+}
+
+func (s *Scorer) commitSpreadFactor(input *Input) (name string, points int, reason string) {
+	points = input.CommitCount - 1
+	if points < 0 {
+		points = 0
+	}
+	if points > 5 {
+		points = 5
+	}
+
+	return "commits", points, fmt.Sprintf("found in %d commits", input.CommitCount)
+}
+
+func (s *Scorer) prodPathFactor(input *Input) (name string, points int, reason string) {
+	name = "path"
+	for _, path := range input.Paths {
+		if s.prodPathRes.MatchAny(path) {
+			return name, 10, "production config path " + path
+		}
+	}
+	return name, 0, "no production config paths"
+}
+
+func (s *Scorer) pemFactor(input *Input) (name string, points int, reason string) {
+	name = "pem"
+	if input.PEMParsed {
+		return name, 5, "private key parsed successfully"
+	}
+	return name, 0, "not a parsed private key"
+}
+
+// Still a leak, but someone also needs the passphrase to use it
+func (s *Scorer) protectedFactor(input *Input) (name string, points int, reason string) {
+	name = "passphrase"
+	if input.Protected {
+		return name, -20, "key is passphrase-protected"
+	}
+	return name, 0, "not passphrase-protected"
+}
+
+func (s *Scorer) certificateFactor(input *Input) (name string, points int, reason string) {
+	name = "certificate"
+	if input.UnexpiredCert {
+		return name, 10, "paired with an unexpired certificate"
+	}
+	return name, 0, "no unexpired certificate"
+}
+
+func (s *Scorer) verificationFactor(input *Input) (name string, points int, reason string) {
+	name = "verification"
+	switch input.Verification {
+	case verify.Valid:
+		return name, 20, "verified as a live credential"
+	case verify.Invalid:
+		return name, -30, "verified as a dead credential"
+	default:
+		return name, 0, "not verified"
+	}
+}
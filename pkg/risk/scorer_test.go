@@ -0,0 +1,102 @@
+package risk_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/pantheon-systems/secrets-searcher/pkg/risk"
+	"github.com/pantheon-systems/secrets-searcher/pkg/verify"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	now     = time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	subject = NewScorer(nil, func() time.Time { return now })
+)
+
+func TestScorer_Score_Maximum(t *testing.T) {
+	input := &Input{
+		Severity:        Critical,
+		PresentAtHead:   true,
+		NewestFindingAt: now.Add(-24 * time.Hour),
+		RepoCount:       3,
+		CommitCount:     6,
+		Paths:           []string{"config/production/database.yml"},
+		PEMParsed:       true,
+		UnexpiredCert:   true,
+	}
+
+	// Fire
+	response := subject.Score(input)
+
+	require.Equal(t, MaxScore, response.Total)
+	require.Len(t, response.Factors, 10)
+}
+
+func TestScorer_Score_Minimum(t *testing.T) {
+	input := &Input{
+		Severity:        Low,
+		NewestFindingAt: now.AddDate(-3, 0, 0),
+		RepoCount:       1,
+		CommitCount:     1,
+		Paths:           []string{"docs/example.md"},
+	}
+
+	// Fire
+	response := subject.Score(input)
+
+	require.Equal(t, 5, response.Total)
+}
+
+func TestScorer_Score_AgeDecays(t *testing.T) {
+	recent := subject.Score(&Input{Severity: Medium, NewestFindingAt: now.AddDate(0, 0, -10)})
+	older := subject.Score(&Input{Severity: Medium, NewestFindingAt: now.AddDate(0, -4, 0)})
+	old := subject.Score(&Input{Severity: Medium, NewestFindingAt: now.AddDate(0, -10, 0)})
+
+	require.Greater(t, recent.Total, older.Total)
+	require.Greater(t, older.Total, old.Total)
+}
+
+func TestScorer_Score_ProdPath(t *testing.T) {
+	for _, path := range []string{"app/.env", "deploy/prod.yaml", "settings.py", "k8s/live/secret.yaml"} {
+		response := subject.Score(&Input{Severity: Low, Paths: []string{path}})
+
+		require.Equal(t, 15, response.Total, path)
+	}
+}
+
+func TestScorer_Score_Verification(t *testing.T) {
+	live := subject.Score(&Input{Severity: High, Verification: verify.Valid})
+	dead := subject.Score(&Input{Severity: High, Verification: verify.Invalid})
+
+	require.Equal(t, 45, live.Total)
+	require.Equal(t, 0, dead.Total)
+}
+
+func TestScorer_Score_Protected(t *testing.T) {
+	plain := subject.Score(&Input{Severity: Critical, PEMParsed: true})
+	protected := subject.Score(&Input{Severity: Critical, Protected: true})
+
+	require.Equal(t, 40, plain.Total)
+	require.Equal(t, 15, protected.Total)
+	require.Contains(t, protected.Breakdown(), "passphrase: -20 (key is passphrase-protected)\n")
+	require.Contains(t, plain.Breakdown(), "passphrase: +0 (not passphrase-protected)\n")
+}
+
+func TestScorer_Score_ProtectedNotBelowZero(t *testing.T) {
+
+	// Fire
+	response := subject.Score(&Input{Severity: Low, Protected: true})
+
+	require.Equal(t, 0, response.Total)
+}
+
+func TestScore_Breakdown(t *testing.T) {
+	response := subject.Score(&Input{Severity: High, PresentAtHead: true, RepoCount: 1, CommitCount: 1})
+
+	// Fire
+	breakdown := response.Breakdown()
+
+	require.Contains(t, breakdown, "severity: +25 (high severity processor)\n")
+	require.Contains(t, breakdown, "head: +20 (still present at HEAD)\n")
+}
@@ -0,0 +1,45 @@
+package risk
+
+//go:generate stringer -type Severity
+
+import "strings"
+
+type Severity int
+
+const (
+	Low Severity = iota
+	Medium
+	High
+	Critical
+)
+
+func Severities() []Severity {
+	return []Severity{
+		Low,
+		Medium,
+		High,
+		Critical,
+	}
+}
+
+func (i Severity) Value() string {
+	return strings.ToLower(i.String())
+}
+
+func NewSeverityFromValue(val string) Severity {
+	for _, e := range Severities() {
+		if e.Value() == val {
+			return e
+		}
+	}
+	panic("unknown severity: " + val)
+}
+
+func ValidSeverityValues() (result []string) {
+	severities := Severities()
+	result = make([]string, len(severities))
+	for i := range severities {
+		result[i] = severities[i].Value()
+	}
+	return
+}
@@ -0,0 +1,273 @@
+package search
+
+import (
+	"sort"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+)
+
+// Bump this when processors change what they find, so blob scans from older versions aren't reused
+const BlobCacheFormatVersion = 2
+
+type (
+	// Remembers what the processors submitted for each blob they searched, so the same blob at the same path
+	// in another commit, branch or repo doesn't have to be searched again. Processors look at the path too.
+	BlobCache struct {
+		rulesetVersion string
+		db             *database.Database
+		log            logg.Logg
+	}
+
+	// Records what's submitted while a blob is searched
+	BlobScanRecorder struct {
+		contract.WorkerJobI
+		events []*database.BlobScanEvent
+	}
+
+	// Stands in for the processor that submitted a cached result
+	blobScanProcessor string
+)
+
+func NewBlobCache(rulesetVersion string, db *database.Database, log logg.Logg) *BlobCache {
+	return &BlobCache{
+		rulesetVersion: rulesetVersion,
+		db:             db,
+		log:            log,
+	}
+}
+
+// Submits what was recorded for the file change's blob again, if every line being searched was searched before.
+// Binary file changes are searched as a whole blob.
+func (c *BlobCache) Replay(job contract.WorkerJobI, searchedLines map[int]bool, wholeBlob bool) (ok bool) {
+	fileChange := job.FileChange()
+	if fileChange.BlobHash == "" {
+		return
+	}
+
+	id := c.scanID(fileChange.BlobHash, fileChange.Path)
+	if !c.db.BlobScanExists(id) {
+		return
+	}
+
+	scan, err := c.db.GetBlobScan(id)
+	if err != nil {
+		errors.ErrLog(job.Log(c.log), err).Warn("unable to read blob scan, searching blob again")
+		return
+	}
+
+	if !scanCovers(scan, searchedLines, wholeBlob) {
+		return
+	}
+
+	job.Log(c.log).WithField("blobHash", fileChange.BlobHash).Debug("replaying blob scan")
+
+	for _, event := range scan.Events {
+		if !wholeBlob && !overlapsLines(event.FileRange, searchedLines) {
+			continue
+		}
+
+		job.SearchingWithProcessor(blobScanProcessor(event.Processor))
+		job.SearchingLine(event.FileRange.StartLineNum)
+
+		if event.Ignore {
+			job.SubmitIgnore(event.FileRange)
+			continue
+		}
+
+		job.SubmitResult(resultFromEvent(event))
+	}
+
+	return true
+}
+
+// Decorates the job so what's submitted can be saved for the blob
+func (c *BlobCache) Recorder(job contract.WorkerJobI) *BlobScanRecorder {
+	return &BlobScanRecorder{WorkerJobI: job}
+}
+
+// Saves what was recorded, keeping what was recorded before for lines that weren't searched this time
+func (c *BlobCache) Save(recorder *BlobScanRecorder, searchedLines map[int]bool, wholeBlob bool) (err error) {
+	fileChange := recorder.FileChange()
+	id := c.scanID(fileChange.BlobHash, fileChange.Path)
+
+	scan := &database.BlobScan{
+		ID:             id,
+		BlobHash:       fileChange.BlobHash,
+		Path:           fileChange.Path,
+		RulesetVersion: c.rulesetVersion,
+		WholeBlob:      wholeBlob,
+	}
+
+	if !wholeBlob && c.db.BlobScanExists(id) {
+		var prevScan *database.BlobScan
+		if prevScan, err = c.db.GetBlobScan(id); err != nil {
+			return errors.WithMessagev(err, "unable to read blob scan", id)
+		}
+
+		for _, lineNum := range prevScan.SearchedLines {
+			if !searchedLines[lineNum] {
+				scan.SearchedLines = append(scan.SearchedLines, lineNum)
+			}
+		}
+		for _, event := range prevScan.Events {
+			if !overlapsLines(event.FileRange, searchedLines) {
+				scan.Events = append(scan.Events, event)
+			}
+		}
+	}
+
+	for lineNum := range searchedLines {
+		scan.SearchedLines = append(scan.SearchedLines, lineNum)
+	}
+	sort.Ints(scan.SearchedLines)
+	scan.Events = append(scan.Events, recorder.events...)
+
+	if err = c.db.WriteBlobScan(scan); err != nil {
+		return errors.WithMessagev(err, "unable to write blob scan", id)
+	}
+
+	return
+}
+
+// Deletes the blob scans saved by other ruleset versions, they'd never be replayed
+func (c *BlobCache) Prune() (err error) {
+	var deleted int
+	if deleted, err = c.db.DeleteBlobScansExcept(c.rulesetVersion); err != nil {
+		return errors.WithMessage(err, "unable to delete blob scans")
+	}
+
+	c.log.WithField("deleted", deleted).Debug("pruned blob scans from other ruleset versions")
+
+	return
+}
+
+func (c *BlobCache) scanID(blobHash, path string) string {
+	return database.CreateHashID(blobHash, path, c.rulesetVersion)
+}
+
+func scanCovers(scan *database.BlobScan, searchedLines map[int]bool, wholeBlob bool) bool {
+	if scan.WholeBlob || wholeBlob {
+		return scan.WholeBlob && wholeBlob
+	}
+
+	scanned := make(map[int]bool, len(scan.SearchedLines))
+	for _, lineNum := range scan.SearchedLines {
+		scanned[lineNum] = true
+	}
+	for lineNum := range searchedLines {
+		if !scanned[lineNum] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Multi-line results can start on a line that isn't searched, like a key whose header didn't change
+func overlapsLines(fileRange *manip.FileRange, lines map[int]bool) bool {
+	for lineNum := fileRange.StartLineNum; lineNum <= fileRange.EndLineNum; lineNum++ {
+		if lines[lineNum] {
+			return true
+		}
+	}
+	return false
+}
+
+//
+// Recorder
+
+func (r *BlobScanRecorder) SubmitResult(result *contract.Result) {
+	r.events = append(r.events, &database.BlobScanEvent{
+		Processor:        r.Processor().GetName(),
+		FileRange:        result.FileRange,
+		ContextFileRange: result.ContextFileRange,
+		SecretValue:      result.SecretValue,
+		FileBasename:     result.FileBasename,
+		SecretExtras:     eventExtras(result.SecretExtras),
+		FindingExtras:    eventExtras(result.FindingExtras),
+		Section:          eventSection(result.Section),
+	})
+
+	r.WorkerJobI.SubmitResult(result)
+}
+
+func (r *BlobScanRecorder) SubmitIgnore(fileRange *manip.FileRange) {
+	r.events = append(r.events, &database.BlobScanEvent{
+		Processor: r.Processor().GetName(),
+		Ignore:    true,
+		FileRange: fileRange,
+	})
+
+	r.WorkerJobI.SubmitIgnore(fileRange)
+}
+
+func (p blobScanProcessor) GetName() string {
+	return string(p)
+}
+
+//
+// Conversion
+
+func eventExtras(extras []*contract.ResultExtra) (result []*database.BlobScanExtra) {
+	for _, extra := range extras {
+		result = append(result, &database.BlobScanExtra{
+			Key:    extra.Key,
+			Header: extra.Header,
+			Value:  extra.Value,
+			Code:   extra.Code,
+			URL:    extra.URL,
+			Debug:  extra.Debug,
+		})
+	}
+	return
+}
+
+func eventSection(section *contract.ResultSection) *database.BlobScanSection {
+	if section == nil {
+		return nil
+	}
+	return &database.BlobScanSection{
+		Name:      section.Name,
+		Contents:  section.Contents,
+		FileRange: section.FileRange,
+	}
+}
+
+func resultFromEvent(event *database.BlobScanEvent) (result *contract.Result) {
+	result = &contract.Result{
+		FileRange:        event.FileRange,
+		ContextFileRange: event.ContextFileRange,
+		SecretValue:      event.SecretValue,
+		FileBasename:     event.FileBasename,
+		SecretExtras:     resultExtras(event.SecretExtras),
+		FindingExtras:    resultExtras(event.FindingExtras),
+	}
+
+	if event.Section != nil {
+		result.Section = &contract.ResultSection{
+			Name:      event.Section.Name,
+			Contents:  event.Section.Contents,
+			FileRange: event.Section.FileRange,
+		}
+	}
+
+	return
+}
+
+func resultExtras(extras []*database.BlobScanExtra) (result []*contract.ResultExtra) {
+	for _, extra := range extras {
+		result = append(result, &contract.ResultExtra{
+			Key:    extra.Key,
+			Header: extra.Header,
+			Value:  extra.Value,
+			Code:   extra.Code,
+			URL:    extra.URL,
+			Debug:  extra.Debug,
+		})
+	}
+	return
+}
@@ -0,0 +1,187 @@
+package search_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func blobCacheJob(t *testing.T, commitHash string) *Job {
+	return blobCacheJobAt(t, commitHash, "vendor/lib/config.php")
+}
+
+func blobCacheJobAt(t *testing.T, commitHash, path string) *Job {
+	job := NewJob("job", "repoID", "repo", nil, nil, "", false, nil, logg.NewLogrusLogg(logrus.New()), nil)
+	_, err := job.Start()
+	require.NoError(t, err)
+
+	job.SearchingCommit(&git.Commit{Hash: commitHash})
+	job.SearchingFileChange(&git.FileChange{Path: path, BlobHash: "blobHash"})
+
+	return job
+}
+
+func TestBlobCache_Replay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blob-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log := logg.NewLogrusLogg(logrus.New())
+	db, err := database.New(dir, log)
+	require.NoError(t, err)
+	subject := NewBlobCache("rulesetVersion", db, log)
+
+	// Search the blob in one commit
+	firstJob := blobCacheJob(t, "commit1")
+	recorder := subject.Recorder(firstJob)
+	recorder.SearchingWithProcessor(proc{"proc"})
+	recorder.SearchingLine(3)
+	recorder.SubmitResult(&contract.Result{
+		FileRange:     &manip.FileRange{StartLineNum: 3, StartIndex: 10, EndLineNum: 3, EndIndex: 20},
+		SecretValue:   "s3cr3t-value",
+		FindingExtras: []*contract.ResultExtra{{Key: "key", Header: "Header", Value: "value"}},
+	})
+	recorder.SearchingLine(5)
+	recorder.SubmitIgnore(&manip.FileRange{StartLineNum: 5, StartIndex: 0, EndLineNum: 5, EndIndex: 4})
+	require.NoError(t, subject.Save(recorder, map[int]bool{3: true, 5: true}, false))
+
+	// Fire
+	secondJob := blobCacheJob(t, "commit2")
+	ok := subject.Replay(secondJob, map[int]bool{3: true}, false)
+
+	require.True(t, ok)
+	results := secondJob.GetJobResults()
+	require.Len(t, results, 1)
+	require.Equal(t, "proc", results[0].Processor.GetName())
+	require.Equal(t, "s3cr3t-value", results[0].SecretValue)
+	require.Equal(t, 10, results[0].FileRange.StartIndex)
+	require.Equal(t, "value", results[0].FindingExtras[0].Value)
+}
+
+// Like a key whose header line didn't change when its body did
+func TestBlobCache_Replay_MultiLine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blob-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log := logg.NewLogrusLogg(logrus.New())
+	db, err := database.New(dir, log)
+	require.NoError(t, err)
+	subject := NewBlobCache("rulesetVersion", db, log)
+
+	firstJob := blobCacheJob(t, "commit1")
+	recorder := subject.Recorder(firstJob)
+	recorder.SearchingWithProcessor(proc{"proc"})
+	recorder.SearchingLine(1)
+	recorder.SubmitResult(&contract.Result{
+		FileRange:   &manip.FileRange{StartLineNum: 1, StartIndex: 0, EndLineNum: 4, EndIndex: 10},
+		SecretValue: "multi-line-value",
+	})
+	require.NoError(t, subject.Save(recorder, map[int]bool{1: true, 2: true, 3: true, 4: true}, false))
+
+	// Fire
+	secondJob := blobCacheJob(t, "commit2")
+	ok := subject.Replay(secondJob, map[int]bool{2: true, 3: true}, false)
+
+	require.True(t, ok)
+	results := secondJob.GetJobResults()
+	require.Len(t, results, 1)
+	require.Equal(t, "multi-line-value", results[0].SecretValue)
+}
+
+func TestBlobCache_Replay_LinesNotSearched(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blob-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log := logg.NewLogrusLogg(logrus.New())
+	db, err := database.New(dir, log)
+	require.NoError(t, err)
+	subject := NewBlobCache("rulesetVersion", db, log)
+
+	firstJob := blobCacheJob(t, "commit1")
+	require.NoError(t, subject.Save(subject.Recorder(firstJob), map[int]bool{3: true}, false))
+
+	// Fire
+	ok := subject.Replay(blobCacheJob(t, "commit2"), map[int]bool{3: true, 4: true}, false)
+	otherRulesetOK := NewBlobCache("otherRulesetVersion", db, log).Replay(blobCacheJob(t, "commit2"), map[int]bool{3: true}, false)
+
+	require.False(t, ok)
+	require.False(t, otherRulesetOK)
+}
+
+func TestBlobCache_Replay_OtherPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blob-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log := logg.NewLogrusLogg(logrus.New())
+	db, err := database.New(dir, log)
+	require.NoError(t, err)
+	subject := NewBlobCache("rulesetVersion", db, log)
+
+	// The same blob finds a secret at one path and nothing at the other
+	recorder := subject.Recorder(blobCacheJobAt(t, "commit1", "config.yml"))
+	recorder.SearchingWithProcessor(proc{"proc"})
+	recorder.SearchingLine(3)
+	recorder.SubmitResult(&contract.Result{
+		FileRange:    &manip.FileRange{StartLineNum: 3, StartIndex: 10, EndLineNum: 3, EndIndex: 20},
+		SecretValue:  "s3cr3t-value",
+		FileBasename: "config.yml",
+	})
+	require.NoError(t, subject.Save(recorder, map[int]bool{3: true}, false))
+	exampleRecorder := subject.Recorder(blobCacheJobAt(t, "commit1", "config.yml.example"))
+	require.NoError(t, subject.Save(exampleRecorder, map[int]bool{3: true}, false))
+
+	// Fire
+	job := blobCacheJobAt(t, "commit2", "config.yml")
+	ok := subject.Replay(job, map[int]bool{3: true}, false)
+	exampleJob := blobCacheJobAt(t, "commit2", "config.yml.example")
+	exampleOK := subject.Replay(exampleJob, map[int]bool{3: true}, false)
+	newPathOK := subject.Replay(blobCacheJobAt(t, "commit2", "config.yml.bak"), map[int]bool{3: true}, false)
+
+	require.True(t, ok)
+	results := job.GetJobResults()
+	require.Len(t, results, 1)
+	require.Equal(t, "config.yml", results[0].FileBaseName)
+	require.True(t, exampleOK)
+	require.Empty(t, exampleJob.GetJobResults())
+	require.False(t, newPathOK)
+}
+
+func TestBlobCache_Prune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blob-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log := logg.NewLogrusLogg(logrus.New())
+	db, err := database.New(dir, log)
+	require.NoError(t, err)
+	oldCache := NewBlobCache("oldRulesetVersion", db, log)
+	require.NoError(t, oldCache.Save(oldCache.Recorder(blobCacheJob(t, "commit1")), map[int]bool{3: true}, false))
+	subject := NewBlobCache("rulesetVersion", db, log)
+	require.NoError(t, subject.Save(subject.Recorder(blobCacheJob(t, "commit1")), map[int]bool{3: true}, false))
+
+	// Fire
+	err = subject.Prune()
+
+	require.NoError(t, err)
+	require.True(t, subject.Replay(blobCacheJob(t, "commit2"), map[int]bool{3: true}, false))
+	require.Equal(t, 1, countFiles(t, filepath.Join(dir, "blob-scan")))
+}
+
+func countFiles(t *testing.T, dir string) int {
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	return len(files)
+}
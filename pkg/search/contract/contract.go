@@ -1,11 +1,17 @@
 package contract
 
 import (
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
 	"github.com/pantheon-systems/secrets-searcher/pkg/git"
 	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
 	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
 )
 
+// Returned by processors that stop because the file change's deadline passed
+var ErrDeadlineExceeded = errors.New("file change deadline exceeded")
+
 type (
 
 	//
@@ -29,6 +35,18 @@ type (
 		GetJobResults() []*JobResult
 	}
 
+	// The file changes of a commit are searched concurrently, each with its own job
+	ForksFileChangesI interface {
+		ForFileChange(fileChange *git.FileChange) WorkerJobI
+		FinishFileChange()
+	}
+
+	// Processors stop searching a file change once its deadline passes
+	HasDeadlineI interface {
+		SetDeadline(deadline time.Time)
+		DeadlineExceeded() bool
+	}
+
 	AcceptsContext interface {
 		SearchingCommit(commit *git.Commit)
 		SearchingWithProcessor(proc NamedProcessorI)
@@ -38,6 +56,7 @@ type (
 		SearchingLine(line int)
 	}
 	HasContext interface {
+		RepoName() (repoName string)
 		Commit() (commit *git.Commit)
 		Processor() (proc NamedProcessorI)
 	}
@@ -69,11 +88,13 @@ type (
 		HasContext
 		AcceptsLineContext
 		HasFileChangeContext
+		HasDeadlineI
 	}
 
 	WorkerJobI interface {
 		DealsWithProcessor
 		IsManaged
+		ForksFileChangesI
 	}
 	ProcessorJobI interface {
 		DealsWithProcessor
@@ -106,6 +127,28 @@ type (
 		FindsResultsInLineI
 	}
 
+	// Binary file changes have no diff, so only processors that ask for them get them
+	AcceptsBinaryI interface {
+		AcceptsBinaryFileChange(fileChange *git.FileChange) bool
+	}
+
+	// Processors that do more than submit results are run even when a blob's results are cached
+	RunsUncachedI interface {
+		RunsUncached() bool
+	}
+
+	// Processors that run line processors on lines of their own, like notebook cells. Lines returns the
+	// line numbers in the file the prefilter found the line processor's keywords in, unless it isn't filtered.
+	RunsLineProcessorsI interface {
+		FindResultsInPrefilteredFileChange(job ProcessorJobI, lines func(lineProcName string) (lineNums map[int]bool, filtered bool)) (err error)
+	}
+
+	// Line processors that can only find something in lines with one of their keywords.
+	// Without keywords, the processor is run on every line.
+	HasKeywordsI interface {
+		Keywords() []string
+	}
+
 	//
 	// Result
 
@@ -116,6 +159,7 @@ type (
 		SecretExtras     []*ResultExtra
 		FindingExtras    []*ResultExtra
 		FileBasename     string
+		Section          *ResultSection
 	}
 	LineResult struct {
 		LineRange        *manip.LineRange
@@ -124,6 +168,14 @@ type (
 		SecretExtras     []*ResultExtra
 		FindingExtras    []*ResultExtra
 	}
+
+	// Part of a file that was searched on its own, like a notebook cell. The file range
+	// is where the result is in the section's contents.
+	ResultSection struct {
+		Name      string
+		Contents  string
+		FileRange *manip.FileRange
+	}
 	ResultExtra struct {
 		Key    string
 		Header string
@@ -144,5 +196,14 @@ type (
 		SecretValue      string
 		SecretExtras     []*ResultExtra
 		FindingExtras    []*ResultExtra
+		Section          *ResultSection
 	}
 )
+
+// Appends an extra for the value, unless the value is empty
+func AppendExtra(extras []*ResultExtra, key, header, value string) []*ResultExtra {
+	if value == "" {
+		return extras
+	}
+	return append(extras, &ResultExtra{Key: key, Header: header, Value: value})
+}
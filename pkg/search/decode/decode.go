@@ -0,0 +1,284 @@
+package decode
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+)
+
+const (
+	ChainExtraKey = "decode-chain"
+
+	Base64Step = "base64"
+	HexStep    = "hex"
+	URLStep    = "url"
+	GzipStep   = "gzip"
+	JSONStep   = "json"
+
+	MaxDepth = 8
+
+	// Gzipped values that unzip to more than this don't decode
+	MaxGunzipSize = 1 << 20
+
+	candidateMinLen = 16
+)
+
+var (
+	candidateRegex = regexp.MustCompile(`(?:[A-Za-z0-9+/_.~\-]|%[0-9A-Fa-f]{2})+={0,2}`)
+	hexRegex       = regexp.MustCompile(`^(?:[0-9A-Fa-f]{2})+$`)
+	gzipMagic      = []byte{0x1f, 0x8b}
+
+	base64Encodings = []*base64.Encoding{
+		base64.StdEncoding,
+		base64.RawStdEncoding,
+		base64.URLEncoding,
+		base64.RawURLEncoding,
+	}
+)
+
+type (
+	// Decodes encoded values found in a line, and the values encoded inside those, up to a depth
+	Pipeline struct {
+		maxDepth int
+	}
+
+	// A layer of decoded content, with the steps it took to get to it
+	Decoded struct {
+		Value string
+
+		// Set when the value came from a field of a decoded JSON document
+		Key string
+
+		Chain []string
+	}
+)
+
+func NewPipeline(maxDepth int) *Pipeline {
+	return &Pipeline{
+		maxDepth: maxDepth,
+	}
+}
+
+// Ranges in the line that might be encoded values
+func (p *Pipeline) Candidates(line string) (result []*manip.LineRange) {
+	for _, match := range candidateRegex.FindAllStringIndex(line, -1) {
+		if match[1]-match[0] < candidateMinLen {
+			continue
+		}
+		result = append(result, manip.NewLineRange(match[0], match[1]))
+	}
+
+	return
+}
+
+// Every layer the value decodes to cleanly, outermost first
+func (p *Pipeline) Decode(value string) (result []*Decoded) {
+	return p.decode(value, nil)
+}
+
+func (p *Pipeline) decode(value string, chain []string) (result []*Decoded) {
+	if encodingDepth(chain) >= p.maxDepth {
+		return
+	}
+
+	decoded, steps, ok := decodeValue(value)
+	if !ok {
+		return
+	}
+
+	for _, layer := range layers(decoded, append(append([]string{}, chain...), steps...)) {
+		result = append(result, layer)
+
+		// JSON documents are searched as they are, their fields are decoded further
+		if layer.Key == "" && layer.Chain[len(layer.Chain)-1] == JSONStep {
+			continue
+		}
+
+		result = append(result, p.decode(layer.Value, layer.Chain)...)
+	}
+
+	return
+}
+
+// Decoding chain, ending with what was matched in the decoded content, like "base64 -> json -> password"
+func (d *Decoded) ChainString(matched string) string {
+	steps := append([]string{}, d.Chain...)
+	if matched != "" {
+		steps = append(steps, matched)
+	}
+
+	return strings.Join(steps, " -> ")
+}
+
+func (d *Decoded) ChainExtra(matched string) *contract.ResultExtra {
+	return &contract.ResultExtra{
+		Key:    ChainExtraKey,
+		Header: "Decoded from",
+		Value:  d.ChainString(matched),
+		Code:   true,
+	}
+}
+
+// Lines of the decoded value
+func (d *Decoded) Lines() []string {
+	return strings.Split(strings.TrimSuffix(d.Value, "\n"), "\n")
+}
+
+// JSON documents are broken up into their string fields, followed by the document itself
+func layers(value string, chain []string) (result []*Decoded) {
+	fields, ok := jsonFields(value)
+	if !ok {
+		return []*Decoded{{Value: value, Chain: chain}}
+	}
+
+	jsonChain := append(append([]string{}, chain...), JSONStep)
+	for _, field := range fields {
+		result = append(result, &Decoded{Value: field[1], Key: field[0], Chain: jsonChain})
+	}
+	result = append(result, &Decoded{Value: value, Chain: jsonChain})
+
+	return
+}
+
+func decodeValue(value string) (result string, steps []string, ok bool) {
+	if strings.Contains(value, "%") {
+		if decoded, err := url.QueryUnescape(value); err == nil && decoded != value && isText(decoded) {
+			return decoded, []string{URLStep}, true
+		}
+	}
+
+	if len(value) < candidateMinLen {
+		return
+	}
+
+	if hexRegex.MatchString(value) {
+		if decoded, err := hex.DecodeString(value); err == nil && isText(string(decoded)) {
+			return string(decoded), []string{HexStep}, true
+		}
+	}
+
+	decoded, ok := decodeBase64(value)
+	if !ok {
+		return
+	}
+	if bytes.HasPrefix(decoded, gzipMagic) {
+		result, ok = gunzip(decoded)
+		return result, []string{Base64Step, GzipStep}, ok
+	}
+	if ok = isText(string(decoded)); ok {
+		return string(decoded), []string{Base64Step}, true
+	}
+
+	return
+}
+
+// Number of encodings that were decoded, JSON isn't an encoding
+func encodingDepth(chain []string) (result int) {
+	for _, step := range chain {
+		if step != JSONStep {
+			result++
+		}
+	}
+
+	return
+}
+
+func decodeBase64(value string) (result []byte, ok bool) {
+	for _, encoding := range base64Encodings {
+		var err error
+		if result, err = encoding.DecodeString(value); err == nil {
+			return result, true
+		}
+	}
+
+	return
+}
+
+func gunzip(data []byte) (result string, ok bool) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	unzipped, err := ioutil.ReadAll(io.LimitReader(reader, MaxGunzipSize+1))
+	if err != nil || len(unzipped) > MaxGunzipSize || !isText(string(unzipped)) {
+		return
+	}
+
+	return string(unzipped), true
+}
+
+// Key and value of each string field in a JSON object, sorted by key path
+func jsonFields(value string) (result [][2]string, ok bool) {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal([]byte(trimmed), &doc); err != nil {
+		return
+	}
+
+	fields := map[string][2]string{}
+	collectJSONFields(doc, "", "", fields)
+
+	paths := make([]string, 0, len(fields))
+	for path := range fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		result = append(result, fields[path])
+	}
+
+	return result, true
+}
+
+func collectJSONFields(node interface{}, path, key string, fields map[string][2]string) {
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		for childKey, child := range typed {
+			collectJSONFields(child, path+"."+childKey, childKey, fields)
+		}
+	case []interface{}:
+		for i, child := range typed {
+			collectJSONFields(child, path+"["+strconv.Itoa(i)+"]", key, fields)
+		}
+	case string:
+		if typed != "" {
+			fields[path] = [2]string{key, typed}
+		}
+	}
+}
+
+// Decoded bytes are only followed when they look like text
+func isText(value string) bool {
+	if value == "" || !utf8.ValidString(value) {
+		return false
+	}
+
+	for _, r := range value {
+		if !unicode.IsPrint(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,110 @@
+package decode_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/decode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline_Decode_Base64JSON(t *testing.T) {
+	subject := NewPipeline(3)
+
+	// {"db":{"user":"app","password":"hunter2-prod"}}
+	value := "eyJkYiI6eyJ1c2VyIjoiYXBwIiwicGFzc3dvcmQiOiJodW50ZXIyLXByb2QifX0="
+
+	// Fire
+	result := subject.Decode(value)
+
+	require.Len(t, result, 3)
+	require.Equal(t, "password", result[0].Key)
+	require.Equal(t, "hunter2-prod", result[0].Value)
+	require.Equal(t, "base64 -> json -> password", result[0].ChainString(result[0].Key))
+	require.Equal(t, "user", result[1].Key)
+	require.Equal(t, `{"db":{"user":"app","password":"hunter2-prod"}}`, result[2].Value)
+	require.Equal(t, "base64 -> json", result[2].ChainString(""))
+}
+
+func TestPipeline_Decode_GzipBase64(t *testing.T) {
+	subject := NewPipeline(3)
+
+	// Fire
+	result := subject.Decode("H4sIAAAAAAACAyvJz07Ns01MSjY0MgYAkklT0gwAAAA=")
+
+	require.Len(t, result, 1)
+	require.Equal(t, "token=abc123", result[0].Value)
+	require.Equal(t, "base64 -> gzip", result[0].ChainString(""))
+}
+
+func TestPipeline_Decode_GzipTooLarge(t *testing.T) {
+	subject := NewPipeline(3)
+	var zipped bytes.Buffer
+	writer := gzip.NewWriter(&zipped)
+	_, err := writer.Write([]byte("token=" + strings.Repeat("a", MaxGunzipSize)))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	// Fire
+	result := subject.Decode(base64.StdEncoding.EncodeToString(zipped.Bytes()))
+
+	require.Empty(t, result)
+}
+
+func TestPipeline_Decode_Hex(t *testing.T) {
+	subject := NewPipeline(3)
+
+	// Fire
+	result := subject.Decode("6170695f7365637265743d733363723374")
+
+	require.Len(t, result, 1)
+	require.Equal(t, "api_secret=s3cr3t", result[0].Value)
+	require.Equal(t, []string{HexStep}, result[0].Chain)
+}
+
+func TestPipeline_Decode_URL(t *testing.T) {
+	subject := NewPipeline(3)
+
+	// Fire
+	result := subject.Decode("key%3Da%26secret%3DZx9%21q")
+
+	require.Len(t, result, 1)
+	require.Equal(t, "key=a&secret=Zx9!q", result[0].Value)
+	require.Equal(t, []string{URLStep}, result[0].Chain)
+}
+
+func TestPipeline_Decode_DepthLimit(t *testing.T) {
+	value := "Y0dGemMzZHZjbVE2SUdsdWJtVnlMWE5sWTNKbGRDMTJZV3gxWlE9PQ=="
+
+	// Fire
+	shallow := NewPipeline(1).Decode(value)
+	deep := NewPipeline(2).Decode(value)
+
+	require.Len(t, shallow, 1)
+	require.Len(t, deep, 2)
+	require.Equal(t, "password: inner-secret-value", deep[1].Value)
+	require.Equal(t, "base64 -> base64", deep[1].ChainString(""))
+}
+
+func TestPipeline_Decode_NotEncoded(t *testing.T) {
+	subject := NewPipeline(3)
+
+	// Fire
+	result := subject.Decode("ThisIsJustALongIdentifierName")
+
+	require.Empty(t, result)
+}
+
+func TestPipeline_Candidates(t *testing.T) {
+	subject := NewPipeline(3)
+	line := `config: "eyJkYiI6eyJ1c2VyIjoiYXBwIn19", short: "abc"`
+
+	// Fire
+	result := subject.Candidates(line)
+
+	require.Len(t, result, 1)
+	require.Equal(t, "eyJkYiI6eyJ1c2VyIjoiYXBwIn19", result[0].ExtractValue(line).Value)
+}
@@ -0,0 +1,25 @@
+package search
+
+import "sync"
+
+// Bounds how many file changes are searched at once across all workers, which also bounds
+// how many file changes are held in memory
+type FileChangePool struct {
+	slots chan struct{}
+}
+
+func NewFileChangePool(size int) *FileChangePool {
+	return &FileChangePool{slots: make(chan struct{}, size)}
+}
+
+// Blocks until there's a free slot, then runs the function in a goroutine
+func (p *FileChangePool) Go(wg *sync.WaitGroup, fn func()) {
+	p.slots <- struct{}{}
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		defer func() { <-p.slots }()
+		fn()
+	}()
+}
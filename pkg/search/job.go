@@ -2,6 +2,9 @@ package search
 
 import (
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/pantheon-systems/secrets-searcher/pkg/stats"
 
@@ -30,12 +33,22 @@ type (
 		startedFlag bool
 
 		// Results
-		results []*contract.JobResult
-		ignores map[*git.FileChange][]*manip.FileRange
+		collected *jobResults
+		ignores   map[*git.FileChange][]*manip.FileRange
 
 		scope    *Scope
 		scopeLog logg.Logg
 
+		// Zero for no deadline
+		deadline time.Time
+	}
+
+	// Shared by a job and the jobs for its file changes
+	jobResults struct {
+		mutex   *sync.Mutex
+		stream  func(result *contract.JobResult)
+		results []*contract.JobResult
+
 		// Stats
 		secretTracker manip.Set
 	}
@@ -52,11 +65,53 @@ func NewJob(name, repoID, repoName string, repository *git.Repository, commitHas
 		bar:          bar,
 		log:          log,
 		jobState: jobState{
-			scope:         NewScope(enableProfiling, stats),
-			secretTracker: manip.NewEmptyBasicSet(),
-			ignores:       make(map[*git.FileChange][]*manip.FileRange),
+			scope: NewScope(enableProfiling, stats),
+			collected: &jobResults{
+				mutex:         &sync.Mutex{},
+				secretTracker: manip.NewEmptyBasicSet(),
+			},
+			ignores: make(map[*git.FileChange][]*manip.FileRange),
+		},
+	}
+}
+
+// Results are passed to the function as they're submitted instead of being kept for GetJobResults
+func (j *Job) StreamResults(fn func(result *contract.JobResult)) {
+	j.collected.stream = fn
+}
+
+// A job for one of the current commit's file changes, so the commit's file changes can be
+// searched concurrently. Its results are collected by this job.
+func (j *Job) ForFileChange(fileChange *git.FileChange) contract.WorkerJobI {
+	result := &Job{
+		name:     j.name,
+		repoID:   j.repoID,
+		repoName: j.repoName,
+		log:      j.log,
+		jobState: jobState{
+			startedFlag: true,
+			collected:   j.collected,
+			ignores:     make(map[*git.FileChange][]*manip.FileRange),
+			scope:       j.scope.ForkCommit(),
 		},
 	}
+	result.SearchingFileChange(fileChange)
+
+	return result
+}
+
+func (j *Job) SetDeadline(deadline time.Time) {
+	j.deadline = deadline
+}
+
+func (j *Job) DeadlineExceeded() bool {
+	return !j.deadline.IsZero() && time.Now().After(j.deadline)
+}
+
+func (j *Job) FinishFileChange() {
+	delete(j.ignores, j.scope.FileChange)
+	j.scope.FinishFileChange()
+	j.scopeLog = nil
 }
 
 func (j *Job) Start() (result []*git.Commit, err error) {
@@ -95,6 +150,15 @@ func (j *Job) SearchingLine(line int) {
 	j.scope.StartLine(line)
 }
 
+// Identifies the repo's chunk of commits, which is the same across runs as long as the commits are
+func (j *Job) CheckpointID() string {
+	return checkpointID(j.repoID, j.commitHashes)
+}
+
+func (j *Job) RepoName() (repoName string) {
+	return j.repoName
+}
+
 func (j *Job) Commit() (commit *git.Commit) {
 	return j.scope.Commit
 }
@@ -171,7 +235,7 @@ func (j *Job) SubmitResult(result *contract.Result) {
 	j.ignores[j.scope.FileChange] = append(j.ignores[j.scope.FileChange], result.FileRange)
 
 	// Build result
-	j.results = append(j.results, &contract.JobResult{
+	j.collect(&contract.JobResult{
 		RepoID:           j.repoID,
 		Processor:        j.scope.Proc,
 		FileChange:       j.scope.FileChange,
@@ -181,10 +245,23 @@ func (j *Job) SubmitResult(result *contract.Result) {
 		FileBaseName:     result.FileBasename,
 		SecretExtras:     result.SecretExtras,
 		FindingExtras:    result.FindingExtras,
+		Section:          result.Section,
 	})
+}
+
+func (j *Job) collect(jobResult *contract.JobResult) {
+	j.collected.mutex.Lock()
+	j.collected.secretTracker.Add(database.CreateHashID(jobResult.SecretValue))
+	stream := j.collected.stream
+	if stream == nil {
+		j.collected.results = append(j.collected.results, jobResult)
+	}
+	j.collected.mutex.Unlock()
 
-	// For stats
-	j.secretTracker.Add(database.CreateHashID(result.SecretValue))
+	// Streamed outside of the lock so a slow consumer doesn't block the other file changes
+	if stream != nil {
+		stream(jobResult)
+	}
 }
 
 func (j *Job) SubmitIgnore(fileRange *manip.FileRange) {
@@ -240,7 +317,9 @@ func (j *Job) Finish() {
 	j.scope.FinishRepo()
 
 	if j.bar != nil {
-		secretsFound := j.secretTracker.Len()
+		j.collected.mutex.Lock()
+		secretsFound := j.collected.secretTracker.Len()
+		j.collected.mutex.Unlock()
 		message := fmt.Sprintf("%d commits searched, %d secrets found",
 			len(j.commitHashes), secretsFound)
 
@@ -251,15 +330,21 @@ func (j *Job) Finish() {
 }
 
 func (j *Job) GetJobResults() []*contract.JobResult {
-	results := *&j.results
+	j.collected.mutex.Lock()
+	results := j.collected.results
+	j.collected.mutex.Unlock()
 
-	// Self-destruct for GC
+	j.Release()
+
+	return results
+}
+
+// Self-destruct for GC
+func (j *Job) Release() {
 	j.repository = nil
-	j.results = nil
+	j.collected.results = nil
 	j.ignores = nil
 	j.scope = nil
-
-	return results
 }
 
 func (j *Job) commits() (result []*git.Commit, err error) {
@@ -277,3 +362,7 @@ func (j *Job) commits() (result []*git.Commit, err error) {
 
 	return
 }
+
+func checkpointID(repoID string, commitHashes []string) string {
+	return database.CreateHashID(repoID, strings.Join(commitHashes, ","))
+}
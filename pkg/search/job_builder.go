@@ -35,9 +35,11 @@ type (
 		chunkSize       int
 		showBarPerJob   bool
 		enableProfiling bool
+		resume          bool
 
 		// Services
-		git *gitpkg.Git
+		git       *gitpkg.Git
+		scanMarks *ScanMarks
 
 		interact *interactpkg.Interact
 		stats    *stats.Stats
@@ -51,11 +53,12 @@ type (
 		Repository   *gitpkg.Repository
 		CommitHashes []string
 		Oldest       string
-		CommitCount  int
 	}
 )
 
-func NewJobBuilder(repoFilter *manip.SliceFilter, sourceDir string, commitFilter *gitpkg.CommitFilter, workerCount int, chunkSize int, showBarPerJob bool, enableProfiling bool, git *gitpkg.Git, interact *interactpkg.Interact, stats *stats.Stats, db *database.Database, log logg.Logg) (result *JobBuilder) {
+// Scan marks are optional, every commit is searched when they're nil.
+// When resuming, jobs that were checkpointed by an earlier search are left out.
+func NewJobBuilder(repoFilter *manip.SliceFilter, sourceDir string, commitFilter *gitpkg.CommitFilter, workerCount int, chunkSize int, showBarPerJob bool, enableProfiling bool, resume bool, git *gitpkg.Git, scanMarks *ScanMarks, interact *interactpkg.Interact, stats *stats.Stats, db *database.Database, log logg.Logg) (result *JobBuilder) {
 	return &JobBuilder{
 		repoFilter:      repoFilter,
 		sourceDir:       sourceDir,
@@ -64,7 +67,9 @@ func NewJobBuilder(repoFilter *manip.SliceFilter, sourceDir string, commitFilter
 		chunkSize:       chunkSize,
 		showBarPerJob:   showBarPerJob,
 		enableProfiling: enableProfiling,
+		resume:          resume,
 		git:             git,
+		scanMarks:       scanMarks,
 		interact:        interact,
 		stats:           stats,
 		db:              db,
@@ -95,30 +100,21 @@ func (s *JobBuilder) BuildJobs(jobProg *progress.Progress) (result []*Job, err e
 		bar.Start()
 	}
 
-	// Get jobs, grouped by repo name
-	var jobByRepo = make(map[string][]*Job, reposLen)
+	// Get repository and commit objects
+	var repoDatas []*repoData
 	for _, repo := range repos {
 		err = func() (err error) {
 			if bar != nil {
 				defer bar.Incr()
 			}
 
-			// Get repository and commit objects
 			var repoDat *repoData
 			repoDat, err = s.repoData(repo)
 			if err != nil {
 				err = errors.WithMessagev(err, "unable to get repositories and commits for repo", repo.Name)
 				return
 			}
-
-			var repoJobs []*Job
-			repoJobs, err = s.buildRepoJobs(repoDat, jobProg)
-			if err != nil {
-				err = errors.WithMessagev(err, "unable to build repo jobs for repo", repo.Name)
-				return
-			}
-
-			jobByRepo[repo.Name] = repoJobs
+			repoDatas = append(repoDatas, repoDat)
 
 			return
 		}()
@@ -127,15 +123,35 @@ func (s *JobBuilder) BuildJobs(jobProg *progress.Progress) (result []*Job, err e
 		}
 	}
 
+	// Repos with more than a worker's share of the commits are searched by more than one worker at once
+	workersByRepo := s.repoWorkerCounts(repoDatas)
+
+	// Get jobs, grouped by repo name
+	var jobByRepo = make(map[string][]*Job, reposLen)
+	for _, repoDat := range repoDatas {
+		var repoJobs []*Job
+		repoJobs, err = s.buildRepoJobs(repoDat, workersByRepo[repoDat.RepoName], jobProg)
+		if err != nil {
+			err = errors.WithMessagev(err, "unable to build repo jobs for repo", repoDat.RepoName)
+			return
+		}
+
+		// Nothing new to search in the repo
+		if len(repoJobs) == 0 {
+			continue
+		}
+
+		jobByRepo[repoDat.RepoName] = repoJobs
+	}
+
 	// Build a flat list of jobs.
-	// To avoid too many workers reading from the same clone directory at once,
-	// we will spread the jobs out so that each worker gets its own repo to work on.
-	// However, to avoid too many repos being worked on at once, we will
-	// So if there are 2 workers and repos A to D to work on, the jobs would be ordered like:
-	// A, B, A, B, A, B, A, B, A, B finishes, A, C, A, C, A, C, etc
+	// The jobs are spread out so that workers are split between repos, and each repo gets as
+	// many workers at once as it was given. Repos with the most workers go first.
+	// So if there are 3 workers, repo A gets 2 of them, and B to D get 1, the jobs would be ordered like:
+	// A, A, B, A, A, B, A, A, B finishes, A, A, C, etc
 	for {
 		var batch []*Job
-		batch, jobByRepo, err = s.getBatch(jobByRepo)
+		batch, jobByRepo, err = s.getBatch(jobByRepo, workersByRepo)
 		if err != nil {
 			err = errors.WithMessage(err, "unable to get batch")
 			return
@@ -154,7 +170,34 @@ func (s *JobBuilder) BuildJobs(jobProg *progress.Progress) (result []*Job, err e
 	return
 }
 
-func (s *JobBuilder) getBatch(jobsByRepo map[string][]*Job) (result []*Job, rest map[string][]*Job, err error) {
+// A repo gets a worker for each worker's share of all the commits that it has, so a repo
+// that would finish long after the others on one worker is split between several
+func (s *JobBuilder) repoWorkerCounts(repoDatas []*repoData) (result map[string]int) {
+	var commitCount int
+	for _, repoDat := range repoDatas {
+		commitCount += len(repoDat.CommitHashes)
+	}
+	share := float64(commitCount) / float64(s.workerCount)
+
+	result = make(map[string]int, len(repoDatas))
+	for _, repoDat := range repoDatas {
+		workers := int(math.Ceil(float64(len(repoDat.CommitHashes)) / share))
+		if workers < 1 {
+			workers = 1
+		}
+		if workers > s.workerCount {
+			workers = s.workerCount
+		}
+		if workers > 1 {
+			s.log.WithField("repo", repoDat.RepoName).Debugf("repo is searched by %d workers at once", workers)
+		}
+		result[repoDat.RepoName] = workers
+	}
+
+	return
+}
+
+func (s *JobBuilder) getBatch(jobsByRepo map[string][]*Job, workersByRepo map[string]int) (result []*Job, rest map[string][]*Job, err error) {
 	if len(jobsByRepo) == 0 {
 		return
 	}
@@ -164,7 +207,7 @@ func (s *JobBuilder) getBatch(jobsByRepo map[string][]*Job) (result []*Job, rest
 	// Get next job for each repo
 	for {
 		var firstJobs []*Job
-		firstJobs, jobsByRepo, err = s.getFirstJobs(jobsByRepo, max)
+		firstJobs, jobsByRepo, err = s.getFirstJobs(jobsByRepo, workersByRepo, max)
 		if err != nil {
 			err = errors.WithMessage(err, "unable to get first job")
 			return
@@ -187,33 +230,49 @@ func (s *JobBuilder) getBatch(jobsByRepo map[string][]*Job) (result []*Job, rest
 	return
 }
 
-func (s *JobBuilder) getFirstJobs(jobsByRepo map[string][]*Job, max int) (result []*Job, rest map[string][]*Job, err error) {
+func (s *JobBuilder) getFirstJobs(jobsByRepo map[string][]*Job, workersByRepo map[string]int, max int) (result []*Job, rest map[string][]*Job, err error) {
 	if len(jobsByRepo) == 0 {
 		return
 	}
 
-	// Get first n repo names
+	// Repos with the most workers first
 	repoNames := make([]string, len(jobsByRepo))
 	i := 0
 	for k := range jobsByRepo {
 		repoNames[i] = k
 		i++
 	}
-	sort.Strings(repoNames)
+	sort.Slice(repoNames, func(i, j int) bool {
+		if workersByRepo[repoNames[i]] != workersByRepo[repoNames[j]] {
+			return workersByRepo[repoNames[i]] > workersByRepo[repoNames[j]]
+		}
+		return repoNames[i] < repoNames[j]
+	})
 
-	// Get next job for each repo
+	// Get the next jobs for each repo, one for each of its workers
 	collected := 0
 	for _, repoName := range repoNames {
-		// Get next job from repo jobs and delete it from the source
-		result = append(result, jobsByRepo[repoName][0])
-		jobsByRepo[repoName] = jobsByRepo[repoName][1:]
+		take := workersByRepo[repoName]
+		if take < 1 {
+			take = 1
+		}
+		if take > max-collected {
+			take = max - collected
+		}
+		if take > len(jobsByRepo[repoName]) {
+			take = len(jobsByRepo[repoName])
+		}
+
+		// Get next jobs from repo jobs and delete them from the source
+		result = append(result, jobsByRepo[repoName][:take]...)
+		jobsByRepo[repoName] = jobsByRepo[repoName][take:]
 
 		// Delete repo from map if its slice is empty now
 		if len(jobsByRepo[repoName]) == 0 {
 			delete(jobsByRepo, repoName)
 		}
 
-		collected += 1
+		collected += take
 
 		if collected == max {
 			break
@@ -225,25 +284,42 @@ func (s *JobBuilder) getFirstJobs(jobsByRepo map[string][]*Job, max int) (result
 	return
 }
 
-func (s *JobBuilder) buildRepoJobs(repoDat *repoData, jobProg *progress.Progress) (result []*Job, err error) {
-	// Get chunks of commits
-	commitHashChunks := s.chunkCommits(repoDat.CommitHashes)
+// A repo with more than one worker is split into at least as many jobs as it has workers
+func (s *JobBuilder) buildRepoJobs(repoDat *repoData, workers int, jobProg *progress.Progress) (result []*Job, err error) {
+	chunkSize := s.chunkSize
+	if workers > 1 {
+		workerChunkSize := int(math.Ceil(float64(len(repoDat.CommitHashes)) / float64(workers)))
+		if workerChunkSize < chunkSize {
+			chunkSize = workerChunkSize
+		}
+	}
+
+	// Get chunks of commits, leaving out the ones completed before
+	var commitHashChunks [][]string
+	var jobNums []int
+	var commitCount int
+	for i, chunk := range chunkCommits(repoDat.CommitHashes, chunkSize) {
+		if s.resume && s.db.CheckpointExists(checkpointID(repoDat.RepoID, chunk)) {
+			continue
+		}
+		commitHashChunks = append(commitHashChunks, chunk)
+		jobNums = append(jobNums, i)
+		commitCount += len(chunk)
+	}
 	commitHashChunksLen := len(commitHashChunks)
 
+	if s.resume {
+		s.log.WithField("repo", repoDat.RepoName).
+			Debugf("%d of %d commits are left from the interrupted search", commitCount, len(repoDat.CommitHashes))
+	}
+
 	result = make([]*Job, commitHashChunksLen)
 
 	for i := 0; i < commitHashChunksLen; i++ {
 		jobCommitHashes := commitHashChunks[i]
 		jobCommitCount := len(jobCommitHashes)
 
-		var spawnedRepository *gitpkg.Repository
-		spawnedRepository, err = repoDat.Repository.Spawn()
-		if err != nil {
-			err = errors.WithMessage(err, "unable to spawn repository")
-			return
-		}
-
-		jobName := fmt.Sprintf("%s-%d", repoDat.RepoName, i)
+		jobName := fmt.Sprintf("%s-%d", repoDat.RepoName, jobNums[i])
 
 		// Log
 		jobLog := s.log.WithFields(logg.Fields{
@@ -257,22 +333,22 @@ func (s *JobBuilder) buildRepoJobs(repoDat *repoData, jobProg *progress.Progress
 		var jobBar *progress.Bar
 		if jobProg != nil {
 			var barName string
-			var commitCount int
+			var barCommitCount int
 			if s.showBarPerJob {
 				barName = jobName
-				commitCount = jobCommitCount
+				barCommitCount = jobCommitCount
 			} else {
 				barName = repoDat.RepoName
-				commitCount = repoDat.CommitCount
+				barCommitCount = commitCount
 			}
-			jobBar = jobProg.AddBar(barName, commitCount, barSuffixMsg, barCompletedMsg)
+			jobBar = jobProg.AddBar(barName, barCommitCount, barSuffixMsg, barCompletedMsg)
 		}
 
 		result[i] = NewJob(
 			jobName,
 			repoDat.RepoID,
 			repoDat.RepoName,
-			spawnedRepository,
+			repoDat.Repository,
 			jobCommitHashes,
 			repoDat.Oldest,
 			s.enableProfiling,
@@ -313,12 +389,19 @@ func (s *JobBuilder) repoData(repo *database.Repo) (result *repoData, err error)
 		return
 	}
 
+	// Commits searched in an earlier run are left out
+	if s.scanMarks != nil {
+		if commitHashes, err = s.scanMarks.NewCommitHashes(repo, repository, commitHashes); err != nil {
+			err = errors.WithMessage(err, "unable to get commits added since the last search")
+			return
+		}
+	}
+
 	result = &repoData{
 		RepoID:       repo.ID,
 		RepoName:     repo.Name,
 		Repository:   repository,
 		CommitHashes: commitHashes,
-		CommitCount:  len(commitHashes),
 		Oldest:       oldest,
 	}
 
@@ -360,14 +443,14 @@ func (s *JobBuilder) commitHashesFromLog(repository *gitpkg.Repository) (result
 	return
 }
 
-func (s *JobBuilder) chunkCommits(items []string) (result [][]string) {
+func chunkCommits(items []string, chunkSize int) (result [][]string) {
 	itemsLen := len(items)
-	chunksLen := int(math.Ceil(float64(itemsLen) / float64(s.chunkSize)))
+	chunksLen := int(math.Ceil(float64(itemsLen) / float64(chunkSize)))
 	result = make([][]string, chunksLen)
 
 	for i := range result {
-		start := i * s.chunkSize
-		end := start + s.chunkSize
+		start := i * chunkSize
+		end := start + chunkSize
 		if end > itemsLen {
 			end = itemsLen
 		}
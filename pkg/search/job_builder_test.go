@@ -0,0 +1,60 @@
+package search_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/git"
+	interactpkg "github.com/pantheon-systems/secrets-searcher/pkg/interact"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/pantheon-systems/secrets-searcher/pkg/stats"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	gitvendor "gopkg.in/src-d/go-git.v4"
+)
+
+func TestJobBuilder_BuildJobs_SplitsLargeRepo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "job-builder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log := logg.NewLogrusLogg(logrus.New())
+	db, err := database.New(filepath.Join(dir, "db"), log)
+	require.NoError(t, err)
+
+	// One repo with most of the commits and one with a single commit
+	sourceDir := filepath.Join(dir, "source")
+	for repoName, commitCount := range map[string]int{"large": 6, "small": 1} {
+		cloneDir := filepath.Join(sourceDir, repoName)
+		gitRepo, err := gitvendor.PlainInit(cloneDir, false)
+		require.NoError(t, err)
+		worktree, err := gitRepo.Worktree()
+		require.NoError(t, err)
+		for i := 0; i < commitCount; i++ {
+			scanMarksCommit(t, worktree, cloneDir, fmt.Sprintf("commit %d", i))
+		}
+		require.NoError(t, db.WriteRepo(&database.Repo{ID: repoName + "ID", Name: repoName}))
+	}
+
+	interact := interactpkg.New(false, log)
+	repoFilter := manip.NewSliceFilter(manip.StringSet([]string{"large", "small"}), nil)
+	commitFilter := git.NewCommitFilter(nil, time.Time{}, time.Time{}, true)
+	jobBuilder := NewJobBuilder(repoFilter, sourceDir, commitFilter, 3, 100, false, false, false, git.New(nil, log), nil, interact, stats.New(), db, log)
+
+	// Fire
+	jobs, err := jobBuilder.BuildJobs(nil)
+
+	require.NoError(t, err)
+	var repoNames []string
+	for _, job := range jobs {
+		repoNames = append(repoNames, job.RepoName())
+	}
+	require.Equal(t, []string{"large", "large", "large", "small"}, repoNames)
+}
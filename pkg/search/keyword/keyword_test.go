@@ -0,0 +1,45 @@
+package keyword_test
+
+import (
+	"regexp"
+	"testing"
+
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/keyword"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher_Each(t *testing.T) {
+	subject := NewMatcher([]string{"he", "she", "his", "hers", "xoxb-"})
+
+	// Fire
+	var found []string
+	subject.Each("uSHErs token=xoxb-1", func(keywordIndex int) {
+		found = append(found, subject.Keywords()[keywordIndex])
+	})
+
+	require.Equal(t, []string{"she", "he", "hers", "xoxb-"}, found)
+}
+
+func TestFromRegexp(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{`(?i)(?:pass.?(?:word|wd|phrase)?|secret|token)`, []string{"pass", "secret", "token"}},
+		{`(xox[p|b]-[0-9]{12}-[0-9]{12})`, []string{"xoxb-", "xoxp-", "xox|-"}},
+		{`AKIA[0-9A-Z]{16}`, []string{"akia"}},
+		{`-----BEGIN ((EC|PGP|DSA|RSA|OPENSSH) )?PRIVATE KEY( BLOCK)?-----`, []string{"-----begin "}},
+		{`[0-9a-f]{32}`, nil},
+		{`(?:ab|secret)`, nil},
+		{`api.?key`, []string{"api"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+
+			// Fire
+			result := FromRegexp(regexp.MustCompile(tt.pattern))
+
+			require.Equal(t, tt.want, result)
+		})
+	}
+}
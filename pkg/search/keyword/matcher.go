@@ -0,0 +1,86 @@
+package keyword
+
+// Finds all keywords in a text in a single pass (Aho-Corasick).
+// ASCII letters are compared case-insensitively.
+type Matcher struct {
+	keywords []string
+	nodes    []*node
+}
+
+type node struct {
+	next    [256]int
+	outputs []int
+}
+
+func NewMatcher(keywords []string) (result *Matcher) {
+	result = &Matcher{keywords: keywords}
+	result.build()
+	return
+}
+
+func (m *Matcher) Keywords() []string {
+	return m.keywords
+}
+
+// Calls fn with the index of each keyword found in the text, once per occurrence
+func (m *Matcher) Each(text string, fn func(keywordIndex int)) {
+	state := 0
+	for i := 0; i < len(text); i++ {
+		state = m.nodes[state].next[lower(text[i])]
+		for _, keywordIndex := range m.nodes[state].outputs {
+			fn(keywordIndex)
+		}
+	}
+}
+
+func (m *Matcher) build() {
+	root := &node{}
+	m.nodes = []*node{root}
+
+	// Trie, where a zero transition means there's no child yet
+	for keywordIndex, keyword := range m.keywords {
+		state := 0
+		for i := 0; i < len(keyword); i++ {
+			c := lower(keyword[i])
+			if m.nodes[state].next[c] == 0 {
+				m.nodes = append(m.nodes, &node{})
+				m.nodes[state].next[c] = len(m.nodes) - 1
+			}
+			state = m.nodes[state].next[c]
+		}
+		m.nodes[state].outputs = append(m.nodes[state].outputs, keywordIndex)
+	}
+
+	// Failure links, breadth first, folded into the transitions so matching never backtracks
+	fail := make([]int, len(m.nodes))
+	var queue []int
+	for c := 0; c < 256; c++ {
+		if child := root.next[c]; child != 0 {
+			queue = append(queue, child)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		n := m.nodes[state]
+
+		n.outputs = append(n.outputs, m.nodes[fail[state]].outputs...)
+
+		for c := 0; c < 256; c++ {
+			child := n.next[c]
+			if child == 0 {
+				n.next[c] = m.nodes[fail[state]].next[c]
+				continue
+			}
+			fail[child] = m.nodes[fail[state]].next[c]
+			queue = append(queue, child)
+		}
+	}
+}
+
+func lower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
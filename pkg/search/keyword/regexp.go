@@ -0,0 +1,214 @@
+package keyword
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"sort"
+)
+
+const (
+	// Shorter keywords match too much to be worth filtering on
+	minKeywordLen = 3
+
+	// Alternatives are expanded until there would be more keywords than this
+	maxKeywords = 64
+
+	// Larger character classes aren't expanded, like [0-9]
+	maxClassRunes = 6
+)
+
+// Lowercase literals of which at least one is in every string the regexp matches, or nil if there
+// are none worth filtering on. The non-ASCII case folds of k and s (K and ſ) aren't considered.
+func FromRegexp(re *regexp.Regexp) (result []string) {
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return
+	}
+
+	result, _ = required(parsed.Simplify())
+	sort.Strings(result)
+
+	return
+}
+
+// Literals of which at least one is in every match
+func required(re *syntax.Regexp) (result []string, ok bool) {
+	if result, ok = exact(re); ok && worthFiltering(result) {
+		return
+	}
+	result, ok = nil, false
+
+	switch re.Op {
+	case syntax.OpCapture, syntax.OpPlus:
+		return required(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return required(re.Sub[0])
+		}
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			subResult, subOK := required(sub)
+			if !subOK {
+				return nil, false
+			}
+			result = union(result, subResult)
+		}
+		ok = len(result) <= maxKeywords
+	case syntax.OpConcat:
+		return requiredInConcat(re.Sub)
+	}
+
+	return
+}
+
+// Runs of consecutive exact parts are joined into literals, and the best literals found are used
+func requiredInConcat(subs []*syntax.Regexp) (result []string, ok bool) {
+	consider := func(candidate []string) {
+		if !worthFiltering(candidate) {
+			return
+		}
+		if !ok || better(candidate, result) {
+			result, ok = candidate, true
+		}
+	}
+
+	run := []string{""}
+	for _, sub := range subs {
+		if subExact, subOK := exact(sub); subOK {
+			if len(run)*len(subExact) <= maxKeywords {
+				run = cross(run, subExact)
+				continue
+			}
+			consider(run)
+			run = subExact
+			continue
+		}
+
+		consider(run)
+		run = []string{""}
+		if subRequired, subOK := required(sub); subOK {
+			consider(subRequired)
+		}
+	}
+	consider(run)
+
+	return
+}
+
+// Every string the regexp matches, if there are few enough
+func exact(re *syntax.Regexp) (result []string, ok bool) {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return []string{""}, true
+	case syntax.OpLiteral:
+		literal := make([]byte, len(re.Rune))
+		for i, r := range re.Rune {
+			if r >= 0x80 {
+				return
+			}
+			literal[i] = lower(byte(r))
+		}
+		return []string{string(literal)}, true
+	case syntax.OpCharClass:
+		var count int
+		for i := 0; i < len(re.Rune); i += 2 {
+			count += int(re.Rune[i+1]-re.Rune[i]) + 1
+			if count > maxClassRunes*2 {
+				return
+			}
+		}
+		for i := 0; i < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				if r >= 0x80 {
+					return nil, false
+				}
+				result = union(result, []string{string(lower(byte(r)))})
+			}
+		}
+		ok = len(result) <= maxClassRunes
+	case syntax.OpCapture:
+		return exact(re.Sub[0])
+	case syntax.OpConcat:
+		result = []string{""}
+		for _, sub := range re.Sub {
+			subResult, subOK := exact(sub)
+			if !subOK || len(result)*len(subResult) > maxKeywords {
+				return nil, false
+			}
+			result = cross(result, subResult)
+		}
+		ok = true
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			subResult, subOK := exact(sub)
+			if !subOK {
+				return nil, false
+			}
+			result = union(result, subResult)
+		}
+		ok = len(result) <= maxKeywords
+	}
+
+	if !ok {
+		result = nil
+	}
+
+	return
+}
+
+func worthFiltering(keywords []string) bool {
+	if len(keywords) == 0 || len(keywords) > maxKeywords {
+		return false
+	}
+	for _, keyword := range keywords {
+		if len(keyword) < minKeywordLen {
+			return false
+		}
+	}
+	return true
+}
+
+// Longer keywords are rarer, and then fewer keywords are better
+func better(a, b []string) bool {
+	aLen, bLen := shortest(a), shortest(b)
+	if aLen != bLen {
+		return aLen > bLen
+	}
+	return len(a) < len(b)
+}
+
+func shortest(keywords []string) (result int) {
+	for i, keyword := range keywords {
+		if i == 0 || len(keyword) < result {
+			result = len(keyword)
+		}
+	}
+	return
+}
+
+func cross(a, b []string) (result []string) {
+	for _, x := range a {
+		for _, y := range b {
+			result = union(result, []string{x + y})
+		}
+	}
+	return
+}
+
+func union(a, b []string) (result []string) {
+	result = a
+	for _, y := range b {
+		found := false
+		for _, x := range result {
+			if x == y {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, y)
+		}
+	}
+	return
+}
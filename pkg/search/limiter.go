@@ -0,0 +1,124 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	gitpkg "github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	"github.com/pantheon-systems/secrets-searcher/pkg/stats"
+)
+
+// Why a file change wasn't searched completely
+const (
+	SkipReasonBlobTooLarge     = "blob too large"
+	SkipReasonLinesTruncated   = "lines truncated"
+	SkipReasonDeadlineExceeded = "deadline exceeded"
+	SkipReasonBlobNotFetched   = "blob not fetched"
+)
+
+// Keeps huge file changes and runaway processors from stalling a worker. Anything skipped
+// or truncated is recorded in the logs, the stats and the database.
+type Limiter struct {
+	maxLineLength     int
+	fileChangeTimeout time.Duration
+	stats             *stats.Stats
+	db                *database.Database
+	log               logg.Logg
+}
+
+// Max line length and file change timeout are 0 for no limit
+func NewLimiter(maxLineLength int, fileChangeTimeout time.Duration, stats *stats.Stats, db *database.Database, log logg.Logg) *Limiter {
+	return &Limiter{
+		maxLineLength:     maxLineLength,
+		fileChangeTimeout: fileChangeTimeout,
+		stats:             stats,
+		db:                db,
+		log:               log,
+	}
+}
+
+// Truncates the file change's long lines and starts its deadline. Has to be called before the diff is built.
+func (l *Limiter) Apply(job contract.WorkerJobI) {
+	if l.fileChangeTimeout > 0 {
+		job.SetDeadline(time.Now().Add(l.fileChangeTimeout))
+	}
+
+	if l.maxLineLength == 0 {
+		return
+	}
+
+	if truncated := truncateLongLines(job.FileChange(), l.maxLineLength); truncated > 0 {
+		l.Skipped(job, SkipReasonLinesTruncated,
+			fmt.Sprintf("%d added lines were cut to %d bytes", truncated, l.maxLineLength))
+	}
+}
+
+func (l *Limiter) SkippedTooLarge(job contract.WorkerJobI) {
+	l.Skipped(job, SkipReasonBlobTooLarge, fmt.Sprintf("blob is %d bytes", job.FileChange().BlobSize))
+}
+
+func (l *Limiter) SkippedNotFetched(job contract.WorkerJobI) {
+	l.Skipped(job, SkipReasonBlobNotFetched, "blob was left out of the partial clone")
+}
+
+func (l *Limiter) SkippedDeadline(job contract.WorkerJobI) {
+	l.Skipped(job, SkipReasonDeadlineExceeded,
+		fmt.Sprintf("not finished within %s, stopped at processor %s", l.fileChangeTimeout, job.Processor().GetName()))
+}
+
+func (l *Limiter) Skipped(job contract.WorkerJobI, reason, detail string) {
+	fileChange := job.FileChange()
+	commitHash := job.Commit().Hash
+
+	job.Log(l.log).WithField("reason", reason).Warnf("file change wasn't searched completely: %s", detail)
+
+	l.stats.SkipCounts.Add(reason)
+
+	skip := &database.Skip{
+		ID:         database.CreateHashID(job.RepoName(), commitHash, fileChange.Path, reason),
+		RepoName:   job.RepoName(),
+		CommitHash: commitHash,
+		Path:       fileChange.Path,
+		Reason:     reason,
+		Detail:     detail,
+	}
+	if err := l.db.WriteSkip(skip); err != nil {
+		errors.ErrLog(job.Log(l.log), err).Error("unable to write skip")
+	}
+}
+
+// Cuts added lines down to the max length, so the processors' regexes can't run away on them.
+// Returns how many lines were cut.
+func truncateLongLines(fileChange *gitpkg.FileChange, maxLineLength int) (result int) {
+	for _, chunk := range fileChange.Chunks {
+		if chunk.Operation != gitpkg.Add || len(chunk.Content) <= maxLineLength {
+			continue
+		}
+
+		lines := strings.Split(chunk.Content, "\n")
+		for i, line := range lines {
+			if len(line) <= maxLineLength {
+				continue
+			}
+
+			// Don't cut a character in half
+			end := maxLineLength
+			for end > 0 && !utf8.RuneStart(line[end]) {
+				end--
+			}
+
+			lines[i] = line[:end]
+			result++
+		}
+
+		chunk.Content = strings.Join(lines, "\n")
+	}
+
+	return
+}
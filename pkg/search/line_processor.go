@@ -83,20 +83,47 @@ func (l *LineProcessorWrapper) GetName() string {
 	return l.proc.GetName()
 }
 
+func (l *LineProcessorWrapper) LineProcessor() contract.LineProcessorI {
+	return l.proc
+}
+
 func (l *LineProcessorWrapper) FindResultsInFileChange(job contract.ProcessorJobI) (err error) {
+	return l.FindResultsInLines(job, nil)
+}
+
+// Only the added lines in lineNums are searched, or all of them when it's nil
+func (l *LineProcessorWrapper) FindResultsInLines(job contract.ProcessorJobI, lineNums map[int]bool) (err error) {
 	job.SearchingWithProcessor(l.proc)
 	fileChange := job.FileChange()
 
-	// Find in each chunk
-	chunks := fileChange.Chunks
-
-	currFileLineNum := 1
-	currDiffLineNum := 1
-
 	// Decorate the job in a struct that can convert line ranges to file ranges
 	lineJob := NewLineProcessorJob(job)
 
-	for _, chunk := range chunks {
+	return eachAddedLine(fileChange, func(lineNum int, line string) (err error) {
+		if lineNums != nil && !lineNums[lineNum] {
+			return
+		}
+		if job.DeadlineExceeded() {
+			return contract.ErrDeadlineExceeded
+		}
+
+		job.SearchingLine(lineNum)
+		dev.BreakpointInProcessor(fileChange.Path, l.proc.GetName(), lineNum)
+
+		// Run inner processor
+		if err = l.proc.FindResultsInLine(lineJob, line); err != nil {
+			err = errors.WithMessagev(err, "unable to find in line using processor", l.proc.GetName())
+		}
+
+		return
+	})
+}
+
+// Calls fn with each non-empty added line and its line number in the file
+func eachAddedLine(fileChange *gitpkg.FileChange, fn func(lineNum int, line string) error) (err error) {
+	currFileLineNum := 1
+
+	for _, chunk := range fileChange.Chunks {
 		chunkString := chunk.Content
 
 		// Remove the trailing line break
@@ -107,36 +134,20 @@ func (l *LineProcessorWrapper) FindResultsInFileChange(job contract.ProcessorJob
 
 		switch chunk.Operation {
 		case gitpkg.Equal:
-			lineCount := manip.CountRunes(chunkString, '\n') + 1
-			currFileLineNum += lineCount
-			currDiffLineNum += lineCount
-		case gitpkg.Delete:
-			lineCount := manip.CountRunes(chunkString, '\n') + 1
-			currDiffLineNum += lineCount
+			currFileLineNum += manip.CountRunes(chunkString, '\n') + 1
 		case gitpkg.Add:
 
 			// For each line in chunk
 			lines := strings.Split(chunkString, "\n")
 			for _, line := range lines {
-				if line == "" {
-					currFileLineNum += 1
-					continue
-				}
-
-				job.SearchingLine(currFileLineNum)
-				dev.BreakpointInProcessor(fileChange.Path, l.proc.GetName(), currFileLineNum)
-
-				// Run inner processor
-				err = l.proc.FindResultsInLine(lineJob, line)
-
-				if err != nil {
-					err = errors.WithMessagev(err, "unable to find in line using processor", l.proc.GetName())
-					return
+				if line != "" {
+					if err = fn(currFileLineNum, line); err != nil {
+						return
+					}
 				}
 
 				// Advance to the next line
 				currFileLineNum += 1
-				currDiffLineNum += 1
 			}
 		}
 	}
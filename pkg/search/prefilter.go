@@ -0,0 +1,106 @@
+package search
+
+import (
+	gitpkg "github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/keyword"
+)
+
+type (
+	// Finds the keywords of every line processor in one pass over a file change, so each
+	// processor is only run on the lines that have one of its keywords
+	Prefilter struct {
+		matcher            *keyword.Matcher
+		procNamesByKeyword [][]string
+		filtered           map[string]bool
+	}
+
+	// The lines each filtered processor has to search in a file change
+	PrefilterHits struct {
+		filtered    map[string]bool
+		linesByProc map[string]map[int]bool
+	}
+)
+
+// Nil when none of the processors have keywords
+func NewPrefilter(processors []contract.ProcessorI, log logg.Logg) (result *Prefilter) {
+	keywordIndexes := map[string]int{}
+	var keywords []string
+	var procNamesByKeyword [][]string
+	filtered := map[string]bool{}
+
+	for _, proc := range processors {
+		wrapper, ok := proc.(*LineProcessorWrapper)
+		if !ok {
+			continue
+		}
+		keywordsProc, ok := wrapper.LineProcessor().(contract.HasKeywordsI)
+		if !ok {
+			continue
+		}
+		procKeywords := keywordsProc.Keywords()
+		if len(procKeywords) == 0 {
+			log.Debugf("no keywords for processor %s, it searches every line", proc.GetName())
+			continue
+		}
+
+		log.Tracef("keywords for processor %s: %v", proc.GetName(), procKeywords)
+		filtered[proc.GetName()] = true
+
+		for _, procKeyword := range procKeywords {
+			i, ok := keywordIndexes[procKeyword]
+			if !ok {
+				i = len(keywords)
+				keywordIndexes[procKeyword] = i
+				keywords = append(keywords, procKeyword)
+				procNamesByKeyword = append(procNamesByKeyword, nil)
+			}
+			procNamesByKeyword[i] = append(procNamesByKeyword[i], proc.GetName())
+		}
+	}
+
+	if len(filtered) == 0 {
+		return
+	}
+
+	return &Prefilter{
+		matcher:            keyword.NewMatcher(keywords),
+		procNamesByKeyword: procNamesByKeyword,
+		filtered:           filtered,
+	}
+}
+
+func (p *Prefilter) Scan(fileChange *gitpkg.FileChange) (result *PrefilterHits) {
+	result = &PrefilterHits{
+		filtered:    p.filtered,
+		linesByProc: map[string]map[int]bool{},
+	}
+
+	_ = eachAddedLine(fileChange, func(lineNum int, line string) error {
+		p.matcher.Each(line, func(keywordIndex int) {
+			for _, procName := range p.procNamesByKeyword[keywordIndex] {
+				if result.linesByProc[procName] == nil {
+					result.linesByProc[procName] = map[int]bool{}
+				}
+				result.linesByProc[procName][lineNum] = true
+			}
+		})
+		return nil
+	})
+
+	return
+}
+
+// The lines with one of the processor's keywords, unless the processor isn't filtered
+func (h *PrefilterHits) Lines(procName string) (lineNums map[int]bool, filtered bool) {
+	if !h.filtered[procName] {
+		return
+	}
+
+	if lineNums = h.linesByProc[procName]; lineNums == nil {
+		lineNums = map[int]bool{}
+	}
+
+	return lineNums, true
+}
@@ -0,0 +1,69 @@
+package dotfile
+
+//go:generate stringer -type Format
+
+import "strings"
+
+type Format int
+
+const (
+	Netrc Format = iota
+	Npmrc
+	Pypirc
+	DockerConfig
+	GitCredentials
+	Htpasswd
+)
+
+func Formats() []Format {
+	return []Format{
+		Netrc,
+		Npmrc,
+		Pypirc,
+		DockerConfig,
+		GitCredentials,
+		Htpasswd,
+	}
+}
+
+func (i Format) Value() string {
+	return strings.ToLower(i.String())
+}
+
+// Dotfiles are found by file name
+func (i Format) PathMatchString() string {
+	switch i {
+	case Netrc:
+		return `(?:^|/)[._]netrc$`
+	case Npmrc:
+		return `(?:^|/)\.npmrc$`
+	case Pypirc:
+		return `(?:^|/)\.pypirc$`
+	case DockerConfig:
+		return `(?:^|/)(?:\.docker/config\.json|\.dockercfg)$`
+	case GitCredentials:
+		return `(?:^|/)\.git-credentials$`
+	case Htpasswd:
+		return `(?:^|/)\.htpasswd$`
+	default:
+		panic("unknown format: " + i.String())
+	}
+}
+
+func NewFormatFromValue(val string) Format {
+	for _, e := range Formats() {
+		if e.Value() == val {
+			return e
+		}
+	}
+	panic("unknown dotfile format: " + val)
+}
+
+func ValidFormatValues() (result []string) {
+	formats := Formats()
+	result = make([]string, len(formats))
+	for i := range formats {
+		result[i] = formats[i].Value()
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package dotfile
+
+import (
+	"regexp"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+)
+
+const HostExtraKey = "dotfile-host"
+
+type Processor struct {
+	name   string
+	format Format
+	pathRe *regexp.Regexp
+	log    logg.Logg
+}
+
+func NewProcessor(name string, format Format, log logg.Logg) *Processor {
+	return &Processor{
+		name:   name,
+		format: format,
+		pathRe: regexp.MustCompile(format.PathMatchString()),
+		log:    log,
+	}
+}
+
+func (p *Processor) GetName() string {
+	return p.name
+}
+
+func (p *Processor) FindResultsInFileChange(job contract.ProcessorJobI) (err error) {
+	fileChange := job.FileChange()
+	if !p.pathRe.MatchString(fileChange.Path) {
+		return
+	}
+
+	var contents string
+	if contents, err = fileChange.FileContents(); err != nil {
+		return
+	}
+
+	added := job.Diff().AddedLineNums()
+
+	for _, cred := range Parse(p.format, contents) {
+		if !added[cred.Line] {
+			continue
+		}
+
+		job.SearchingLine(cred.Line)
+		job.SubmitResult(&contract.Result{
+			FileRange: &manip.FileRange{
+				StartLineNum: cred.Line,
+				StartIndex:   cred.Start,
+				EndLineNum:   cred.Line,
+				EndIndex:     cred.End,
+			},
+			SecretValue:   cred.Secret,
+			FindingExtras: buildExtras(cred),
+		})
+	}
+
+	return
+}
+
+func buildExtras(cred *Credential) (result []*contract.ResultExtra) {
+	result = contract.AppendExtra(result, HostExtraKey, "Credential for", cred.Host)
+	result = contract.AppendExtra(result, "dotfile-user", "User", cred.User)
+	result = contract.AppendExtra(result, "dotfile-hash-scheme", "Hash scheme", cred.Scheme)
+	if cred.Encoded {
+		result = contract.AppendExtra(result, "dotfile-encoding", "Encoding", "base64")
+	}
+
+	return
+}
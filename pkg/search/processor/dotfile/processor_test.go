@@ -0,0 +1,121 @@
+package dotfile_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/builtin"
+	"github.com/pantheon-systems/secrets-searcher/pkg/dev"
+	"github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/processor/dotfile"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/searchtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	gitvendor "gopkg.in/src-d/go-git.v4"
+	gitobject "gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+var log = logg.NewLogrusLogg(logrus.New())
+
+func TestProcessor_FindResultsInFileChange(t *testing.T) {
+	dev.Params = &dev.Parameters{}
+
+	for name, tt := range map[string]struct {
+		processor builtin.ProcessorName
+		commits   []map[string]string
+		expHosts  []string
+		expRanges []*manip.FileRange
+	}{
+		"only added lines": {
+			processor: builtin.NetrcDotfile,
+			commits: []map[string]string{
+				{".netrc": "machine a.example.com login a password first\n"},
+				{".netrc": "machine a.example.com login a password first\nmachine b.example.com login b password second\n"},
+			},
+			expHosts: []string{"a.example.com", "b.example.com"},
+			expRanges: []*manip.FileRange{
+				{StartLineNum: 1, StartIndex: 39, EndLineNum: 1, EndIndex: 44},
+				{StartLineNum: 2, StartIndex: 39, EndLineNum: 2, EndIndex: 45},
+			},
+		},
+		"other paths": {
+			processor: builtin.NetrcDotfile,
+			commits:   []map[string]string{{"netrc.txt": "machine a.example.com login a password first\n"}},
+		},
+		"same auth for two registries": {
+			processor: builtin.DockerConfigDotfile,
+			commits: []map[string]string{{".docker/config.json": `{
+  "auths": {
+    "one.example.com": {"auth": "dXNlcjpodW50ZXIy"},
+    "two.example.com": {"auth": "dXNlcjpodW50ZXIy"}
+  }
+}`}},
+			expHosts: []string{"one.example.com", "two.example.com"},
+			expRanges: []*manip.FileRange{
+				{StartLineNum: 3, StartIndex: 33, EndLineNum: 3, EndIndex: 49},
+				{StartLineNum: 4, StartIndex: 33, EndLineNum: 4, EndIndex: 49},
+			},
+		},
+	} {
+		dir, err := ioutil.TempDir("", "dotfile-processor-test")
+		require.NoError(t, err)
+		repository, commitHashes := testRepo(t, dir, tt.commits)
+		subject := searchtest.CoreProcessor(tt.processor, nil)
+		worker := search.NewWorker([]contract.ProcessorI{subject}, nil, nil, nil, nil, nil, log)
+		job := search.NewJob("job", "repoID", "repo", repository, commitHashes, commitHashes[0], false, nil, log, nil)
+
+		// Fire
+		require.True(t, worker.Do(context.Background(), job), name)
+
+		var hosts []string
+		var ranges []*manip.FileRange
+		for _, result := range job.GetJobResults() {
+			for _, extra := range result.FindingExtras {
+				if extra.Key == HostExtraKey {
+					hosts = append(hosts, extra.Value)
+				}
+			}
+			ranges = append(ranges, result.FileRange)
+		}
+		require.Equal(t, tt.expHosts, hosts, name)
+		require.Equal(t, tt.expRanges, ranges, name)
+
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// A repo with a commit for each set of files, oldest first
+func testRepo(t *testing.T, cloneDir string, commits []map[string]string) (repository *git.Repository, commitHashes []string) {
+	gitRepo, err := gitvendor.PlainInit(cloneDir, false)
+	require.NoError(t, err)
+	worktree, err := gitRepo.Worktree()
+	require.NoError(t, err)
+	for i, files := range commits {
+		for name, contents := range files {
+			path := filepath.Join(cloneDir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+			require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+			_, err = worktree.Add(name)
+			require.NoError(t, err)
+		}
+		hash, err := worktree.Commit(fmt.Sprintf("commit %d", i), &gitvendor.CommitOptions{
+			Author: &gitobject.Signature{Name: "name", Email: "name@example.com", When: time.Now().Add(time.Duration(i) * time.Minute)},
+		})
+		require.NoError(t, err)
+		commitHashes = append(commitHashes, hash.String())
+	}
+
+	repository, err = git.New(nil, log).OpenRepository(cloneDir)
+	require.NoError(t, err)
+
+	return
+}
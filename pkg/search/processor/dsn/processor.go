@@ -0,0 +1,60 @@
+package dsn
+
+import (
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+)
+
+const (
+	HostExtraKey     = "dsn-host"
+	DatabaseExtraKey = "dsn-database"
+)
+
+type Processor struct {
+	name          string
+	codeWhitelist *search.CodeWhitelist
+	log           logg.Logg
+}
+
+func NewProcessor(name string, codeWhitelist *search.CodeWhitelist, log logg.Logg) *Processor {
+	return &Processor{
+		name:          name,
+		codeWhitelist: codeWhitelist,
+		log:           log,
+	}
+}
+
+func (p *Processor) GetName() string {
+	return p.name
+}
+
+// The password is the secret, the rest of the connection string is context
+func (p *Processor) FindResultsInLine(job contract.LineProcessorJobI, line string) (err error) {
+	for _, dsn := range ParseLine(line) {
+		lineRange := manip.NewLineRange(dsn.PasswordStart, dsn.PasswordEnd)
+
+		if p.codeWhitelist.IsSecretWhitelisted(line, lineRange) {
+			job.SubmitLineRangeIgnore(lineRange)
+			continue
+		}
+
+		job.SubmitLineResult(&contract.LineResult{
+			LineRange:     lineRange,
+			SecretValue:   dsn.Password,
+			FindingExtras: buildExtras(dsn),
+		})
+	}
+
+	return
+}
+
+func buildExtras(dsn *DSN) (result []*contract.ResultExtra) {
+	result = contract.AppendExtra(result, "dsn-scheme", "Connection type", dsn.Scheme)
+	result = contract.AppendExtra(result, HostExtraKey, "Database host", dsn.Host)
+	result = contract.AppendExtra(result, "dsn-user", "Database user", dsn.User)
+	result = contract.AppendExtra(result, DatabaseExtraKey, "Database name", dsn.Database)
+
+	return
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
 	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/decode"
 )
 
 var (
@@ -35,10 +36,12 @@ type Processor struct {
 	entropyThreshold float64
 	skipPEMs         bool
 	codeWhitelist    *search.CodeWhitelist
+	decoder          *decode.Pipeline
 	log              logg.Logg
 }
 
-func NewProcessor(name, charset string, lengthThreshold int, entropyThreshold float64, codeWhitelist *search.CodeWhitelist, skipPEMs bool, log logg.Logg) (result *Processor) {
+// Decoder is optional, encoded values are only searched when it's set
+func NewProcessor(name, charset string, lengthThreshold int, entropyThreshold float64, codeWhitelist *search.CodeWhitelist, skipPEMs bool, decoder *decode.Pipeline, log logg.Logg) (result *Processor) {
 	return &Processor{
 		name:             name,
 		charset:          charset,
@@ -46,6 +49,7 @@ func NewProcessor(name, charset string, lengthThreshold int, entropyThreshold fl
 		entropyThreshold: entropyThreshold,
 		skipPEMs:         skipPEMs,
 		codeWhitelist:    codeWhitelist,
+		decoder:          decoder,
 		log:              log,
 	}
 }
@@ -93,6 +97,12 @@ func (p *Processor) FindResultsInFileChange(job contract.ProcessorJobI) (err err
 }
 
 func (p *Processor) findEntropyInLine(job contract.ProcessorJobI, diffLine *git.Line) {
+
+	// Decoded values go first, or the encoded value would be reported and the decoded one ignored
+	if p.decoder != nil {
+		p.findEntropyInDecodedValues(job, diffLine)
+	}
+
 	entResults := entropypkg.FindHighEntropyWords(diffLine.Code, p.charset, p.lengthThreshold, p.entropyThreshold)
 	if entResults == nil {
 		return
@@ -107,11 +117,7 @@ func (p *Processor) findEntropyInLine(job contract.ProcessorJobI, diffLine *git.
 		var secretExtras []*contract.ResultExtra
 
 		// Entropy value extra
-		secretExtras = append(secretExtras, &contract.ResultExtra{
-			Key:    fmt.Sprintf("entropy-%s", p.charset),
-			Header: fmt.Sprintf("Entropy (%s)", p.charset),
-			Value:  fmt.Sprintf("%f", entResult.Entropy),
-		})
+		secretExtras = append(secretExtras, p.entropyExtra(entResult))
 
 		// Try to decode base64
 		//var decoded []byte
@@ -156,6 +162,40 @@ func (p *Processor) findEntropyInLine(job contract.ProcessorJobI, diffLine *git.
 	return
 }
 
+// Encoded values in the line are decoded, and the decoded content is searched for high entropy words
+func (p *Processor) findEntropyInDecodedValues(job contract.ProcessorJobI, diffLine *git.Line) {
+	for _, lineRange := range p.decoder.Candidates(diffLine.Code) {
+		encoded := lineRange.ExtractValue(diffLine.Code).Value
+
+	decodedLoop:
+		for _, decoded := range p.decoder.Decode(encoded) {
+			for _, decodedLine := range decoded.Lines() {
+				for _, entResult := range entropypkg.FindHighEntropyWords(decodedLine, p.charset, p.lengthThreshold, p.entropyThreshold) {
+					if p.codeWhitelist.IsSecretWhitelisted(decodedLine, entResult.LineRange.LineRange) {
+						continue
+					}
+
+					job.SubmitResult(&contract.Result{
+						FileRange:     manip.NewFileRangeFromLineRange(lineRange, diffLine.NumInFile),
+						SecretValue:   entResult.LineRange.Value,
+						SecretExtras:  []*contract.ResultExtra{p.entropyExtra(entResult)},
+						FindingExtras: []*contract.ResultExtra{decoded.ChainExtra(decoded.Key)},
+					})
+					break decodedLoop
+				}
+			}
+		}
+	}
+}
+
+func (p *Processor) entropyExtra(entResult *entropypkg.Result) *contract.ResultExtra {
+	return &contract.ResultExtra{
+		Key:    fmt.Sprintf("entropy-%s", p.charset),
+		Header: fmt.Sprintf("Entropy (%s)", p.charset),
+		Value:  fmt.Sprintf("%f", entResult.Entropy),
+	}
+}
+
 func (p *Processor) skipPEMsInDiff(diff *git.Diff) (ok bool) {
 	for {
 
@@ -0,0 +1,52 @@
+package iac
+
+//go:generate stringer -type Format
+
+import "strings"
+
+type Format int
+
+const (
+	TerraformState Format = iota
+	CloudFormation
+)
+
+func Formats() []Format {
+	return []Format{
+		TerraformState,
+		CloudFormation,
+	}
+}
+
+func (i Format) Value() string {
+	return strings.ToLower(i.String())
+}
+
+func (i Format) PathMatchString() string {
+	switch i {
+	case TerraformState:
+		return `\.tfstate(?:\.backup)?$`
+	case CloudFormation:
+		return `(?i)\.(?:ya?ml|json|template)$`
+	default:
+		panic("unknown format: " + i.String())
+	}
+}
+
+func NewFormatFromValue(val string) Format {
+	for _, e := range Formats() {
+		if e.Value() == val {
+			return e
+		}
+	}
+	panic("unknown iac format: " + val)
+}
+
+func ValidFormatValues() (result []string) {
+	formats := Formats()
+	result = make([]string, len(formats))
+	for i := range formats {
+		result[i] = formats[i].Value()
+	}
+	return
+}
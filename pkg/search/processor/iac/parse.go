@@ -0,0 +1,339 @@
+package iac
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/structured"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// password, master_password, private_key_pem, client_secret, secret_access_key
+	sensitiveKeyRegex = regexp.MustCompile(`(?i)(?:^|[_.])(?:password|passwd|private_key|secret|access_key)(?:[_.]|$)`)
+
+	// Keys that name or point to a secret rather than hold it
+	referenceKeyRegex = regexp.MustCompile(`(?i)[_.](?:id|ids|arn|name|version|length)$`)
+)
+
+// A sensitive value in an IaC file and the resource it belongs to
+type Secret struct {
+	Address   string
+	Attribute string
+	Value     string
+	Marked    bool
+	Position  *structured.Position
+}
+
+func Parse(format Format, contents string) (result []*Secret, err error) {
+	switch format {
+	case TerraformState:
+		result, err = parseTerraformState(contents)
+	case CloudFormation:
+		result, err = parseCloudFormation(contents)
+	default:
+		panic("unknown format: " + format.String())
+	}
+
+	return
+}
+
+// Only the pairs that are secrets are located, together, since repeated keys map to successive occurrences
+func collect(contents string, pairs []*structured.Pair, secretFor func(pair *structured.Pair) *Secret) (result []*Secret) {
+	var secrets []*Secret
+	var secretPairs []*structured.Pair
+	for _, pair := range pairs {
+		if pair.Value == "" {
+			continue
+		}
+		if secret := secretFor(pair); secret != nil {
+			secret.Value = pair.Value
+			secrets = append(secrets, secret)
+			secretPairs = append(secretPairs, pair)
+		}
+	}
+
+	positions := structured.Locate(contents, secretPairs)
+
+	for i, secret := range secrets {
+		if positions[i] == nil {
+			continue
+		}
+		secret.Position = positions[i]
+		result = append(result, secret)
+	}
+
+	return
+}
+
+//
+// Terraform state
+
+type (
+	tfState struct {
+		Resources []*tfResource        `json:"resources"`
+		Outputs   map[string]*tfOutput `json:"outputs"`
+		Modules   []*tfModuleV3        `json:"modules"`
+	}
+	tfResource struct {
+		Module    string        `json:"module"`
+		Mode      string        `json:"mode"`
+		Type      string        `json:"type"`
+		Name      string        `json:"name"`
+		Instances []*tfInstance `json:"instances"`
+	}
+	tfInstance struct {
+		IndexKey            interface{} `json:"index_key"`
+		SensitiveAttributes [][]*tfStep `json:"sensitive_attributes"`
+	}
+	tfStep struct {
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	tfOutput struct {
+		Sensitive bool `json:"sensitive"`
+	}
+
+	// State files before Terraform 0.12
+	tfModuleV3 struct {
+		Path    []string             `json:"path"`
+		Outputs map[string]*tfOutput `json:"outputs"`
+	}
+)
+
+func parseTerraformState(contents string) (result []*Secret, err error) {
+	var state tfState
+	if err = json.Unmarshal([]byte(contents), &state); err != nil {
+		return
+	}
+
+	var pairs []*structured.Pair
+	if pairs, err = structured.Parse(structured.JSON, contents); err != nil {
+		return
+	}
+
+	return collect(contents, pairs, state.secretFor), nil
+}
+
+func (s *tfState) secretFor(pair *structured.Pair) *Secret {
+	path := pair.Path
+
+	switch {
+
+	// resources[0].instances[0].attributes.password
+	case len(path) >= 6 && path[0] == "resources" && path[2] == "instances" && path[4] == "attributes":
+		resource, instance := s.instance(path[1], path[3])
+		if resource == nil {
+			return nil
+		}
+		attribute := path[5:]
+		marked := instance.marks(attribute)
+		if !marked && !isSensitiveKey(pair.Key()) {
+			return nil
+		}
+		return &Secret{Address: resource.address(instance), Attribute: pathString(attribute), Marked: marked}
+
+	// outputs.db_password.value
+	case len(path) >= 3 && path[0] == "outputs" && path[2] == "value":
+		if output := s.Outputs[path[1]]; output == nil || !output.Sensitive {
+			return nil
+		}
+		return &Secret{Address: "output." + path[1], Attribute: pathString(path[2:]), Marked: true}
+
+	// modules[0].resources.aws_db_instance.main.primary.attributes.password
+	case len(path) == 7 && path[0] == "modules" && path[2] == "resources" && path[4] == "primary" && path[5] == "attributes":
+		module := s.module(path[1])
+		if module == nil || !isSensitiveKey(path[6]) {
+			return nil
+		}
+		return &Secret{Address: module.prefix() + path[3], Attribute: path[6]}
+
+	// modules[0].outputs.db_password.value
+	case len(path) >= 5 && path[0] == "modules" && path[2] == "outputs" && path[4] == "value":
+		module := s.module(path[1])
+		if module == nil {
+			return nil
+		}
+		if output := module.Outputs[path[3]]; output == nil || !output.Sensitive {
+			return nil
+		}
+		return &Secret{Address: module.prefix() + "output." + path[3], Attribute: pathString(path[4:]), Marked: true}
+	}
+
+	return nil
+}
+
+func (s *tfState) instance(resourceKey, instanceKey string) (resource *tfResource, instance *tfInstance) {
+	resourceIndex, ok := parseIndex(resourceKey)
+	if !ok || resourceIndex >= len(s.Resources) {
+		return
+	}
+	resource = s.Resources[resourceIndex]
+
+	instanceIndex, ok := parseIndex(instanceKey)
+	if !ok || instanceIndex >= len(resource.Instances) {
+		return nil, nil
+	}
+	instance = resource.Instances[instanceIndex]
+
+	return
+}
+
+func (s *tfState) module(moduleKey string) *tfModuleV3 {
+	moduleIndex, ok := parseIndex(moduleKey)
+	if !ok || moduleIndex >= len(s.Modules) {
+		return nil
+	}
+	return s.Modules[moduleIndex]
+}
+
+// module.db.aws_db_instance.main["primary"]
+func (r *tfResource) address(instance *tfInstance) (result string) {
+	if r.Module != "" {
+		result = r.Module + "."
+	}
+	if r.Mode == "data" {
+		result += "data."
+	}
+	result += r.Type + "." + r.Name
+
+	switch key := instance.IndexKey.(type) {
+	case float64:
+		result += "[" + strconv.FormatFloat(key, 'f', -1, 64) + "]"
+	case string:
+		result += "[" + strconv.Quote(key) + "]"
+	}
+
+	return
+}
+
+// Whether Terraform marked the attribute, or something containing it, as sensitive
+func (i *tfInstance) marks(attribute []string) bool {
+	for _, steps := range i.SensitiveAttributes {
+		if len(steps) == 0 || len(steps) > len(attribute) {
+			continue
+		}
+
+		matched := true
+		for j, step := range steps {
+			if step.key() != attribute[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The step as a structured path key
+func (s *tfStep) key() string {
+	switch s.Type {
+	case "get_attr":
+		var name string
+		if err := json.Unmarshal(s.Value, &name); err == nil {
+			return name
+		}
+	case "index":
+		var index struct {
+			Value interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(s.Value, &index); err != nil {
+			return ""
+		}
+		switch value := index.Value.(type) {
+		case float64:
+			return "[" + strconv.Itoa(int(value)) + "]"
+		case string:
+			return value
+		}
+	}
+
+	return ""
+}
+
+// module.network.module.db.
+func (m *tfModuleV3) prefix() (result string) {
+	for i, name := range m.Path {
+		if i == 0 && name == "root" {
+			continue
+		}
+		result += "module." + name + "."
+	}
+	return
+}
+
+//
+// CloudFormation
+
+type cfnParameter struct {
+	NoEcho interface{} `yaml:"NoEcho"`
+}
+
+func parseCloudFormation(contents string) (result []*Secret, err error) {
+	var template struct {
+		Parameters map[string]*cfnParameter `yaml:"Parameters"`
+	}
+	if err = yaml.Unmarshal([]byte(contents), &template); err != nil {
+		return
+	}
+
+	format := structured.YAML
+	if strings.HasPrefix(strings.TrimSpace(contents), "{") {
+		format = structured.JSON
+	}
+
+	var pairs []*structured.Pair
+	if pairs, err = structured.Parse(format, contents); err != nil {
+		return
+	}
+
+	// Parameters.DBPassword.Default, when DBPassword has NoEcho
+	return collect(contents, pairs, func(pair *structured.Pair) *Secret {
+		path := pair.Path
+		if len(path) != 3 || path[0] != "Parameters" || path[2] != "Default" {
+			return nil
+		}
+		if parameter := template.Parameters[path[1]]; parameter == nil || !parameter.noEcho() {
+			return nil
+		}
+		return &Secret{Address: path[1], Attribute: path[2], Marked: true}
+	}), nil
+}
+
+func (p *cfnParameter) noEcho() bool {
+	switch value := p.NoEcho.(type) {
+	case bool:
+		return value
+	case string:
+		return strings.EqualFold(value, "true")
+	}
+	return false
+}
+
+//
+// Helpers
+
+func isSensitiveKey(key string) bool {
+	return sensitiveKeyRegex.MatchString(key) && !referenceKeyRegex.MatchString(key)
+}
+
+func parseIndex(key string) (result int, ok bool) {
+	if !strings.HasPrefix(key, "[") || !strings.HasSuffix(key, "]") {
+		return
+	}
+	var err error
+	if result, err = strconv.Atoi(key[1 : len(key)-1]); err != nil {
+		return
+	}
+	return result, true
+}
+
+func pathString(path []string) string {
+	return (&structured.Pair{Path: path}).PathString()
+}
@@ -0,0 +1,133 @@
+package iac_test
+
+import (
+	"testing"
+
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/processor/iac"
+	"github.com/stretchr/testify/require"
+)
+
+type found struct {
+	address   string
+	attribute string
+	value     string
+	marked    bool
+	lineNum   int
+	index     int
+}
+
+func runParseTest(t *testing.T, format Format, contents string, expected []found) {
+
+	// Fire
+	secrets, err := Parse(format, contents)
+
+	require.NoError(t, err)
+	var actual []found
+	for _, secret := range secrets {
+		actual = append(actual, found{
+			address:   secret.Address,
+			attribute: secret.Attribute,
+			value:     secret.Value,
+			marked:    secret.Marked,
+			lineNum:   secret.Position.StartLineNum,
+			index:     secret.Position.StartIndex,
+		})
+	}
+	require.Equal(t, expected, actual)
+}
+
+func TestParse_TerraformState(t *testing.T) {
+	contents := `{
+  "version": 4,
+  "outputs": {
+    "db_password": {"value": "0utput-pass", "type": "string", "sensitive": true},
+    "db_host": {"value": "db.example.com", "type": "string"}
+  },
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_db_instance",
+      "name": "main",
+      "instances": [
+        {
+          "attributes": {
+            "identifier": "main",
+            "password": "rds-pass"
+          },
+          "sensitive_attributes": []
+        }
+      ]
+    },
+    {
+      "module": "module.app",
+      "mode": "managed",
+      "type": "random_password",
+      "name": "api",
+      "instances": [
+        {
+          "index_key": "primary",
+          "attributes": {
+            "result": "r4ndom-result",
+            "secret_arn": "arn:aws:secretsmanager:us-east-1:1:secret:x"
+          },
+          "sensitive_attributes": [[{"type": "get_attr", "value": "result"}]]
+        }
+      ]
+    }
+  ]
+}`
+	runParseTest(t, TerraformState, contents, []found{
+		{"output.db_password", "value", "0utput-pass", true, 4, 30},
+		{"aws_db_instance.main", "password", "rds-pass", false, 16, 25},
+		{`module.app.random_password.api["primary"]`, "result", "r4ndom-result", true, 31, 23},
+	})
+}
+
+func TestParse_TerraformStateV3(t *testing.T) {
+	contents := `{
+  "version": 3,
+  "modules": [
+    {
+      "path": ["root", "db"],
+      "outputs": {},
+      "resources": {
+        "aws_iam_access_key.deploy": {
+          "type": "aws_iam_access_key",
+          "primary": {
+            "id": "AKIAEXAMPLE",
+            "attributes": {
+              "id": "AKIAEXAMPLE",
+              "secret": "v3-secret"
+            }
+          }
+        }
+      }
+    }
+  ]
+}`
+	runParseTest(t, TerraformState, contents, []found{
+		{"module.db.aws_iam_access_key.deploy", "secret", "v3-secret", false, 14, 25},
+	})
+}
+
+func TestParse_CloudFormation(t *testing.T) {
+	contents := `AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  DBPassword:
+    Type: String
+    NoEcho: true
+    Default: cfn-pass
+  DBUser:
+    Type: String
+    Default: admin
+Resources:
+  DB:
+    Type: AWS::RDS::DBInstance
+    Properties:
+      MasterUsername: !Ref DBUser
+      MasterUserPassword: !Ref DBPassword
+`
+	runParseTest(t, CloudFormation, contents, []found{
+		{"DBPassword", "Default", "cfn-pass", true, 6, 13},
+	})
+}
@@ -0,0 +1,105 @@
+package iac
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+)
+
+const AddressExtraKey = "iac-address"
+
+type Processor struct {
+	name   string
+	format Format
+	pathRe *regexp.Regexp
+	log    logg.Logg
+}
+
+func NewProcessor(name string, format Format, log logg.Logg) *Processor {
+	return &Processor{
+		name:   name,
+		format: format,
+		pathRe: regexp.MustCompile(format.PathMatchString()),
+		log:    log,
+	}
+}
+
+func (p *Processor) GetName() string {
+	return p.name
+}
+
+func (p *Processor) FindResultsInFileChange(job contract.ProcessorJobI) (err error) {
+	fileChange := job.FileChange()
+	if !p.pathRe.MatchString(fileChange.Path) {
+		return
+	}
+
+	var contents string
+	if contents, err = fileChange.FileContents(); err != nil {
+		return
+	}
+
+	// Only templates with hidden parameters are worth parsing
+	if p.format == CloudFormation && !strings.Contains(contents, "NoEcho") {
+		return
+	}
+
+	secrets, parseErr := Parse(p.format, contents)
+	if parseErr != nil {
+		job.Log(p.log).WithError(parseErr).Debug("skipping file that can't be parsed")
+		return
+	}
+
+	added := job.Diff().AddedLineNums()
+
+	for _, secret := range secrets {
+		position := secret.Position
+		if !added[position.StartLineNum] {
+			continue
+		}
+
+		job.SearchingLine(position.StartLineNum)
+		job.SubmitResult(&contract.Result{
+			FileRange: &manip.FileRange{
+				StartLineNum: position.StartLineNum,
+				StartIndex:   position.StartIndex,
+				EndLineNum:   position.EndLineNum,
+				EndIndex:     position.EndIndex,
+			},
+			SecretValue:   secret.Value,
+			FindingExtras: p.buildExtras(secret),
+		})
+	}
+
+	return
+}
+
+func (p *Processor) buildExtras(secret *Secret) (result []*contract.ResultExtra) {
+	result = []*contract.ResultExtra{
+		{
+			Key:    AddressExtraKey,
+			Header: "Resource",
+			Value:  secret.Address,
+			Code:   true,
+		},
+		{
+			Key:    "iac-attribute",
+			Header: "Attribute",
+			Value:  secret.Attribute,
+			Code:   true,
+		},
+	}
+
+	if secret.Marked {
+		result = append(result, &contract.ResultExtra{
+			Key:    "iac-marked-sensitive",
+			Header: "Marked sensitive",
+			Value:  "yes",
+		})
+	}
+
+	return
+}
@@ -0,0 +1,207 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+)
+
+const (
+	FlagsExtraKey = "jwt-flags"
+	UnsignedFlag  = "unsigned"
+	LongLivedFlag = "long-lived"
+	NoExpiryFlag  = "no-expiry"
+)
+
+// Header and claims are both JSON objects, so both segments start with "eyJ" ('{"')
+var tokenRegex = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{4,}={0,2}\.eyJ[A-Za-z0-9_-]{4,}={0,2}\.[A-Za-z0-9_-]*`)
+
+type (
+	Processor struct {
+		name             string
+		skipExpiredAfter time.Duration
+		longLivedAfter   time.Duration
+		now              time.Time
+		log              logg.Logg
+	}
+	Token struct {
+		Header Header
+		Claims Claims
+	}
+	Header struct {
+		Alg string `json:"alg"`
+		Typ string `json:"typ"`
+		Kid string `json:"kid"`
+	}
+	Claims struct {
+		Iss string          `json:"iss"`
+		Sub string          `json:"sub"`
+		Aud json.RawMessage `json:"aud"`
+		Exp *float64        `json:"exp"`
+		Iat *float64        `json:"iat"`
+	}
+)
+
+// Tokens that expired more than skipExpiredAfter before now are ignored, and tokens
+// valid for longer than longLivedAfter are flagged. Zero disables either policy.
+func NewProcessor(name string, skipExpiredAfter, longLivedAfter time.Duration, now time.Time, log logg.Logg) *Processor {
+	return &Processor{
+		name:             name,
+		skipExpiredAfter: skipExpiredAfter,
+		longLivedAfter:   longLivedAfter,
+		now:              now,
+		log:              log,
+	}
+}
+
+func (p *Processor) GetName() string {
+	return p.name
+}
+
+func (p *Processor) FindResultsInLine(job contract.LineProcessorJobI, line string) (err error) {
+	for _, match := range tokenRegex.FindAllStringIndex(line, -1) {
+		lineRange := manip.NewLineRange(match[0], match[1])
+		value := line[match[0]:match[1]]
+
+		token, parseErr := Parse(value)
+		if parseErr != nil {
+			job.Log(p.log).WithError(parseErr).Debug("JWT lookalike could not be decoded")
+			continue
+		}
+
+		if p.expiredLongAgo(token) {
+			job.Log(p.log).Debugf("ignoring JWT that expired at %s", token.Claims.ExpiresAt().Format(time.RFC3339))
+			job.SubmitLineRangeIgnore(lineRange)
+			continue
+		}
+
+		job.SubmitLineResult(&contract.LineResult{
+			LineRange:     lineRange,
+			SecretValue:   value,
+			FindingExtras: p.buildExtras(token),
+		})
+	}
+
+	return
+}
+
+func (p *Processor) expiredLongAgo(token *Token) bool {
+	if p.skipExpiredAfter == 0 || token.Claims.Exp == nil {
+		return false
+	}
+	return p.now.Sub(token.Claims.ExpiresAt()) > p.skipExpiredAfter
+}
+
+func (p *Processor) flags(token *Token) (result []string) {
+	if strings.EqualFold(token.Header.Alg, "none") {
+		result = append(result, UnsignedFlag)
+	}
+
+	claims := token.Claims
+	switch {
+	case claims.Exp == nil:
+		result = append(result, NoExpiryFlag)
+	case p.longLivedAfter > 0 && claims.Iat != nil:
+		if claims.ExpiresAt().Sub(claims.IssuedAt()) > p.longLivedAfter {
+			result = append(result, LongLivedFlag)
+		}
+	case p.longLivedAfter > 0:
+		if claims.ExpiresAt().Sub(p.now) > p.longLivedAfter {
+			result = append(result, LongLivedFlag)
+		}
+	}
+
+	return
+}
+
+func (p *Processor) buildExtras(token *Token) (result []*contract.ResultExtra) {
+	result = contract.AppendExtra(result, "jwt-alg", "JWT algorithm", token.Header.Alg)
+	result = contract.AppendExtra(result, "jwt-iss", "JWT issuer", token.Claims.Iss)
+	result = contract.AppendExtra(result, "jwt-sub", "JWT subject", token.Claims.Sub)
+	result = contract.AppendExtra(result, "jwt-aud", "JWT audience", strings.Join(token.Claims.Audience(), ", "))
+	if token.Claims.Exp != nil {
+		exp := token.Claims.ExpiresAt().UTC().Format(time.RFC3339)
+		if token.Claims.ExpiresAt().Before(p.now) {
+			exp += " (expired)"
+		}
+		result = contract.AppendExtra(result, "jwt-exp", "JWT expires", exp)
+	}
+	result = contract.AppendExtra(result, FlagsExtraKey, "JWT flags", strings.Join(p.flags(token), ", "))
+
+	return
+}
+
+//
+// Parsing
+
+// Decodes the header and claims, the signature isn't checked
+func Parse(value string) (result *Token, err error) {
+	segments := strings.Split(value, ".")
+	if len(segments) != 3 {
+		err = errors.Errorv("expected 3 JWT segments, found", len(segments))
+		return
+	}
+
+	result = &Token{}
+	if err = decodeSegment(segments[0], &result.Header); err != nil {
+		return
+	}
+	if err = decodeSegment(segments[1], &result.Claims); err != nil {
+		return
+	}
+	if result.Header.Alg == "" {
+		err = errors.New("JWT header has no alg")
+	}
+
+	return
+}
+
+func decodeSegment(segment string, target interface{}) (err error) {
+	var decoded []byte
+	if decoded, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "=")); err != nil {
+		err = errors.Wrap(err, "unable to decode JWT segment")
+		return
+	}
+	if err = json.Unmarshal(decoded, target); err != nil {
+		err = errors.Wrap(err, "unable to parse JWT segment")
+	}
+	return
+}
+
+func (c *Claims) ExpiresAt() time.Time {
+	return numericDate(*c.Exp)
+}
+
+func (c *Claims) IssuedAt() time.Time {
+	return numericDate(*c.Iat)
+}
+
+// "aud" is either a string or an array of strings
+func (c *Claims) Audience() (result []string) {
+	if len(c.Aud) == 0 {
+		return
+	}
+
+	var single string
+	if err := json.Unmarshal(c.Aud, &single); err == nil {
+		if single != "" {
+			result = []string{single}
+		}
+		return
+	}
+
+	_ = json.Unmarshal(c.Aud, &result)
+
+	return
+}
+
+func numericDate(value float64) time.Time {
+	return time.Unix(int64(value), 0)
+}
@@ -0,0 +1,112 @@
+package jwt_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/processor/jwt"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/searchtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	log = logg.NewLogrusLogg(logrus.New())
+	now = time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestProcessor_Claims(t *testing.T) {
+	token := buildToken(`{"alg":"HS256","typ":"JWT"}`, `{"iss":"auth.example.com","sub":"svc-deploy","aud":["api","admin"],"iat":1590969600,"exp":1590973200}`, "c2ln")
+	subject := NewProcessor("jwt", 90*24*time.Hour, 7*24*time.Hour, now, log)
+	job := &searchtest.LineProcJobMock{Logger: log}
+
+	// Fire
+	err := subject.FindResultsInLine(job, `AUTH_TOKEN="`+token+`"`)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{token}, job.SecretValues)
+	require.Equal(t, map[string]string{
+		"jwt-alg": "HS256",
+		"jwt-iss": "auth.example.com",
+		"jwt-sub": "svc-deploy",
+		"jwt-aud": "api, admin",
+		"jwt-exp": "2020-06-01T01:00:00Z",
+	}, extraValues(job.FindingExtras))
+}
+
+func TestProcessor_AlgNoneAndLongLived(t *testing.T) {
+	token := buildToken(`{"alg":"none"}`, `{"sub":"admin","iat":1590969600,"exp":1622505600}`, "")
+	subject := NewProcessor("jwt", 90*24*time.Hour, 7*24*time.Hour, now, log)
+	job := &searchtest.LineProcJobMock{Logger: log}
+
+	// Fire
+	err := subject.FindResultsInLine(job, token)
+
+	require.NoError(t, err)
+	require.Equal(t, UnsignedFlag+", "+LongLivedFlag, extraValues(job.FindingExtras)[FlagsExtraKey])
+}
+
+func TestProcessor_NoExpiry(t *testing.T) {
+	token := buildToken(`{"alg":"RS256"}`, `{"sub":"admin"}`, "c2ln")
+	subject := NewProcessor("jwt", 90*24*time.Hour, 7*24*time.Hour, now, log)
+	job := &searchtest.LineProcJobMock{Logger: log}
+
+	// Fire
+	err := subject.FindResultsInLine(job, token)
+
+	require.NoError(t, err)
+	require.Equal(t, NoExpiryFlag, extraValues(job.FindingExtras)[FlagsExtraKey])
+}
+
+func TestProcessor_ExpiredLongAgo(t *testing.T) {
+	token := buildToken(`{"alg":"HS256"}`, `{"sub":"admin","exp":1262304000}`, "c2ln")
+	subject := NewProcessor("jwt", 90*24*time.Hour, 7*24*time.Hour, now, log)
+	job := &searchtest.LineProcJobMock{Logger: log}
+
+	// Fire
+	err := subject.FindResultsInLine(job, token)
+
+	require.NoError(t, err)
+	require.Empty(t, job.SecretValues)
+	require.Len(t, job.Ignored, 1)
+}
+
+func TestProcessor_ExpiredLongAgo_PolicyDisabled(t *testing.T) {
+	token := buildToken(`{"alg":"HS256"}`, `{"sub":"admin","exp":1262304000}`, "c2ln")
+	subject := NewProcessor("jwt", 0, 0, now, log)
+	job := &searchtest.LineProcJobMock{Logger: log}
+
+	// Fire
+	err := subject.FindResultsInLine(job, token)
+
+	require.NoError(t, err)
+	require.Len(t, job.SecretValues, 1)
+	require.Equal(t, "2010-01-01T00:00:00Z (expired)", extraValues(job.FindingExtras)["jwt-exp"])
+}
+
+func TestProcessor_NotJSON(t *testing.T) {
+	subject := NewProcessor("jwt", 0, 0, now, log)
+	job := &searchtest.LineProcJobMock{Logger: log}
+
+	// Fire
+	err := subject.FindResultsInLine(job, "eyJhbGciOiJIUzI1NiJ9.eyJnotjson.c2ln")
+
+	require.NoError(t, err)
+	require.Empty(t, job.SecretValues)
+}
+
+func buildToken(header, claims, signature string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(header)) + "." + encode([]byte(claims)) + "." + signature
+}
+
+func extraValues(extras []*contract.ResultExtra) (result map[string]string) {
+	result = map[string]string{}
+	for _, extra := range extras {
+		result[extra.Key] = extra.Value
+	}
+	return
+}
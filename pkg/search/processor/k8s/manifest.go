@@ -0,0 +1,171 @@
+package k8s
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	documentSeparatorRegex = regexp.MustCompile(`(?m)^---.*$`)
+
+	// A block scalar's indicator, like "|" or ">-", and the indentation of its first line
+	blockScalarRegex = regexp.MustCompile(`^[|>][-+0-9]*[ \t]*(?:#.*)?\r?\n[ \t]*`)
+)
+
+type (
+	// A data value in a secret resource, with where it was written in the file
+	Entry struct {
+		Kind      string
+		Name      string
+		Namespace string
+		Key       string
+		Value     string
+		Encoded   bool
+		LineNum   int
+		Index     int
+		RawValue  string
+	}
+
+	manifest struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name      string `yaml:"name"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"metadata"`
+		Data       map[string]string `yaml:"data"`
+		StringData map[string]string `yaml:"stringData"`
+		Spec       struct {
+			// SealedSecret-like resources keep unencrypted fields here
+			Template struct {
+				Data       map[string]string `yaml:"data"`
+				StringData map[string]string `yaml:"stringData"`
+			} `yaml:"template"`
+		} `yaml:"spec"`
+		Items []*manifest `yaml:"items"`
+	}
+)
+
+// Data values of the resources in the YAML that have one of the kinds.
+// Documents that aren't valid YAML (templates, etc) are skipped.
+func ParseEntries(contents string, kinds []string) (result []*Entry) {
+	for _, doc := range splitDocuments(contents) {
+		var m manifest
+		if err := yaml.Unmarshal([]byte(doc.value), &m); err != nil {
+			continue
+		}
+
+		for _, resource := range m.resources() {
+			if !hasKind(kinds, resource.Kind) {
+				continue
+			}
+			result = append(result, resource.entries(contents, doc.offset, len(doc.value))...)
+		}
+	}
+
+	return
+}
+
+func (m *manifest) resources() (result []*manifest) {
+	if m.Kind == "List" || strings.HasSuffix(m.Kind, "List") {
+		for _, item := range m.Items {
+			if item != nil {
+				result = append(result, item)
+			}
+		}
+		return
+	}
+
+	return []*manifest{m}
+}
+
+func (m *manifest) entries(contents string, docOffset, docLen int) (result []*Entry) {
+	add := func(values map[string]string, encoded bool) {
+		for key, rawValue := range values {
+			entry := &Entry{
+				Kind:      m.Kind,
+				Name:      m.Metadata.Name,
+				Namespace: m.Metadata.Namespace,
+				Key:       key,
+				Value:     rawValue,
+				Encoded:   encoded,
+				RawValue:  rawValue,
+			}
+
+			if encoded {
+				decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(rawValue))
+				if err != nil {
+					continue
+				}
+				entry.Value = string(decoded)
+			}
+
+			if !entry.locate(contents, docOffset, docLen) {
+				continue
+			}
+
+			result = append(result, entry)
+		}
+	}
+
+	add(m.Data, true)
+	add(m.StringData, false)
+	add(m.Spec.Template.Data, true)
+	add(m.Spec.Template.StringData, false)
+
+	return
+}
+
+// Finds the "key: value" line so the result can point at it
+func (e *Entry) locate(contents string, docOffset, docLen int) bool {
+	firstLine := strings.SplitN(e.RawValue, "\n", 2)[0]
+	if firstLine == "" {
+		return false
+	}
+
+	keyRe := regexp.MustCompile(`(?m)^[ \t-]*['"]?` + regexp.QuoteMeta(e.Key) + `['"]?[ \t]*:[ \t]*['"]?`)
+	doc := contents[docOffset : docOffset+docLen]
+
+	for _, match := range keyRe.FindAllStringIndex(doc, -1) {
+		valueStart := match[1] + len(blockScalarRegex.FindString(doc[match[1]:]))
+		if !strings.HasPrefix(doc[valueStart:], firstLine) {
+			continue
+		}
+
+		offset := docOffset + valueStart
+		e.LineNum = strings.Count(contents[:offset], "\n") + 1
+		e.Index = offset - (strings.LastIndex(contents[:offset], "\n") + 1)
+		e.RawValue = firstLine
+
+		return true
+	}
+
+	return false
+}
+
+type document struct {
+	value  string
+	offset int
+}
+
+func splitDocuments(contents string) (result []document) {
+	start := 0
+	for _, sep := range documentSeparatorRegex.FindAllStringIndex(contents, -1) {
+		result = append(result, document{value: contents[start:sep[0]], offset: start})
+		start = sep[1]
+	}
+	result = append(result, document{value: contents[start:], offset: start})
+
+	return
+}
+
+func hasKind(kinds []string, kind string) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package k8s_test
+
+import (
+	"testing"
+
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/processor/k8s"
+	"github.com/stretchr/testify/require"
+)
+
+var kinds = []string{"Secret", "SealedSecret"}
+
+func TestParseEntries_Secret(t *testing.T) {
+	contents := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  password: bm90LWEtc2VjcmV0
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: db-creds
+  namespace: billing
+type: Opaque
+data:
+  password: aHVudGVyMg==
+stringData:
+  api-token: "abc123xyz"
+`
+
+	// Fire
+	entries := ParseEntries(contents, kinds)
+
+	require.Len(t, entries, 2)
+	byKey := map[string]*Entry{}
+	for _, entry := range entries {
+		byKey[entry.Key] = entry
+	}
+
+	require.Equal(t, &Entry{
+		Kind:      "Secret",
+		Name:      "db-creds",
+		Namespace: "billing",
+		Key:       "password",
+		Value:     "hunter2",
+		Encoded:   true,
+		LineNum:   15,
+		Index:     12,
+		RawValue:  "aHVudGVyMg==",
+	}, byKey["password"])
+	require.Equal(t, "abc123xyz", byKey["api-token"].Value)
+	require.False(t, byKey["api-token"].Encoded)
+	require.Equal(t, 17, byKey["api-token"].LineNum)
+	require.Equal(t, 14, byKey["api-token"].Index)
+}
+
+func TestParseEntries_SealedSecretTemplate(t *testing.T) {
+	contents := `kind: SealedSecret
+metadata:
+  name: api
+spec:
+  encryptedData:
+    token: AgBy3i4OJSWK+PiTySYZZA9rO43cGDEq
+  template:
+    data:
+      token: c2VjcmV0LXRva2Vu
+`
+
+	// Fire
+	entries := ParseEntries(contents, kinds)
+
+	require.Len(t, entries, 1)
+	require.Equal(t, "secret-token", entries[0].Value)
+	require.Equal(t, 9, entries[0].LineNum)
+}
+
+func TestParseEntries_List(t *testing.T) {
+	contents := `kind: List
+items:
+  - kind: Secret
+    metadata:
+      name: one
+    data:
+      key: b25l
+`
+
+	// Fire
+	entries := ParseEntries(contents, kinds)
+
+	require.Len(t, entries, 1)
+	require.Equal(t, "one", entries[0].Value)
+	require.Equal(t, 7, entries[0].LineNum)
+}
+
+func TestParseEntries_BlockScalar(t *testing.T) {
+	contents := `kind: Secret
+data:
+  password: |
+    aHVudGVyMg==
+  token: >-
+    c2VjcmV0LXRva2Vu
+`
+
+	// Fire
+	entries := ParseEntries(contents, kinds)
+
+	require.Len(t, entries, 2)
+	byKey := map[string]*Entry{}
+	for _, entry := range entries {
+		byKey[entry.Key] = entry
+	}
+	require.Equal(t, "hunter2", byKey["password"].Value)
+	require.Equal(t, 4, byKey["password"].LineNum)
+	require.Equal(t, 4, byKey["password"].Index)
+	require.Equal(t, "aHVudGVyMg==", byKey["password"].RawValue)
+	require.Equal(t, "secret-token", byKey["token"].Value)
+	require.Equal(t, 6, byKey["token"].LineNum)
+	require.Equal(t, 4, byKey["token"].Index)
+}
+
+func TestParseEntries_InvalidYAML(t *testing.T) {
+	contents := `kind: Secret
+data:
+  password: {{ .Values.password | b64enc }}
+`
+
+	// Fire
+	entries := ParseEntries(contents, kinds)
+
+	require.Empty(t, entries)
+}
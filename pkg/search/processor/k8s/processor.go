@@ -0,0 +1,89 @@
+package k8s
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+)
+
+var yamlPathRegex = regexp.MustCompile(`(?i)\.ya?ml$`)
+
+type Processor struct {
+	name    string
+	kinds   []string
+	targets *search.TargetSet
+	log     logg.Logg
+}
+
+func NewProcessor(name string, kinds []string, targets *search.TargetSet, log logg.Logg) *Processor {
+	return &Processor{
+		name:    name,
+		kinds:   kinds,
+		targets: targets,
+		log:     log,
+	}
+}
+
+func (p *Processor) GetName() string {
+	return p.name
+}
+
+// Decoded values are checked against the targets using the data key, like a setter would
+func (p *Processor) FindResultsInFileChange(job contract.ProcessorJobI) (err error) {
+	fileChange := job.FileChange()
+	if !yamlPathRegex.MatchString(fileChange.Path) {
+		return
+	}
+
+	var contents string
+	if contents, err = fileChange.FileContents(); err != nil {
+		return
+	}
+	if !strings.Contains(contents, "kind:") {
+		return
+	}
+
+	added := job.Diff().AddedLineNums()
+
+	for _, entry := range ParseEntries(contents, p.kinds) {
+		if !added[entry.LineNum] {
+			continue
+		}
+
+		log := job.Log(p.log).WithField("secretKey", entry.Key)
+		if !p.targets.Matches(entry.Key, entry.Value, log) {
+			log.Debug("decoded value doesn't match any targets")
+			continue
+		}
+
+		job.SearchingLine(entry.LineNum)
+		job.SubmitResult(&contract.Result{
+			FileRange: &manip.FileRange{
+				StartLineNum: entry.LineNum,
+				StartIndex:   entry.Index,
+				EndLineNum:   entry.LineNum,
+				EndIndex:     entry.Index + len(entry.RawValue),
+			},
+			SecretValue:   entry.Value,
+			FindingExtras: buildExtras(entry),
+		})
+	}
+
+	return
+}
+
+func buildExtras(entry *Entry) (result []*contract.ResultExtra) {
+	result = contract.AppendExtra(result, "k8s-kind", "Kubernetes kind", entry.Kind)
+	result = contract.AppendExtra(result, "k8s-secret-name", "Kubernetes secret", entry.Name)
+	result = contract.AppendExtra(result, "k8s-namespace", "Kubernetes namespace", entry.Namespace)
+	result = contract.AppendExtra(result, "k8s-secret-key", "Kubernetes secret key", entry.Key)
+	if entry.Encoded {
+		result = contract.AppendExtra(result, "k8s-encoding", "Encoding", "base64")
+	}
+
+	return
+}
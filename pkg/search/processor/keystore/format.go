@@ -0,0 +1,61 @@
+package keystore
+
+//go:generate stringer -type Format
+
+import "strings"
+
+type Format int
+
+const (
+	PuTTY Format = iota
+	JKS
+	PKCS12
+)
+
+func Formats() []Format {
+	return []Format{
+		PuTTY,
+		JKS,
+		PKCS12,
+	}
+}
+
+func (i Format) Value() string {
+	return strings.ToLower(i.String())
+}
+
+// Keystores are found by file name, then checked by content
+func (i Format) PathMatchString() string {
+	switch i {
+	case PuTTY:
+		return `(?i)\.ppk$`
+	case JKS:
+		return `(?i)\.(jks|jceks|keystore)$`
+	case PKCS12:
+		return `(?i)\.(p12|pfx)$`
+	default:
+		panic("unknown format: " + i.String())
+	}
+}
+
+func (i Format) IsBinary() bool {
+	return i != PuTTY
+}
+
+func NewFormatFromValue(val string) Format {
+	for _, e := range Formats() {
+		if e.Value() == val {
+			return e
+		}
+	}
+	panic("unknown keystore format: " + val)
+}
+
+func ValidFormatValues() (result []string) {
+	formats := Formats()
+	result = make([]string, len(formats))
+	for i := range formats {
+		result[i] = formats[i].Value()
+	}
+	return
+}
@@ -0,0 +1,162 @@
+package keystore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/pkcs12"
+)
+
+const (
+	jksMagic   = 0xFEEDFEED
+	jceksMagic = 0xCECECECE
+
+	jksPrivateKeyTag  = 1
+	jksTrustedCertTag = 2
+	jceksSecretKeyTag = 3
+)
+
+// What can be told about a keystore without its passphrase
+type Inspection struct {
+	HasPrivateKey   bool
+	Protected       bool
+	ProtectionKnown bool
+}
+
+func Inspect(format Format, contents string) (result *Inspection) {
+	switch format {
+	case PuTTY:
+		return inspectPuTTY(contents)
+	case JKS:
+		return inspectJKS([]byte(contents))
+	case PKCS12:
+		return inspectPKCS12([]byte(contents))
+	default:
+		panic("unknown format: " + format.String())
+	}
+}
+
+// PuTTY-User-Key-File-2: ssh-rsa
+// Encryption: aes256-cbc
+// Comment: imported-openssh-key
+// [...]
+func inspectPuTTY(contents string) (result *Inspection) {
+	result = &Inspection{}
+
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+	if !scanner.Scan() || !strings.HasPrefix(scanner.Text(), "PuTTY-User-Key-File-") {
+		return
+	}
+	result.HasPrivateKey = true
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "Encryption:") {
+			result.Protected = strings.TrimSpace(strings.TrimPrefix(line, "Encryption:")) != "none"
+			result.ProtectionKnown = true
+			break
+		}
+	}
+
+	return
+}
+
+// Java keystores encrypt every private key entry, so they're always protected.
+// Trust stores only hold certificates, so they aren't reported.
+func inspectJKS(contents []byte) (result *Inspection) {
+	result = &Inspection{Protected: true, ProtectionKnown: true}
+
+	reader := bytes.NewReader(contents)
+	var header struct{ Magic, Version, Count uint32 }
+	if err := binary.Read(reader, binary.BigEndian, &header); err != nil {
+		return
+	}
+	if header.Magic != jksMagic && header.Magic != jceksMagic {
+		return
+	}
+
+	for i := uint32(0); i < header.Count; i++ {
+		var tag uint32
+		if err := binary.Read(reader, binary.BigEndian, &tag); err != nil {
+			return
+		}
+
+		switch tag {
+		case jksPrivateKeyTag, jceksSecretKeyTag:
+			result.HasPrivateKey = true
+			return
+		case jksTrustedCertTag:
+			if !skipJKSTrustedCert(reader) {
+				return
+			}
+		default:
+			return
+		}
+	}
+
+	return
+}
+
+// Alias, timestamp, then a certificate type and the encoded certificate
+func skipJKSTrustedCert(reader *bytes.Reader) bool {
+	if !skipJKSUTF(reader) {
+		return false
+	}
+	if _, err := reader.Seek(8, io.SeekCurrent); err != nil {
+		return false
+	}
+	if !skipJKSUTF(reader) {
+		return false
+	}
+
+	var certLen uint32
+	if err := binary.Read(reader, binary.BigEndian, &certLen); err != nil {
+		return false
+	}
+	_, err := reader.Seek(int64(certLen), io.SeekCurrent)
+
+	return err == nil
+}
+
+func skipJKSUTF(reader *bytes.Reader) bool {
+	var strLen uint16
+	if err := binary.Read(reader, binary.BigEndian, &strLen); err != nil {
+		return false
+	}
+	_, err := reader.Seek(int64(strLen), io.SeekCurrent)
+
+	return err == nil
+}
+
+// A PKCS#12 file that opens with an empty password isn't protected at all
+func inspectPKCS12(contents []byte) (result *Inspection) {
+	result = &Inspection{}
+
+	// DER encoded SEQUENCE
+	if len(contents) < 2 || contents[0] != 0x30 {
+		return
+	}
+
+	blocks, err := pkcs12.ToPEM(contents, "")
+	switch {
+	case err == nil:
+		for _, block := range blocks {
+			if strings.HasSuffix(block.Type, "PRIVATE KEY") {
+				result.HasPrivateKey = true
+			}
+		}
+		result.ProtectionKnown = true
+	case err == pkcs12.ErrIncorrectPassword:
+		result.HasPrivateKey = true
+		result.Protected = true
+		result.ProtectionKnown = true
+	default:
+		// Possibly a newer encryption scheme, but still most likely a keystore
+		result.HasPrivateKey = true
+	}
+
+	return
+}
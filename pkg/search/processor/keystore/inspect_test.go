@@ -0,0 +1,77 @@
+package keystore_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/processor/keystore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect_PuTTY(t *testing.T) {
+	plain := "PuTTY-User-Key-File-2: ssh-rsa\nEncryption: none\nComment: deploy\n"
+	encrypted := "PuTTY-User-Key-File-2: ssh-rsa\nEncryption: aes256-cbc\nComment: deploy\n"
+
+	// Fire
+	plainInspection := Inspect(PuTTY, plain)
+	encryptedInspection := Inspect(PuTTY, encrypted)
+
+	require.Equal(t, &Inspection{HasPrivateKey: true, Protected: false, ProtectionKnown: true}, plainInspection)
+	require.Equal(t, &Inspection{HasPrivateKey: true, Protected: true, ProtectionKnown: true}, encryptedInspection)
+	require.False(t, Inspect(PuTTY, "not a key").HasPrivateKey)
+}
+
+func TestInspect_JKS(t *testing.T) {
+	keystore := jks(t, func(buf *bytes.Buffer) {
+		writeUint32(t, buf, 1) // private key entry
+	})
+
+	// Fire
+	response := Inspect(JKS, keystore)
+
+	require.True(t, response.HasPrivateKey)
+	require.True(t, response.Protected)
+}
+
+func TestInspect_JKS_TrustStore(t *testing.T) {
+	trustStore := jks(t, func(buf *bytes.Buffer) {
+		writeUint32(t, buf, 2) // trusted certificate entry
+		writeUTF(t, buf, "ca")
+		buf.Write(make([]byte, 8))
+		writeUTF(t, buf, "X.509")
+		writeUint32(t, buf, 3)
+		buf.Write([]byte{1, 2, 3})
+	})
+
+	// Fire
+	response := Inspect(JKS, trustStore)
+
+	require.False(t, response.HasPrivateKey)
+}
+
+func TestInspect_PKCS12_NotDER(t *testing.T) {
+
+	// Fire
+	response := Inspect(PKCS12, "hello")
+
+	require.False(t, response.HasPrivateKey)
+}
+
+func jks(t *testing.T, writeEntry func(buf *bytes.Buffer)) string {
+	buf := bytes.NewBuffer(nil)
+	writeUint32(t, buf, 0xFEEDFEED)
+	writeUint32(t, buf, 2)
+	writeUint32(t, buf, 1)
+	writeEntry(buf)
+	return buf.String()
+}
+
+func writeUint32(t *testing.T, buf *bytes.Buffer, value uint32) {
+	require.NoError(t, binary.Write(buf, binary.BigEndian, value))
+}
+
+func writeUTF(t *testing.T, buf *bytes.Buffer, value string) {
+	require.NoError(t, binary.Write(buf, binary.BigEndian, uint16(len(value))))
+	buf.WriteString(value)
+}
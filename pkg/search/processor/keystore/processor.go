@@ -0,0 +1,106 @@
+package keystore
+
+import (
+	"encoding/base64"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/processor/pem"
+)
+
+const base64LineLen = 76
+
+type Processor struct {
+	name   string
+	format Format
+	pathRe *regexp.Regexp
+	log    logg.Logg
+}
+
+func NewProcessor(name string, format Format, log logg.Logg) *Processor {
+	return &Processor{
+		name:   name,
+		format: format,
+		pathRe: regexp.MustCompile(format.PathMatchString()),
+		log:    log,
+	}
+}
+
+func (p *Processor) GetName() string {
+	return p.name
+}
+
+// Binary keystores have no diff, so we have to ask for them
+func (p *Processor) AcceptsBinaryFileChange(fileChange *git.FileChange) bool {
+	return p.format.IsBinary() && p.pathRe.MatchString(fileChange.Path)
+}
+
+// The whole file is the secret
+func (p *Processor) FindResultsInFileChange(job contract.ProcessorJobI) (err error) {
+	fileChange := job.FileChange()
+	if !p.pathRe.MatchString(fileChange.Path) {
+		return
+	}
+
+	var contents string
+	if contents, err = fileChange.FileContents(); err != nil {
+		return
+	}
+
+	inspection := Inspect(p.format, contents)
+	if !inspection.HasPrivateKey {
+		job.Log(p.log).Debugf("no private keys in %s file", p.format.String())
+		return
+	}
+
+	var findingExtras []*contract.ResultExtra
+	if inspection.ProtectionKnown {
+		findingExtras = append(findingExtras, pem.ProtectedExtra(inspection.Protected))
+	}
+
+	result := &contract.Result{
+		FindingExtras: findingExtras,
+		FileBasename:  path.Base(fileChange.Path),
+	}
+	if p.format.IsBinary() {
+		result.SecretValue = encodeBinary(contents)
+		result.FileBasename += ".base64"
+		result.FileRange = &manip.FileRange{StartLineNum: 1, EndLineNum: 1}
+	} else {
+		result.SecretValue = strings.TrimRight(contents, "\n")
+		result.FileRange = wholeFileRange(result.SecretValue)
+	}
+
+	job.SearchingLine(1)
+	job.SubmitResult(result)
+
+	return
+}
+
+func encodeBinary(contents string) string {
+	encoded := base64.StdEncoding.EncodeToString([]byte(contents))
+
+	var lines []string
+	for len(encoded) > base64LineLen {
+		lines = append(lines, encoded[:base64LineLen])
+		encoded = encoded[base64LineLen:]
+	}
+	lines = append(lines, encoded)
+
+	return strings.Join(lines, "\n")
+}
+
+func wholeFileRange(contents string) *manip.FileRange {
+	lines := strings.Split(contents, "\n")
+	return &manip.FileRange{
+		StartLineNum: 1,
+		StartIndex:   0,
+		EndLineNum:   len(lines),
+		EndIndex:     len(lines[len(lines)-1]),
+	}
+}
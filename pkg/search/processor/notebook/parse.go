@@ -0,0 +1,194 @@
+package notebook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+)
+
+var (
+	cellKeyRegex    = regexp.MustCompile(`"cell_type"\s*:`)
+	sourceKeyRegex  = regexp.MustCompile(`"(?:source|input)"\s*:`)
+	outputsKeyRegex = regexp.MustCompile(`"outputs"\s*:`)
+)
+
+type (
+	Notebook struct {
+		Sections []*Section
+		contents string
+	}
+
+	// A cell's source, or one of its text outputs
+	Section struct {
+		Name     string
+		Contents string
+		Lines    []*Line
+	}
+
+	// A line of a section and where its JSON-escaped form starts in the file, or -1
+	Line struct {
+		Value   string
+		LineNum int
+		offset  int
+	}
+
+	nbFormat struct {
+		Cells      []*nbCell `json:"cells"`
+		Worksheets []struct {
+			Cells []*nbCell `json:"cells"`
+		} `json:"worksheets"`
+	}
+	nbCell struct {
+		Source  multiString `json:"source"`
+		Input   multiString `json:"input"`
+		Outputs []*nbOutput `json:"outputs"`
+	}
+	nbOutput struct {
+		Text multiString            `json:"text"`
+		Data map[string]multiString `json:"data"`
+	}
+
+	// Notebook text is a string or a list of lines
+	multiString string
+)
+
+func (m *multiString) UnmarshalJSON(data []byte) (err error) {
+	var lines []string
+	if err = json.Unmarshal(data, &lines); err == nil {
+		*m = multiString(strings.Join(lines, ""))
+		return
+	}
+
+	var value string
+	if err = json.Unmarshal(data, &value); err != nil {
+		return
+	}
+	*m = multiString(value)
+
+	return
+}
+
+func Parse(contents string) (result *Notebook, err error) {
+	var nb nbFormat
+	if err = json.Unmarshal([]byte(contents), &nb); err != nil {
+		return
+	}
+
+	// nbformat 3 keeps cells in worksheets
+	cells := nb.Cells
+	for _, worksheet := range nb.Worksheets {
+		cells = append(cells, worksheet.Cells...)
+	}
+
+	result = &Notebook{contents: contents}
+	cellOffsets := cellKeyRegex.FindAllStringIndex(contents, -1)
+
+	for i, cell := range cells {
+		cellName := fmt.Sprintf("cell-%d", i+1)
+
+		// Each cell is searched between its key and the next cell's, when they line up
+		spanStart, spanEnd := 0, len(contents)
+		if len(cellOffsets) == len(cells) {
+			spanStart = cellOffsets[i][0]
+			if i+1 < len(cellOffsets) {
+				spanEnd = cellOffsets[i+1][0]
+			}
+		}
+
+		source := cell.Source
+		if source == "" {
+			source = cell.Input
+		}
+		sourceStart := keyOffset(contents, sourceKeyRegex, spanStart, spanEnd)
+		result.addSection(cellName, string(source), sourceStart, spanEnd)
+
+		cursor := keyOffset(contents, outputsKeyRegex, spanStart, spanEnd)
+		for j, output := range cell.Outputs {
+			text := output.Text
+			if text == "" {
+				text = output.Data["text/plain"]
+			}
+			cursor = result.addSection(fmt.Sprintf("%s-output-%d", cellName, j+1), string(text), cursor, spanEnd)
+		}
+	}
+
+	return
+}
+
+// Lines are found in the file in order, starting at the cursor, which is returned advanced
+func (n *Notebook) addSection(name, contents string, cursor, end int) int {
+	if strings.TrimSpace(contents) == "" {
+		return cursor
+	}
+
+	section := &Section{Name: name, Contents: contents}
+
+	for i, value := range strings.Split(strings.TrimSuffix(contents, "\n"), "\n") {
+		line := &Line{Value: value, LineNum: i + 1, offset: -1}
+		section.Lines = append(section.Lines, line)
+		if value == "" {
+			continue
+		}
+
+		escaped := escape(value)
+		if index := strings.Index(n.contents[cursor:end], escaped); index != -1 {
+			line.offset = cursor + index
+			cursor = line.offset + len(escaped)
+		}
+	}
+
+	n.Sections = append(n.Sections, section)
+
+	return cursor
+}
+
+// Whether the line's JSON-escaped form was found in the notebook file
+func (l *Line) Located() bool {
+	return l.offset != -1
+}
+
+// Where a range of a located line is in the notebook file
+func (n *Notebook) FileRange(line *Line, lineRange *manip.LineRange) *manip.FileRange {
+	lineNum, index := n.position(line.offset)
+	return &manip.FileRange{
+		StartLineNum: lineNum,
+		StartIndex:   index + len(escape(line.Value[:lineRange.StartIndex])),
+		EndLineNum:   lineNum,
+		EndIndex:     index + len(escape(line.Value[:lineRange.EndIndex])),
+	}
+}
+
+// The line of the notebook file that a located line is in
+func (n *Notebook) LineNum(line *Line) (result int) {
+	result, _ = n.position(line.offset)
+	return
+}
+
+func (n *Notebook) position(offset int) (lineNum, index int) {
+	lineStart := strings.LastIndex(n.contents[:offset], "\n") + 1
+	return strings.Count(n.contents[:offset], "\n") + 1, offset - lineStart
+}
+
+func keyOffset(contents string, keyRegex *regexp.Regexp, start, end int) int {
+	if match := keyRegex.FindStringIndex(contents[start:end]); match != nil {
+		return start + match[1]
+	}
+	return start
+}
+
+// The JSON string form, without the quotes
+func escape(value string) string {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return value
+	}
+	encoded := strings.TrimSpace(buf.String())
+
+	return encoded[1 : len(encoded)-1]
+}
@@ -0,0 +1,73 @@
+package notebook_test
+
+import (
+	"testing"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/processor/notebook"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	contents := `{
+ "cells": [
+  {
+   "cell_type": "markdown",
+   "metadata": {},
+   "source": ["# Setup"]
+  },
+  {
+   "cell_type": "code",
+   "execution_count": 1,
+   "metadata": {},
+   "outputs": [
+    {
+     "name": "stdout",
+     "output_type": "stream",
+     "text": ["connected as admin\n"]
+    }
+   ],
+   "source": [
+    "import db\n",
+    "db.connect(password=\"s3cret\")"
+   ]
+  }
+ ],
+ "nbformat": 4
+}`
+
+	// Fire
+	notebook, err := Parse(contents)
+
+	require.NoError(t, err)
+	require.Len(t, notebook.Sections, 3)
+
+	require.Equal(t, "cell-1", notebook.Sections[0].Name)
+	require.Equal(t, "cell-2", notebook.Sections[1].Name)
+	require.Equal(t, "import db\ndb.connect(password=\"s3cret\")", notebook.Sections[1].Contents)
+	require.Equal(t, "cell-2-output-1", notebook.Sections[2].Name)
+	require.Equal(t, "connected as admin\n", notebook.Sections[2].Contents)
+
+	// "s3cret" in the second line of the cell, escaped in the file
+	line := notebook.Sections[1].Lines[1]
+	require.Equal(t, 2, line.LineNum)
+	require.Equal(t, 21, notebook.LineNum(line))
+	require.Equal(t, &manip.FileRange{StartLineNum: 21, StartIndex: 27, EndLineNum: 21, EndIndex: 33},
+		notebook.FileRange(line, manip.NewLineRange(21, 27)))
+}
+
+func TestParse_SingleLineCells(t *testing.T) {
+	contents := `{"cells":[{"cell_type":"code","outputs":[],"source":"x = 1\ntoken = 'abc'"},` +
+		`{"cell_type":"code","outputs":[],"source":"token = 'abc'"}],"nbformat":4}`
+
+	// Fire
+	notebook, err := Parse(contents)
+
+	require.NoError(t, err)
+	require.Len(t, notebook.Sections, 2)
+
+	// The same line in the second cell is found in the second cell
+	line := notebook.Sections[1].Lines[0]
+	require.Equal(t, &manip.FileRange{StartLineNum: 1, StartIndex: 128, EndLineNum: 1, EndIndex: 131},
+		notebook.FileRange(line, manip.NewLineRange(9, 12)))
+}
@@ -0,0 +1,144 @@
+package notebook
+
+import (
+	"regexp"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	gitpkg "github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+)
+
+var notebookPathRegex = regexp.MustCompile(`(?i)\.ipynb$`)
+
+// Runs the line processors on each cell's source and text outputs, so results are
+// reported by cell and line instead of by a line of JSON
+type Processor struct {
+	name      string
+	lineProcs []contract.LineProcessorI
+	log       logg.Logg
+}
+
+func NewProcessor(name string, lineProcs []contract.LineProcessorI, log logg.Logg) *Processor {
+	return &Processor{
+		name:      name,
+		lineProcs: lineProcs,
+		log:       log,
+	}
+}
+
+func (p *Processor) GetName() string {
+	return p.name
+}
+
+func (p *Processor) FindResultsInFileChange(job contract.ProcessorJobI) (err error) {
+	return p.FindResultsInPrefilteredFileChange(job, nil)
+}
+
+// Lines is optional, every cell line is searched when it's nil. Cell lines are filtered
+// by the line of JSON they're in.
+func (p *Processor) FindResultsInPrefilteredFileChange(job contract.ProcessorJobI, lines func(lineProcName string) (lineNums map[int]bool, filtered bool)) (err error) {
+	fileChange := job.FileChange()
+	if !notebookPathRegex.MatchString(fileChange.Path) {
+		return
+	}
+
+	var contents string
+	if contents, err = fileChange.FileContents(); err != nil {
+		return
+	}
+
+	notebook, parseErr := Parse(contents)
+	if parseErr != nil {
+		job.Log(p.log).WithError(parseErr).Debug("skipping notebook that can't be parsed")
+		return
+	}
+
+	added := job.Diff().AddedLineNums()
+
+	// Results are attributed to the line processor that found them
+	for _, lineProc := range p.lineProcs {
+		job.SearchingWithProcessor(lineProc)
+
+		var lineNums map[int]bool
+		var filtered bool
+		if lines != nil {
+			lineNums, filtered = lines(lineProc.GetName())
+		}
+		if filtered && len(lineNums) == 0 {
+			continue
+		}
+
+		for _, section := range notebook.Sections {
+			for _, line := range section.Lines {
+
+				// Lines that can't be found in the file are left to the processors that search the JSON
+				if line.Value == "" || !line.Located() {
+					continue
+				}
+				lineNum := notebook.LineNum(line)
+				if !added[lineNum] || (filtered && !lineNums[lineNum]) {
+					continue
+				}
+				if job.DeadlineExceeded() {
+					return contract.ErrDeadlineExceeded
+				}
+
+				job.SearchingLine(lineNum)
+				lineJob := &cellLineJob{job: job, notebook: notebook, section: section, line: line}
+				if err = lineProc.FindResultsInLine(lineJob, line.Value); err != nil {
+					err = errors.WithMessagev(err, "unable to find in notebook line using processor", lineProc.GetName())
+					return
+				}
+			}
+		}
+	}
+
+	return
+}
+
+//
+// Line job
+
+// Converts ranges in a cell's line to ranges in the notebook file
+type cellLineJob struct {
+	job      contract.ProcessorJobI
+	notebook *Notebook
+	section  *Section
+	line     *Line
+}
+
+func (c *cellLineJob) SubmitLineRangeIgnore(lineRange *manip.LineRange) {
+	c.job.SubmitIgnore(c.notebook.FileRange(c.line, lineRange))
+}
+
+func (c *cellLineJob) SubmitLineResult(lineResult *contract.LineResult) {
+	c.job.SubmitResult(&contract.Result{
+		FileRange:     c.notebook.FileRange(c.line, lineResult.LineRange),
+		SecretValue:   lineResult.SecretValue,
+		SecretExtras:  lineResult.SecretExtras,
+		FindingExtras: lineResult.FindingExtras,
+		Section: &contract.ResultSection{
+			Name:      c.section.Name,
+			Contents:  c.section.Contents,
+			FileRange: manip.NewFileRangeFromLineRange(lineResult.LineRange, c.line.LineNum),
+		},
+	})
+}
+
+func (c *cellLineJob) FileChange() (fileChange *gitpkg.FileChange) {
+	return c.job.FileChange()
+}
+
+func (c *cellLineJob) Diff() (result *gitpkg.Diff) {
+	return c.job.Diff()
+}
+
+func (c *cellLineJob) Line() int {
+	return c.job.Line()
+}
+
+func (c *cellLineJob) Log(prefixLog logg.Logg) (result logg.Logg) {
+	return c.job.Log(prefixLog)
+}
@@ -0,0 +1,125 @@
+package pem
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"regexp"
+	"strings"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	"github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	"golang.org/x/crypto/ssh"
+)
+
+const CertificateCollectorName = "CertificateCollector"
+
+var (
+	certificateHeader = "-----BEGIN " + Certificate + "-----"
+
+	// Certificates in code are often a single line with escaped newlines
+	escapedCertificateRe = regexp.MustCompile(`-----BEGIN CERTIFICATE-----(?:\\n|[^-])+-----END CERTIFICATE-----`)
+)
+
+type (
+	// Where collected certificates go, so the reporter can pair them with leaked keys
+	CertificateWriter interface {
+		WriteCertificateIfNotExists(obj *database.Certificate) (created bool, err error)
+	}
+
+	// Doesn't report anything itself, it just remembers every certificate it sees
+	CertificateCollector struct {
+		writer CertificateWriter
+		log    logg.Logg
+	}
+)
+
+func NewCertificateCollector(writer CertificateWriter, log logg.Logg) *CertificateCollector {
+	return &CertificateCollector{
+		writer: writer,
+		log:    log,
+	}
+}
+
+func (c *CertificateCollector) GetName() string {
+	return CertificateCollectorName
+}
+
+// Certificates are written as they're found, and the search tables are reset every run
+func (c *CertificateCollector) RunsUncached() bool {
+	return true
+}
+
+func (c *CertificateCollector) FindResultsInFileChange(job contract.ProcessorJobI) (err error) {
+	fileChange := job.FileChange()
+	if !c.addsCertificate(fileChange) {
+		return
+	}
+
+	var fileContents string
+	if fileContents, err = fileChange.FileContents(); err != nil {
+		return
+	}
+
+	for _, match := range escapedCertificateRe.FindAllString(fileContents, -1) {
+		certString := strings.Replace(match, `\n`, "\n", -1)
+		block, _ := pem.Decode([]byte(certString))
+		if block == nil {
+			continue
+		}
+
+		cert, parseErr := x509.ParseCertificate(block.Bytes)
+		if parseErr != nil {
+			job.Log(c.log).WithError(parseErr).Debug("unable to parse certificate")
+			continue
+		}
+
+		if err = c.writeCertificate(job, cert); err != nil {
+			err = errors.WithMessage(err, "unable to write certificate")
+			return
+		}
+	}
+
+	return
+}
+
+// Deleted lines don't count, the certificate was already seen when it was added
+func (c *CertificateCollector) addsCertificate(fileChange *git.FileChange) bool {
+	for _, chunk := range fileChange.Chunks {
+		if chunk.Operation != git.Delete && strings.Contains(chunk.Content, certificateHeader) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CertificateCollector) writeCertificate(job contract.ProcessorJobI, cert *x509.Certificate) (err error) {
+	sshPubKey, err := ssh.NewPublicKey(cert.PublicKey)
+	if err != nil {
+		job.Log(c.log).WithError(err).Debug("unsupported certificate public key")
+		return nil
+	}
+
+	sans := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+
+	_, err = c.writer.WriteCertificateIfNotExists(&database.Certificate{
+		ID:          database.CreateHashID(string(cert.Raw)),
+		Fingerprint: ssh.FingerprintSHA256(sshPubKey),
+		Subject:     cert.Subject.String(),
+		SANs:        sans,
+		Issuer:      cert.Issuer.String(),
+		NotBefore:   cert.NotBefore,
+		NotAfter:    cert.NotAfter,
+		RepoName:    job.RepoName(),
+		CommitHash:  job.Commit().Hash,
+		Path:        job.FileChange().Path,
+	})
+
+	return
+}
@@ -0,0 +1,146 @@
+package pem_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/dev"
+	"github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/processor/pem"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	gitvendor "gopkg.in/src-d/go-git.v4"
+	gitobject "gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func TestCertificateCollector_FindResultsInFileChange(t *testing.T) {
+	dev.Params = &dev.Parameters{}
+	now := time.Now()
+	apiKey, apiCert := certificate(t, "api.example.com", now.Add(time.Hour))
+	_, oldCert := certificate(t, "old.example.com", now.Add(-time.Hour))
+	escapedAPICert := strings.Replace(strings.TrimSpace(apiCert), "\n", `\n`, -1)
+	apiFingerprint := fingerprint(t, &apiKey.PublicKey)
+
+	for name, tt := range map[string]struct {
+		commits []map[string]string
+
+		// Subjects and the commit each certificate was first seen in
+		expCommits map[string]int
+	}{
+		"pem file": {
+			commits:    []map[string]string{{"api.crt": apiCert}},
+			expCommits: map[string]int{"CN=api.example.com": 0},
+		},
+		"escaped in code": {
+			commits:    []map[string]string{{"config.js": `const ca = "` + escapedAPICert + `";` + "\n"}},
+			expCommits: map[string]int{"CN=api.example.com": 0},
+		},
+		"expired": {
+			commits:    []map[string]string{{"old.crt": oldCert}},
+			expCommits: map[string]int{"CN=old.example.com": 0},
+		},
+		"seen in two commits": {
+			commits:    []map[string]string{{"api.crt": apiCert}, {"copy/api.crt": apiCert, "old.crt": oldCert}},
+			expCommits: map[string]int{"CN=api.example.com": 0, "CN=old.example.com": 1},
+		},
+		"no certificate": {
+			commits:    []map[string]string{{"README.md": "-----BEGIN CERTIFICATE-----\nnot base64\n-----END CERTIFICATE-----\n"}},
+			expCommits: map[string]int{},
+		},
+	} {
+		dir, cleanup := tempDir(t)
+		db, err := database.New(filepath.Join(dir, "db"), log)
+		require.NoError(t, err)
+		repository, commitHashes := testRepo(t, filepath.Join(dir, "repo"), tt.commits)
+		subject := NewCertificateCollector(db, log)
+		worker := search.NewWorker([]contract.ProcessorI{subject}, nil, nil, nil, nil, nil, log)
+		job := search.NewJob("job", "repoID", "repo", repository, commitHashes, commitHashes[0], false, nil, log, nil)
+
+		// Fire
+		require.True(t, worker.Do(context.Background(), job), name)
+
+		certs, err := db.GetCertificates()
+		require.NoError(t, err, name)
+		commits := map[string]int{}
+		for _, cert := range certs {
+			for i, commitHash := range commitHashes {
+				if cert.CommitHash == commitHash {
+					commits[cert.Subject] = i
+				}
+			}
+			require.Equal(t, "repo", cert.RepoName, name)
+			if cert.Subject == "CN=api.example.com" {
+				require.Equal(t, apiFingerprint, cert.Fingerprint, name)
+				require.True(t, cert.NotAfter.After(now), name)
+			} else {
+				require.True(t, cert.NotAfter.Before(now), name)
+			}
+		}
+		require.Equal(t, tt.expCommits, commits, name)
+		require.Empty(t, job.GetJobResults(), name)
+
+		cleanup()
+	}
+}
+
+// Self-signed, in PEM
+func certificate(t *testing.T, commonName string, notAfter time.Time) (key *ecdsa.PrivateKey, result string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	result = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	return
+}
+
+func fingerprint(t *testing.T, publicKey interface{}) string {
+	sshPubKey, err := ssh.NewPublicKey(publicKey)
+	require.NoError(t, err)
+	return ssh.FingerprintSHA256(sshPubKey)
+}
+
+// A repo with a commit for each set of files, oldest first
+func testRepo(t *testing.T, cloneDir string, commits []map[string]string) (repository *git.Repository, commitHashes []string) {
+	gitRepo, err := gitvendor.PlainInit(cloneDir, false)
+	require.NoError(t, err)
+	worktree, err := gitRepo.Worktree()
+	require.NoError(t, err)
+	for i, files := range commits {
+		for name, contents := range files {
+			writeFile(t, filepath.Join(cloneDir, name), contents)
+			_, err = worktree.Add(name)
+			require.NoError(t, err)
+		}
+		hash, err := worktree.Commit(fmt.Sprintf("commit %d", i), &gitvendor.CommitOptions{
+			Author: &gitobject.Signature{Name: "name", Email: "name@example.com", When: time.Now().Add(time.Duration(i) * time.Minute)},
+		})
+		require.NoError(t, err)
+		commitHashes = append(commitHashes, hash.String())
+	}
+
+	repository, err = git.New(nil, log).OpenRepository(cloneDir)
+	require.NoError(t, err)
+
+	return
+}
@@ -0,0 +1,407 @@
+package pem
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	HostKind    = "host"
+	ServiceKind = "service"
+)
+
+type (
+	// Known public keys, used to tell what a found private key grants access to
+	KeyInventory struct {
+		entries map[string][]*KeyInventoryEntry
+		log     logg.Logg
+	}
+	KeyInventoryEntry struct {
+		Fingerprint string
+		Kind        string
+		Name        string
+		Path        string
+	}
+	// A file or directory of authorized_keys files, PEM certificates/public keys, or JWKS JSON
+	KeyInventorySource struct {
+		Path  string
+		Label string
+	}
+
+	jwkSet struct {
+		Keys []*jwk `json:"keys"`
+	}
+	jwk struct {
+		Kty string   `json:"kty"`
+		Kid string   `json:"kid"`
+		Crv string   `json:"crv"`
+		N   string   `json:"n"`
+		E   string   `json:"e"`
+		X   string   `json:"x"`
+		Y   string   `json:"y"`
+		X5c []string `json:"x5c"`
+	}
+)
+
+func NewKeyInventory(log logg.Logg) *KeyInventory {
+	return &KeyInventory{
+		entries: map[string][]*KeyInventoryEntry{},
+		log:     log,
+	}
+}
+
+func LoadKeyInventory(sources []*KeyInventorySource, log logg.Logg) (result *KeyInventory, err error) {
+	result = NewKeyInventory(log)
+	for _, source := range sources {
+		if err = result.AddSource(source); err != nil {
+			err = errors.WithMessagev(err, "unable to load key inventory source", source.Path)
+			return
+		}
+	}
+
+	log.Debugf("loaded %d public keys into key inventory", result.Len())
+
+	return
+}
+
+// Files that can't be parsed are skipped so a directory can hold other things
+func (i *KeyInventory) AddSource(source *KeyInventorySource) (err error) {
+	return filepath.Walk(source.Path, func(path string, info os.FileInfo, walkErr error) (err error) {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !info.Mode().IsRegular() {
+			return
+		}
+
+		var contents []byte
+		if contents, err = ioutil.ReadFile(path); err != nil {
+			return errors.Wrapv(err, "unable to read key inventory file", path)
+		}
+
+		if added := i.addFile(path, contents, source.Label); added == 0 {
+			i.log.WithField("path", path).Debug("no public keys found in key inventory file")
+		}
+
+		return
+	})
+}
+
+func (i *KeyInventory) Add(entry *KeyInventoryEntry) {
+	i.entries[entry.Fingerprint] = append(i.entries[entry.Fingerprint], entry)
+}
+
+func (i *KeyInventory) Len() (result int) {
+	for _, entries := range i.entries {
+		result += len(entries)
+	}
+	return
+}
+
+// Entries with the same SHA256 fingerprint as the private key's public key
+func (i *KeyInventory) Match(privateKey crypto.PrivateKey) (result []*KeyInventoryEntry) {
+	sshPubKey, err := sshPublicKeyFromPrivate(privateKey)
+	if err != nil {
+		return
+	}
+
+	return i.MatchPublicKey(sshPubKey)
+}
+
+func (i *KeyInventory) MatchPublicKey(sshPubKey ssh.PublicKey) (result []*KeyInventoryEntry) {
+	if i == nil {
+		return
+	}
+
+	return i.entries[ssh.FingerprintSHA256(sshPubKey)]
+}
+
+func (e *KeyInventoryEntry) String() string {
+	return fmt.Sprintf("%s %s (%s)", e.Kind, e.Name, e.Path)
+}
+
+func (i *KeyInventory) addFile(path string, contents []byte, label string) (added int) {
+	trimmed := bytes.TrimSpace(contents)
+
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		added = i.addJWKS(path, trimmed, label)
+	case bytes.Contains(trimmed, []byte("-----BEGIN ")):
+		added = i.addPEM(path, trimmed, label)
+	default:
+		added = i.addAuthorizedKeys(path, trimmed, label)
+	}
+
+	return
+}
+
+//
+// authorized_keys
+
+func (i *KeyInventory) addAuthorizedKeys(path string, contents []byte, label string) (added int) {
+	name := label
+	if name == "" {
+		name = hostNameFromPath(path)
+	}
+
+	rest := contents
+	for len(rest) > 0 {
+		var pubKey ssh.PublicKey
+		var err error
+		pubKey, _, _, rest, err = ssh.ParseAuthorizedKey(rest)
+		if err != nil {
+			break
+		}
+
+		i.Add(&KeyInventoryEntry{
+			Fingerprint: ssh.FingerprintSHA256(pubKey),
+			Kind:        HostKind,
+			Name:        name,
+			Path:        path,
+		})
+		added++
+	}
+
+	return
+}
+
+// "hosts/web1/authorized_keys" is web1, "hosts/web1.pub" is too
+func hostNameFromPath(path string) string {
+	base := filepath.Base(path)
+	if strings.HasPrefix(base, "authorized_keys") {
+		return filepath.Base(filepath.Dir(path))
+	}
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+//
+// PEM certificates and public keys
+
+func (i *KeyInventory) addPEM(path string, contents []byte, label string) (added int) {
+	rest := contents
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		var pubKey crypto.PublicKey
+		name := label
+
+		switch block.Type {
+		case Certificate:
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				i.log.WithError(err).WithField("path", path).Debug("unable to parse certificate in key inventory")
+				continue
+			}
+			pubKey = cert.PublicKey
+			if name == "" {
+				name = certificateName(cert)
+			}
+		case "PUBLIC KEY":
+			var err error
+			if pubKey, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+				i.log.WithError(err).WithField("path", path).Debug("unable to parse public key in key inventory")
+				continue
+			}
+		case "RSA PUBLIC KEY":
+			var err error
+			if pubKey, err = x509.ParsePKCS1PublicKey(block.Bytes); err != nil {
+				i.log.WithError(err).WithField("path", path).Debug("unable to parse public key in key inventory")
+				continue
+			}
+		default:
+			continue
+		}
+
+		if name == "" {
+			name = fileStem(path)
+		}
+		if i.addPublicKey(pubKey, ServiceKind, name, path) {
+			added++
+		}
+	}
+
+	return
+}
+
+func certificateName(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return ""
+}
+
+//
+// JWKS
+
+func (i *KeyInventory) addJWKS(path string, contents []byte, label string) (added int) {
+	var set jwkSet
+	if err := json.Unmarshal(contents, &set); err != nil {
+		i.log.WithError(err).WithField("path", path).Debug("unable to parse JSON in key inventory")
+		return
+	}
+
+	// A lone JWK rather than a set
+	if set.Keys == nil {
+		var key jwk
+		if err := json.Unmarshal(contents, &key); err == nil && key.Kty != "" {
+			set.Keys = []*jwk{&key}
+		}
+	}
+
+	for _, key := range set.Keys {
+		pubKey, err := key.publicKey()
+		if err != nil {
+			i.log.WithError(err).WithField("path", path).WithField("kid", key.Kid).Debug("unable to parse JWK in key inventory")
+			continue
+		}
+
+		name := label
+		if name == "" {
+			name = fileStem(path)
+		}
+		if key.Kid != "" {
+			name += " key " + key.Kid
+		}
+
+		if i.addPublicKey(pubKey, ServiceKind, name, path) {
+			added++
+		}
+	}
+
+	return
+}
+
+func (k *jwk) publicKey() (result crypto.PublicKey, err error) {
+	if len(k.X5c) > 0 {
+		var der []byte
+		if der, err = base64.StdEncoding.DecodeString(k.X5c[0]); err != nil {
+			err = errors.Wrap(err, "unable to decode x5c certificate")
+			return
+		}
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(der); err != nil {
+			err = errors.Wrap(err, "unable to parse x5c certificate")
+			return
+		}
+		result = cert.PublicKey
+		return
+	}
+
+	switch k.Kty {
+	case "RSA":
+		var n, e *big.Int
+		if n, err = jwkInt(k.N); err != nil {
+			return
+		}
+		if e, err = jwkInt(k.E); err != nil {
+			return
+		}
+		result = &rsa.PublicKey{N: n, E: int(e.Int64())}
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			err = errors.Errorv("unsupported JWK curve", k.Crv)
+			return
+		}
+		var x, y *big.Int
+		if x, err = jwkInt(k.X); err != nil {
+			return
+		}
+		if y, err = jwkInt(k.Y); err != nil {
+			return
+		}
+		result = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			err = errors.Errorv("unsupported JWK curve", k.Crv)
+			return
+		}
+		var x []byte
+		if x, err = base64.RawURLEncoding.DecodeString(k.X); err != nil {
+			err = errors.Wrap(err, "unable to decode JWK parameter")
+			return
+		}
+		result = ed25519.PublicKey(x)
+	default:
+		err = errors.Errorv("unsupported JWK key type", k.Kty)
+	}
+
+	return
+}
+
+func jwkInt(value string) (result *big.Int, err error) {
+	var decoded []byte
+	if decoded, err = base64.RawURLEncoding.DecodeString(value); err != nil {
+		err = errors.Wrap(err, "unable to decode JWK parameter")
+		return
+	}
+	result = new(big.Int).SetBytes(decoded)
+	return
+}
+
+//
+// Helpers
+
+func (i *KeyInventory) addPublicKey(pubKey crypto.PublicKey, kind, name, path string) (added bool) {
+	sshPubKey, err := ssh.NewPublicKey(pubKey)
+	if err != nil {
+		i.log.WithError(err).WithField("path", path).Debug("unsupported public key in key inventory")
+		return
+	}
+
+	i.Add(&KeyInventoryEntry{
+		Fingerprint: ssh.FingerprintSHA256(sshPubKey),
+		Kind:        kind,
+		Name:        name,
+		Path:        path,
+	})
+
+	return true
+}
+
+func sshPublicKeyFromPrivate(privateKey crypto.PrivateKey) (result ssh.PublicKey, err error) {
+	var signer ssh.Signer
+	if signer, err = ssh.NewSignerFromKey(privateKey); err != nil {
+		err = errors.Wrap(err, "unable to derive public key")
+		return
+	}
+	result = signer.PublicKey()
+	return
+}
+
+func fileStem(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,104 @@
+package pem_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/processor/pem"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+var log = logg.NewLogrusLogg(logrus.New())
+
+func TestLoadKeyInventory_AuthorizedKeys(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	hostKey := rsaKey(t)
+	sshPubKey, err := ssh.NewPublicKey(&hostKey.PublicKey)
+	require.NoError(t, err)
+	writeFile(t, filepath.Join(dir, "web1", "authorized_keys"), "# deploy\n"+string(ssh.MarshalAuthorizedKey(sshPubKey)))
+
+	// Fire
+	subject, err := LoadKeyInventory([]*KeyInventorySource{{Path: dir}}, log)
+
+	require.NoError(t, err)
+	entries := subject.Match(hostKey)
+	require.Len(t, entries, 1)
+	require.Equal(t, HostKind, entries[0].Kind)
+	require.Equal(t, "web1", entries[0].Name)
+	require.Empty(t, subject.Match(rsaKey(t)))
+}
+
+func TestLoadKeyInventory_Certificate(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	serviceKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "api.example.com"},
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &serviceKey.PublicKey, serviceKey)
+	require.NoError(t, err)
+	certPath := filepath.Join(dir, "api.crt")
+	writeFile(t, certPath, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+
+	// Fire
+	subject, err := LoadKeyInventory([]*KeyInventorySource{{Path: certPath}}, log)
+
+	require.NoError(t, err)
+	entries := subject.Match(serviceKey)
+	require.Len(t, entries, 1)
+	require.Equal(t, "service api.example.com ("+certPath+")", entries[0].String())
+}
+
+func TestLoadKeyInventory_JWKS(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	signingKey := rsaKey(t)
+	n := base64.RawURLEncoding.EncodeToString(signingKey.N.Bytes())
+	writeFile(t, filepath.Join(dir, "auth.json"), fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"2020-06","n":"%s","e":"AQAB"}]}`, n))
+
+	// Fire
+	subject, err := LoadKeyInventory([]*KeyInventorySource{{Path: dir, Label: "auth-service"}}, log)
+
+	require.NoError(t, err)
+	entries := subject.Match(signingKey)
+	require.Len(t, entries, 1)
+	require.Equal(t, ServiceKind, entries[0].Kind)
+	require.Equal(t, "auth-service key 2020-06", entries[0].Name)
+}
+
+func tempDir(t *testing.T) (dir string, cleanup func()) {
+	dir, err := ioutil.TempDir("", "key-inventory-test")
+	require.NoError(t, err)
+	return dir, func() { _ = os.RemoveAll(dir) }
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0600))
+}
+
+func rsaKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	return key
+}
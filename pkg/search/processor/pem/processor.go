@@ -25,8 +25,12 @@ import (
 )
 
 const (
-	RSAPrivateKey = "RSA PRIVATE KEY"
-	Certificate   = "CERTIFICATE"
+	RSAPrivateKey       = "RSA PRIVATE KEY"
+	EncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+	Certificate         = "CERTIFICATE"
+
+	FingerprintExtraKey = "public-key-fingerprint"
+	ProtectedExtraKey   = "passphrase-protected"
 
 	// Right now, only added or equal lines (rotation) are cared about. This can change if needed.
 	skipDeletedHeaders = true
@@ -46,6 +50,7 @@ type (
 		oneLineKeyTooPermissiveRe *regexp.Regexp
 		oneLineEscapedStringKeyRe *regexp.Regexp
 		codeWhitelist             *search.CodeWhitelist
+		inventory                 *KeyInventory
 		log                       logg.Logg
 	}
 	searchRule struct {
@@ -54,7 +59,7 @@ type (
 	}
 )
 
-func NewProcessor(name string, pemType string, log logg.Logg) (result *Processor) {
+func NewProcessor(name string, pemType string, inventory *KeyInventory, log logg.Logg) (result *Processor) {
 	header := fmt.Sprintf("-----BEGIN %s-----", pemType)
 	footer := fmt.Sprintf("-----END %s-----", pemType)
 
@@ -80,6 +85,7 @@ func NewProcessor(name string, pemType string, log logg.Logg) (result *Processor
 		oneLineKeyTooPermissiveRe: oneLineKeyTooPermissiveRe,
 		oneLineEscapedStringKeyRe: oneLineEscapedStringKeyRe,
 		codeWhitelist:             codeWhitelist,
+		inventory:                 inventory,
 		log:                       log,
 	}
 }
@@ -138,8 +144,8 @@ func (p *Processor) potentialFinding(job contract.ProcessorJobI, keyString strin
 }
 
 func (p *Processor) buildKeyFinding(job contract.ProcessorJobI, keyString string, fileRange *manip.FileRange, isKeyFile bool) (parsed bool, err error) {
-	switch p.pemType {
-	case RSAPrivateKey:
+	switch {
+	case p.pemType == RSAPrivateKey && !isEncryptedPEMString(keyString):
 		return p.buildRSAPrivateKeyFinding(job, keyString, fileRange, isKeyFile)
 	default:
 		return p.buildGeneralKeyFinding(job, keyString, fileRange)
@@ -147,26 +153,42 @@ func (p *Processor) buildKeyFinding(job contract.ProcessorJobI, keyString string
 }
 
 func (p *Processor) buildGeneralKeyFinding(job contract.ProcessorJobI, keyString string, fileRange *manip.FileRange) (parsed bool, err error) {
-	_, err = p.parseX509PEMString(job, keyString)
+	var privateKey crypto.PrivateKey
+	var publicKey ssh.PublicKey
+	var protected bool
+	privateKey, publicKey, protected, err = p.parseX509PEMString(job, keyString)
 	if err != nil {
 		err = errors.WithMessagev(err, "invalid x509 PEM key", keyString, job.Log(p.log))
 		return
 	}
 
+	// Whether it's protected is only known if we could parse it, or if it said it's encrypted
+	var findingExtras []*contract.ResultExtra
+	if privateKey != nil || protected {
+		findingExtras = append(findingExtras, ProtectedExtra(protected))
+	}
+
 	fileBasename := path.Base(job.FileChange().Path)
 
 	job.SubmitResult(&contract.Result{
-		FileRange:    fileRange,
-		SecretValue:  keyString,
-		FileBasename: fileBasename,
+		FileRange:     fileRange,
+		SecretValue:   keyString,
+		SecretExtras:  p.buildInventoryExtras(publicKey),
+		FindingExtras: findingExtras,
+		FileBasename:  fileBasename,
 	})
 	parsed = true
 
 	return
 }
 
-func (p *Processor) buildKeyFromBlockLines(keyLines []string) string {
-	return p.buildKey(strings.Join(keyLines, "\n"))
+// Legacy encrypted keys have headers like "Proc-Type: 4,ENCRYPTED", separated from the block by an empty line
+func (p *Processor) buildKeyFromBlockLines(headerLines, keyLines []string) string {
+	keyBlock := strings.Join(keyLines, "\n")
+	if len(headerLines) > 0 {
+		keyBlock = strings.Join(headerLines, "\n") + "\n\n" + keyBlock
+	}
+	return p.buildKey(keyBlock)
 }
 
 func (p *Processor) buildKey(keyBlock string) string {
@@ -189,6 +211,10 @@ func (p *Processor) buildRSAPrivateKeyFinding(job contract.ProcessorJobI, keyStr
 		Value:  p.publicKeyInfo(&key.PublicKey),
 		Code:   true,
 	})
+	if publicKey, pubKeyErr := sshPublicKeyFromPrivate(key); pubKeyErr == nil {
+		secretExtras = append(secretExtras, p.buildInventoryExtras(publicKey)...)
+	}
+	findingExtras = append(findingExtras, ProtectedExtra(false))
 
 	var keyPEMBlock *pem.Block
 	keyPEMBlock, err = p.decodePEMString(job, keyString)
@@ -230,6 +256,36 @@ func (p *Processor) buildRSAPrivateKeyFinding(job contract.ProcessorJobI, keyStr
 	return
 }
 
+// Fingerprint of the key's public half, and what it unlocks according to the key inventory
+func (p *Processor) buildInventoryExtras(publicKey ssh.PublicKey) (result []*contract.ResultExtra) {
+	if publicKey == nil {
+		return
+	}
+
+	result = append(result, &contract.ResultExtra{
+		Key:    FingerprintExtraKey,
+		Header: "Public key fingerprint",
+		Value:  ssh.FingerprintSHA256(publicKey),
+	})
+
+	entries := p.inventory.MatchPublicKey(publicKey)
+	if len(entries) == 0 {
+		return
+	}
+
+	unlocks := make([]string, len(entries))
+	for i, entry := range entries {
+		unlocks[i] = entry.String()
+	}
+	result = append(result, &contract.ResultExtra{
+		Key:    "unlocks",
+		Header: "Unlocks",
+		Value:  strings.Join(unlocks, "\n"),
+	})
+
+	return
+}
+
 func (p *Processor) buildBundledCertExtras(job contract.ProcessorJobI, keyPEMBlock *pem.Block) (result []*contract.ResultExtra, err error) {
 	var cert *x509.Certificate
 	var certPEMBlock *pem.Block
@@ -439,7 +495,9 @@ func (p *Processor) findCertContents(job contract.ProcessorJobI) (result, certPa
 	return
 }
 
-func (p *Processor) parseX509PEMString(job contract.ProcessorJobI, keyString string) (result crypto.PrivateKey, err error) {
+// The key is nil if it can't be parsed. Encrypted keys can't be without the passphrase, but some formats
+// still have the public key in the clear.
+func (p *Processor) parseX509PEMString(job contract.ProcessorJobI, keyString string) (result crypto.PrivateKey, publicKey ssh.PublicKey, protected bool, err error) {
 	var block *pem.Block
 	block, err = p.decodePEMString(job, keyString)
 	if err != nil {
@@ -452,8 +510,11 @@ func (p *Processor) parseX509PEMString(job contract.ProcessorJobI, keyString str
 		return
 	}
 
-	switch block.Type {
-	case RSAPrivateKey:
+	switch {
+	case block.Type == EncryptedPrivateKey || isEncryptedPEMBlock(block):
+		protected = true
+		return
+	case block.Type == RSAPrivateKey:
 		var rsaKey *rsa.PrivateKey
 		rsaKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
 		if err != nil {
@@ -466,7 +527,23 @@ func (p *Processor) parseX509PEMString(job contract.ProcessorJobI, keyString str
 		}
 		result = rsaKey
 	default:
-		job.Log(p.log).Warnf("unsupported block type: %s", block.Type)
+		// Not being able to parse other key types doesn't make them any less of a secret
+		rawKey, parseErr := ssh.ParseRawPrivateKey([]byte(keyString))
+		if passphraseErr, ok := parseErr.(*ssh.PassphraseMissingError); ok {
+			protected = true
+			publicKey = passphraseErr.PublicKey
+			return
+		}
+		if parseErr != nil {
+			job.Log(p.log).WithError(parseErr).Warnf("unsupported block type: %s", block.Type)
+			return
+		}
+		result = rawKey
+	}
+
+	var pubKeyErr error
+	if publicKey, pubKeyErr = sshPublicKeyFromPrivate(result); pubKeyErr != nil {
+		errors.ErrLog(job.Log(p.log), pubKeyErr).Warn("unable to fingerprint private key")
 	}
 
 	return
@@ -474,7 +551,7 @@ func (p *Processor) parseX509PEMString(job contract.ProcessorJobI, keyString str
 
 func (p *Processor) parseRSAPrivateKeyX509PEMString(job contract.ProcessorJobI, keyString string) (result *rsa.PrivateKey, err error) {
 	var privateKey crypto.PrivateKey
-	privateKey, err = p.parseX509PEMString(job, keyString)
+	privateKey, _, _, err = p.parseX509PEMString(job, keyString)
 	if err != nil {
 		err = errors.Wrapv(err, "unable to parse x509 PEM string", keyString, job.Log(p.log))
 		return
@@ -526,3 +603,25 @@ func (p *Processor) publicKeyInfo(rsaPublicKey *rsa.PublicKey) string {
 
 	return buf.String()
 }
+
+func ProtectedExtra(protected bool) *contract.ResultExtra {
+	value := "no"
+	if protected {
+		value = "yes"
+	}
+
+	return &contract.ResultExtra{
+		Key:    ProtectedExtraKey,
+		Header: "Passphrase protected",
+		Value:  value,
+	}
+}
+
+func isEncryptedPEMBlock(block *pem.Block) bool {
+	return strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED")
+}
+
+func isEncryptedPEMString(keyString string) bool {
+	block, _ := pem.Decode([]byte(keyString))
+	return block != nil && isEncryptedPEMBlock(block)
+}
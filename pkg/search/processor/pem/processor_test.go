@@ -0,0 +1,107 @@
+package pem_test
+
+import (
+	"context"
+	"crypto/dsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/builtin"
+	"github.com/pantheon-systems/secrets-searcher/pkg/dev"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/processor/pem"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/searchtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_FindResultsInFileChange(t *testing.T) {
+	dev.Params = &dev.Parameters{}
+
+	rsaPrivateKey := rsaKey(t)
+	pkcs8Bytes, err := x509.MarshalPKCS8PrivateKey(rsaPrivateKey)
+	require.NoError(t, err)
+	encryptedBlock, err := x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY",
+		x509.MarshalPKCS1PrivateKey(rsaPrivateKey), []byte("passphrase"), x509.PEMCipherAES256)
+	require.NoError(t, err)
+	encryptedPKCS8Bytes := make([]byte, 600)
+	_, err = rand.Read(encryptedPKCS8Bytes)
+	require.NoError(t, err)
+	dsaPrivateKey, dsaBytes := dsaKey(t)
+
+	for name, tt := range map[string]struct {
+		processor      builtin.ProcessorName
+		key            string
+		expFingerprint string
+		expProtected   string
+	}{
+		"PKCS#8": {
+			processor:      builtin.PKCS8PrivateKeyPEM,
+			key:            string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes})),
+			expFingerprint: fingerprint(t, &rsaPrivateKey.PublicKey),
+			expProtected:   "no",
+		},
+		"encrypted PKCS#8": {
+			processor:    builtin.EncryptedPrivateKeyPEM,
+			key:          string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: encryptedPKCS8Bytes})),
+			expProtected: "yes",
+		},
+		"DSA": {
+			processor:      builtin.DSAPrivateKeyPEM,
+			key:            string(pem.EncodeToMemory(&pem.Block{Type: "DSA PRIVATE KEY", Bytes: dsaBytes})),
+			expFingerprint: fingerprint(t, &dsaPrivateKey.PublicKey),
+			expProtected:   "no",
+		},
+		"RSA with Proc-Type: 4,ENCRYPTED": {
+			processor:    builtin.RSAPrivateKeyPEM,
+			key:          string(pem.EncodeToMemory(encryptedBlock)),
+			expProtected: "yes",
+		},
+	} {
+		dir, cleanup := tempDir(t)
+		repository, commitHashes := testRepo(t, filepath.Join(dir, "repo"), []map[string]string{{"id_key": tt.key}})
+		subject := searchtest.CoreProcessor(tt.processor, nil)
+		worker := search.NewWorker([]contract.ProcessorI{subject}, nil, nil, nil, nil, nil, log)
+		job := search.NewJob("job", "repoID", "repo", repository, commitHashes, commitHashes[0], false, nil, log, nil)
+
+		// Fire
+		require.True(t, worker.Do(context.Background(), job), name)
+
+		results := job.GetJobResults()
+		require.Len(t, results, 1, name)
+		require.Equal(t, tt.key, results[0].SecretValue, name)
+		require.Equal(t, tt.expFingerprint, extraValue(results[0].SecretExtras, FingerprintExtraKey), name)
+		require.Equal(t, tt.expProtected, extraValue(results[0].FindingExtras, ProtectedExtraKey), name)
+
+		cleanup()
+	}
+}
+
+// In the OpenSSL format
+func dsaKey(t *testing.T) (key *dsa.PrivateKey, der []byte) {
+	key = &dsa.PrivateKey{}
+	require.NoError(t, dsa.GenerateParameters(&key.Parameters, rand.Reader, dsa.L1024N160))
+	require.NoError(t, dsa.GenerateKey(key, rand.Reader))
+
+	der, err := asn1.Marshal(struct {
+		Version       int
+		P, Q, G, Y, X *big.Int
+	}{0, key.P, key.Q, key.G, key.Y, key.X})
+	require.NoError(t, err)
+
+	return
+}
+
+func extraValue(extras []*contract.ResultExtra, key string) string {
+	for _, extra := range extras {
+		if extra.Key == key {
+			return extra.Value
+		}
+	}
+	return ""
+}
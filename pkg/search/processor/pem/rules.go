@@ -12,7 +12,10 @@ import (
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
 )
 
-var onlyBase64Re = regexp.MustCompile(`^[a-zA-Z0-9+/]+={0,2}$`)
+var (
+	onlyBase64Re    = regexp.MustCompile(`^[a-zA-Z0-9+/]+={0,2}$`)
+	pemHeaderLineRe = regexp.MustCompile(`^[A-Za-z][A-Za-z-]*: `)
+)
 
 // Find a potential key
 func (p *Processor) executeRules(job contract.ProcessorJobI) {
@@ -99,15 +102,25 @@ func (p *Processor) findMultilineKey(job contract.ProcessorJobI) (parsed bool, e
 		return
 	}
 
-	// Collect block lines, end then we'll be on the footer line
+	// Collect block lines, end then we'll be on the footer line.
+	// Encrypted keys can have header lines before the block, followed by an empty line.
+	var headerLines []string
 	var blockLines []string
 	whileFun := func(line *git.Line) bool {
 		return !strings.HasPrefix(line.Trim(), p.footer)
 	}
 	doFunc := func(line *git.Line) {
-		if line.IsAdd {
-			blockLines = append(blockLines, line.Trim())
+		if !line.IsAdd {
+			return
+		}
+		if len(blockLines) == 0 && pemHeaderLineRe.MatchString(line.Trim()) {
+			headerLines = append(headerLines, line.Trim())
+			return
+		}
+		if len(blockLines) == 0 && len(headerLines) > 0 && line.Trim() == "" {
+			return
 		}
+		blockLines = append(blockLines, line.Trim())
 	}
 	if ok := diff.WhileTrueDo(whileFun, doFunc); !ok {
 		return
@@ -121,7 +134,7 @@ func (p *Processor) findMultilineKey(job contract.ProcessorJobI) (parsed bool, e
 		EndLineNum:   footerLine.NumInFile,
 		EndIndex:     len(footerLine.Code),
 	}
-	keyString := p.buildKeyFromBlockLines(blockLines)
+	keyString := p.buildKeyFromBlockLines(headerLines, blockLines)
 
 	// Validate length and charset
 	blockString := strings.Trim(strings.Join(blockLines, ""), " ")
@@ -0,0 +1,90 @@
+package regex
+
+//go:generate stringer -type Checksum
+
+import (
+	"hash/crc32"
+	"strings"
+)
+
+const (
+	base62Chars       = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	crc32Base62Len    = 6
+	crc32TokenBodyLen = 30
+)
+
+type Checksum int
+
+const (
+	GitHub Checksum = iota
+	NPM
+)
+
+func Checksums() []Checksum {
+	return []Checksum{
+		GitHub,
+		NPM,
+	}
+}
+
+func (i Checksum) Value() string {
+	return strings.ToLower(i.String())
+}
+
+// Whether the token's embedded checksum is correct, so lookalike strings can be rejected
+func (i Checksum) Valid(token string) bool {
+	switch i {
+	case GitHub, NPM:
+		// ghp_ / npm_ followed by 30 random characters and their base62 CRC32
+		underscore := strings.LastIndex(token, "_")
+		if underscore == -1 {
+			return false
+		}
+		return validCRC32Base62(token[underscore+1:])
+	default:
+		panic("unknown checksum: " + i.String())
+	}
+}
+
+func NewChecksumFromValue(val string) Checksum {
+	for _, e := range Checksums() {
+		if e.Value() == val {
+			return e
+		}
+	}
+	panic("unknown checksum: " + val)
+}
+
+func ValidChecksumValues() (result []string) {
+	checksums := Checksums()
+	result = make([]string, len(checksums))
+	for i := range checksums {
+		result[i] = checksums[i].Value()
+	}
+	return
+}
+
+func validCRC32Base62(body string) bool {
+	if len(body) != crc32TokenBodyLen+crc32Base62Len {
+		return false
+	}
+
+	random, checksum := body[:crc32TokenBodyLen], body[crc32TokenBodyLen:]
+
+	return encodeBase62(crc32.ChecksumIEEE([]byte(random)), crc32Base62Len) == checksum
+}
+
+// Left padded with zeros to width
+func encodeBase62(value uint32, width int) string {
+	var result []byte
+	for value > 0 {
+		result = append([]byte{base62Chars[value%62]}, result...)
+		value /= 62
+	}
+
+	if len(result) < width {
+		result = append([]byte(strings.Repeat("0", width-len(result))), result...)
+	}
+
+	return string(result)
+}
@@ -9,20 +9,28 @@ import (
 	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
 	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/decode"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/keyword"
 )
 
 type Processor struct {
 	name          string
 	re            *regexp.Regexp
+	checksum      *Checksum
 	codeWhitelist *search.CodeWhitelist
+	decoder       *decode.Pipeline
 	log           logg.Logg
 }
 
-func NewProcessor(name string, re *regexp.Regexp, codeWhitelist *search.CodeWhitelist, log logg.Logg) (result *Processor) {
+// Checksum is optional, matches that fail it are dropped
+// Decoder is optional, encoded values are only searched when it's set
+func NewProcessor(name string, re *regexp.Regexp, checksum *Checksum, codeWhitelist *search.CodeWhitelist, decoder *decode.Pipeline, log logg.Logg) (result *Processor) {
 	return &Processor{
 		name:          name,
 		re:            re,
+		checksum:      checksum,
 		codeWhitelist: codeWhitelist,
+		decoder:       decoder,
 		log:           log,
 	}
 }
@@ -31,6 +39,14 @@ func (p *Processor) GetName() string {
 	return p.name
 }
 
+// Encoded values can match without a keyword in the line, so there are none when decoding
+func (p *Processor) Keywords() []string {
+	if p.decoder != nil {
+		return nil
+	}
+	return keyword.FromRegexp(p.re)
+}
+
 func (p *Processor) FindResultsInLine(job contract.LineProcessorJobI, line string) (err error) {
 	matches := p.re.FindAllStringSubmatchIndex(line, -1)
 
@@ -53,12 +69,72 @@ func (p *Processor) FindResultsInLine(job contract.LineProcessorJobI, line strin
 			continue
 		}
 
+		secretValue := lineRange.ExtractValue(line).Value
+		if p.checksum != nil && !p.checksum.Valid(secretValue) {
+			job.Log(p.log).Debugf("match failed %s checksum", p.checksum.String())
+			continue
+		}
+
 		job.SubmitLineResult(&contract.LineResult{
 			LineRange:        lineRange,
-			SecretValue:      lineRange.ExtractValue(line).Value,
+			SecretValue:      secretValue,
 			ContextLineRange: contextRange,
 		})
 	}
 
+	if p.decoder != nil {
+		p.findResultsInDecodedValues(job, line)
+	}
+
+	return
+}
+
+// Encoded values in the line are decoded, and the decoded content is searched with the same regex
+func (p *Processor) findResultsInDecodedValues(job contract.LineProcessorJobI, line string) {
+	for _, lineRange := range p.decoder.Candidates(line) {
+		encoded := lineRange.ExtractValue(line).Value
+
+	decodedLoop:
+		for _, decoded := range p.decoder.Decode(encoded) {
+			for _, decodedLine := range decoded.Lines() {
+				secretValue, ok := p.findSecretInString(job, decodedLine)
+				if !ok {
+					continue
+				}
+
+				job.SubmitLineResult(&contract.LineResult{
+					LineRange:     lineRange,
+					SecretValue:   secretValue,
+					FindingExtras: []*contract.ResultExtra{decoded.ChainExtra(decoded.Key)},
+				})
+				break decodedLoop
+			}
+		}
+	}
+}
+
+// First match in the string that isn't whitelisted and passes the checksum
+func (p *Processor) findSecretInString(job contract.LineProcessorJobI, value string) (result string, ok bool) {
+	for _, match := range p.re.FindAllStringSubmatchIndex(value, -1) {
+		lineRange := manip.NewLineRange(match[0], match[1])
+		if len(match) == 4 {
+			lineRange = manip.NewLineRange(match[2], match[3])
+		}
+
+		if p.codeWhitelist.IsSecretWhitelisted(value, lineRange) {
+			continue
+		}
+
+		if result = lineRange.ExtractValue(value).Value; result == "" {
+			continue
+		}
+		if p.checksum != nil && !p.checksum.Valid(result) {
+			job.Log(p.log).Debugf("decoded match failed %s checksum", p.checksum.String())
+			continue
+		}
+
+		return result, true
+	}
+
 	return
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
 	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/decode"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/keyword"
 )
 
 const KeyValueExtraName = "setter-key-value"
@@ -19,6 +21,7 @@ type (
 		name    string
 		Rules   []*Rule
 		targets *search.TargetSet
+		decoder *decode.Pipeline
 		log     logg.Logg
 		processorState
 	}
@@ -27,11 +30,13 @@ type (
 	}
 )
 
-func NewProcessor(name string, rules []*Rule, targets *search.TargetSet, log logg.Logg) (result *Processor) {
+// Decoder is optional, encoded values are only searched when it's set
+func NewProcessor(name string, rules []*Rule, targets *search.TargetSet, decoder *decode.Pipeline, log logg.Logg) (result *Processor) {
 	result = &Processor{
 		name:    name,
 		Rules:   rules,
 		targets: targets,
+		decoder: decoder,
 		log:     log,
 		processorState: processorState{
 			rulesByExt: cmap.New(),
@@ -45,6 +50,15 @@ func (p *Processor) GetName() string {
 	return p.name
 }
 
+// A result's key has to match a target, so the line has one of the targets' keywords.
+// Encoded values can match without one, so there are none when decoding.
+func (p *Processor) Keywords() []string {
+	if p.decoder != nil {
+		return nil
+	}
+	return keyword.FromRegexp(p.targets.KeyRegexp())
+}
+
 func (p *Processor) supportedRules(path string) (result []*Rule) {
 	if uncast, ok := p.rulesByExt.Get(path); ok {
 		return uncast.([]*Rule)
@@ -85,9 +99,71 @@ func (p *Processor) FindResultsInLine(job contract.LineProcessorJobI, line strin
 		break
 	}
 
+	if p.decoder != nil && len(rules) > 0 {
+		p.findResultsInDecodedValues(job, rules, line, targetLog)
+	}
+
 	return
 }
 
+// Encoded values in the line are decoded, and the decoded content is searched using the same rules.
+// Fields of decoded JSON documents are checked against the targets directly.
+func (p *Processor) findResultsInDecodedValues(job contract.LineProcessorJobI, rules []*Rule, line string, targetLog logg.Logg) {
+	for _, lineRange := range p.decoder.Candidates(line) {
+		encoded := lineRange.ExtractValue(line).Value
+
+	decodedLoop:
+		for _, decoded := range p.decoder.Decode(encoded) {
+			if decoded.Key != "" {
+				if p.targets.Matches(decoded.Key, decoded.Value, targetLog) {
+					p.submitDecodedResult(job, lineRange, decoded, "", decoded.Key, decoded.Value)
+					break decodedLoop
+				}
+				continue
+			}
+
+			for _, decodedLine := range decoded.Lines() {
+				for _, rule := range rules {
+					_, keyValue, secretValue, _, ok := rule.FindNextSecret(decodedLine)
+					if !ok || !p.targets.Matches(keyValue.Value, secretValue.Value, targetLog) {
+						continue
+					}
+					p.submitDecodedResult(job, lineRange, decoded, rule.GetName(), keyValue.Value, secretValue.Value)
+					break decodedLoop
+				}
+			}
+		}
+	}
+}
+
+func (p *Processor) submitDecodedResult(job contract.LineProcessorJobI, lineRange *manip.LineRange, decoded *decode.Decoded, ruleName, key, secret string) {
+	defer job.SubmitLineRangeIgnore(lineRange)
+
+	var findingExtras []*contract.ResultExtra
+
+	if ruleName != "" {
+		findingExtras = append(findingExtras, &contract.ResultExtra{
+			Key:    "setter-rule",
+			Header: "Proc. rule",
+			Value:  ruleName,
+		})
+	}
+
+	findingExtras = append(findingExtras, &contract.ResultExtra{
+		Key:    KeyValueExtraName,
+		Header: "Processor key",
+		Value:  key,
+	})
+
+	findingExtras = append(findingExtras, decoded.ChainExtra(key))
+
+	job.SubmitLineResult(&contract.LineResult{
+		LineRange:     lineRange,
+		SecretValue:   secret,
+		FindingExtras: findingExtras,
+	})
+}
+
 func (p *Processor) findResultsWithRules(job contract.LineProcessorJobI, rules []*Rule, line string, startIndex int, targetLog logg.Logg) (found bool, endIndex int) {
 	for _, rule := range rules {
 		if found, endIndex = p.findFirstSecretInStringUsingRule(job, rule, line, startIndex, targetLog); found {
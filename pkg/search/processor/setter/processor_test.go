@@ -0,0 +1,53 @@
+package setter_test
+
+import (
+	"testing"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/app/build"
+	"github.com/pantheon-systems/secrets-searcher/pkg/builtin"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/decode"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/searchtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessor_FindResultsInLine_DecodedJSON(t *testing.T) {
+	targets := searchtest.CoreTargets(builtin.Passwords)
+	procConfig := builtin.ProcessorConfig(builtin.YAMLDictFieldValSetter)
+	procConfig.SetterProcessorConfig.DecodeDepth = 3
+	subject, err := build.ProcSetter(procConfig.Name, &procConfig.SetterProcessorConfig, targets, searchtest.Log())
+	require.NoError(t, err)
+	job := &searchtest.LineProcJobMock{Path: "config.yaml", Logger: searchtest.Log()}
+
+	// {"db":{"user":"app","password":"hunter2-prod"}}
+	line := "settings: eyJkYiI6eyJ1c2VyIjoiYXBwIiwicGFzc3dvcmQiOiJodW50ZXIyLXByb2QifX0="
+
+	// Fire
+	err = subject.FindResultsInLine(job, line)
+
+	require.NoError(t, err)
+	require.Len(t, job.SecretValues, 1)
+	require.Equal(t, "hunter2-prod", job.SecretValues[0])
+	require.Equal(t, "eyJkYiI6eyJ1c2VyIjoiYXBwIiwicGFzc3dvcmQiOiJodW50ZXIyLXByb2QifX0=", job.LineRanges[0].ExtractValue(line).Value)
+
+	var chain string
+	for _, extra := range job.FindingExtras {
+		if extra.Key == decode.ChainExtraKey {
+			chain = extra.Value
+		}
+	}
+	require.Equal(t, "base64 -> json -> password", chain)
+}
+
+func TestProcessor_FindResultsInLine_DecodingDisabled(t *testing.T) {
+	targets := searchtest.CoreTargets(builtin.Passwords)
+	procConfig := builtin.ProcessorConfig(builtin.YAMLDictFieldValSetter)
+	subject, err := build.ProcSetter(procConfig.Name, &procConfig.SetterProcessorConfig, targets, searchtest.Log())
+	require.NoError(t, err)
+	job := &searchtest.LineProcJobMock{Path: "config.yaml", Logger: searchtest.Log()}
+
+	// Fire
+	err = subject.FindResultsInLine(job, "settings: eyJkYiI6eyJ1c2VyIjoiYXBwIiwicGFzc3dvcmQiOiJodW50ZXIyLXByb2QifX0=")
+
+	require.NoError(t, err)
+	require.Empty(t, job.SecretValues)
+}
@@ -322,3 +322,205 @@ func TestFind_ConfParamSystemdServiceEnvVar(t *testing.T) {
 		expCtx:        "Environment=AGGREGATES_PASSWORD=27d09f46d6b94d07a7f803191ef49f81",
 	})
 }
+
+func TestFind_JVMFieldAssign_JavaConstant(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.JVMFieldAssignSetter,
+		coreTargets:   []builtin.TargetName{builtin.APIKeysAndTokens},
+		path:          "Client.java",
+		line:          `    private static final String API_KEY = "8d9b08206d56012f52f91231390e3932";`,
+		expKey:        "API_KEY",
+		expVal:        "8d9b08206d56012f52f91231390e3932",
+		expCtx:        `API_KEY = "8d9b08206d56012f52f91231390e3932"`,
+	})
+}
+
+func TestFind_JVMFieldAssign_KotlinTypedVal(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.JVMFieldAssignSetter,
+		coreTargets:   []builtin.TargetName{builtin.Passwords},
+		path:          "Config.kt",
+		line:          `const val dbPassword: String = "hunter2hunter2"`,
+		expKey:        "dbPassword",
+		expVal:        "hunter2hunter2",
+		expCtx:        `dbPassword: String = "hunter2hunter2"`,
+	})
+}
+
+func TestFind_JVMFieldAssign_AnnotationAttribute(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.JVMFieldAssignSetter,
+		coreTargets:   []builtin.TargetName{builtin.APIKeysAndTokens},
+		path:          "Settings.scala",
+		line:          `@Client(apiKey = "80e1c56259bf32235ef432e811bbf86e")`,
+		expKey:        "apiKey",
+		expVal:        "80e1c56259bf32235ef432e811bbf86e",
+		expCtx:        `apiKey = "80e1c56259bf32235ef432e811bbf86e"`,
+	})
+}
+
+func TestFind_JVMAnnotationValueDefault(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.JVMAnnotationValueDefaultSetter,
+		coreTargets:   []builtin.TargetName{builtin.APIKeysAndTokens},
+		path:          "StripeConfig.java",
+		line:          `    @Value("${stripe.api-key:80e1c56259bf32235ef432e811bbf86e}")`,
+		expKey:        "stripe.api-key",
+		expVal:        "80e1c56259bf32235ef432e811bbf86e",
+		expCtx:        `@Value("${stripe.api-key:80e1c56259bf32235ef432e811bbf86e}`,
+	})
+}
+
+func TestFind_JVMConfigPropertyDefault(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.JVMConfigPropertyDefaultSetter,
+		coreTargets:   []builtin.TargetName{builtin.APIKeysAndTokens},
+		path:          "Service.java",
+		line:          `@ConfigProperty(name = "api.token", defaultValue = "5d24c96b4f6a4aefb99602ce9b60d16b")`,
+		expKey:        "api.token",
+		expVal:        "5d24c96b4f6a4aefb99602ce9b60d16b",
+		expCtx:        `name = "api.token", defaultValue = "5d24c96b4f6a4aefb99602ce9b60d16b"`,
+	})
+}
+
+func TestFind_PropertiesField(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.PropertiesFieldSetter,
+		coreTargets:   []builtin.TargetName{builtin.Passwords},
+		path:          "src/main/resources/application.properties",
+		line:          "spring.datasource.password = pr0d-db-pass",
+		expKey:        "spring.datasource.password",
+		expVal:        "pr0d-db-pass",
+		expCtx:        "spring.datasource.password = pr0d-db-pass",
+	})
+}
+
+func TestFind_CSharpVarAssign_Verbatim(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.CSharpVarAssignSetter,
+		coreTargets:   []builtin.TargetName{builtin.APIKeysAndTokens},
+		path:          "Client.cs",
+		line:          `        private const string ApiKey = @"a52b0d69401e4fa483af274c5da1ea9a";`,
+		expKey:        "ApiKey",
+		expVal:        "a52b0d69401e4fa483af274c5da1ea9a",
+		expCtx:        `ApiKey = @"a52b0d69401e4fa483af274c5da1ea9a"`,
+	})
+}
+
+func TestFind_CSharpPropertyInitializer(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.CSharpPropertyInitializerSetter,
+		coreTargets:   []builtin.TargetName{builtin.Passwords},
+		path:          "Settings.cs",
+		line:          `public string SmtpPassword { get; set; } = "m41l-pass";`,
+		expKey:        "SmtpPassword",
+		expVal:        "m41l-pass",
+		expCtx:        `SmtpPassword { get; set; } = "m41l-pass"`,
+	})
+}
+
+func TestFind_DotNetConfigAppSetting(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.DotNetConfigAppSettingSetter,
+		coreTargets:   []builtin.TargetName{builtin.APIKeysAndTokens},
+		path:          "Web.config",
+		line:          `    <add key="ApiKey" value="27d09f46d6b94d07a7f803191ef49f81" />`,
+		expKey:        "ApiKey",
+		expVal:        "27d09f46d6b94d07a7f803191ef49f81",
+		expCtx:        `<add key="ApiKey" value="27d09f46d6b94d07a7f803191ef49f81"`,
+	})
+}
+
+func TestFind_AppSettingsJSONField_ColonPathKey(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.AppSettingsJSONFieldSetter,
+		coreTargets:   []builtin.TargetName{builtin.Passwords},
+		path:          "appsettings.Development.json",
+		line:          `  "Smtp:Password": "m41l-pass",`,
+		expKey:        "Smtp:Password",
+		expVal:        "m41l-pass",
+		expCtx:        `"Smtp:Password": "m41l-pass"`,
+	})
+}
+
+func TestFind_PowerShellVarAssign_EnvVar(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.PowerShellVarAssignSetter,
+		coreTargets:   []builtin.TargetName{builtin.APIKeysAndTokens},
+		path:          "deploy.ps1",
+		line:          `$env:DEPLOY_TOKEN = 'cc00c867e89a7f17478a817d6a745031'`,
+		expKey:        "DEPLOY_TOKEN",
+		expVal:        "cc00c867e89a7f17478a817d6a745031",
+		expCtx:        `$env:DEPLOY_TOKEN = 'cc00c867e89a7f17478a817d6a745031'`,
+	})
+}
+
+func TestFind_PowerShellVarAssign_Hashtable(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.PowerShellVarAssignSetter,
+		coreTargets:   []builtin.TargetName{builtin.Passwords},
+		path:          "deploy.psm1",
+		line:          `$creds = @{ UserName = "deploy"; Password = "d3ploy-pass" }`,
+		expKey:        "Password",
+		expVal:        "d3ploy-pass",
+		expCtx:        `Password = "d3ploy-pass"`,
+	})
+}
+
+func TestFind_HCLAttrAssign(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.HCLAttrAssignSetter,
+		coreTargets:   []builtin.TargetName{builtin.Passwords},
+		path:          "main.tf",
+		line:          `  master_password = "rds-master-pass"`,
+		expKey:        "master_password",
+		expVal:        "rds-master-pass",
+		expCtx:        `master_password = "rds-master-pass"`,
+	})
+}
+
+func TestFind_HCLVariableDefault(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.HCLVariableDefaultSetter,
+		coreTargets:   []builtin.TargetName{builtin.Passwords},
+		path:          "variables.tf",
+		line:          `variable "db_password" { type = string, default = "v4r-default" }`,
+		expKey:        "db_password",
+		expVal:        "v4r-default",
+		expCtx:        `variable "db_password" { type = string, default = "v4r-default"`,
+	})
+}
+
+func TestFind_DockerfileEnv_SpaceSeparated(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.DockerfileEnvSetter,
+		coreTargets:   []builtin.TargetName{builtin.APIKeysAndTokens},
+		path:          "Dockerfile",
+		line:          "ENV NPM_TOKEN 5d24c96b4f6a4aefb99602ce9b60d16b",
+		expKey:        "NPM_TOKEN",
+		expVal:        "5d24c96b4f6a4aefb99602ce9b60d16b",
+		expCtx:        "ENV NPM_TOKEN 5d24c96b4f6a4aefb99602ce9b60d16b",
+	})
+}
+
+func TestFind_DockerfileArgDefault(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.DockerfileArgDefaultSetter,
+		coreTargets:   []builtin.TargetName{builtin.Passwords},
+		path:          "build/Dockerfile.prod",
+		line:          `ARG DB_PASSWORD="buildtime-pass"`,
+		expKey:        "DB_PASSWORD",
+		expVal:        "buildtime-pass",
+		expCtx:        `ARG DB_PASSWORD="buildtime-pass"`,
+	})
+}
+
+func TestFind_DockerfileArgDefault_NoDefault(t *testing.T) {
+	runRuleTest(t, ruleTest{
+		coreProcessor: builtin.DockerfileArgDefaultSetter,
+		coreTargets:   []builtin.TargetName{builtin.Passwords},
+		path:          "Dockerfile",
+		line:          "ARG DB_PASSWORD",
+		expFail:       true,
+	})
+}
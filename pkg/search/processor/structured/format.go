@@ -0,0 +1,77 @@
+package structured
+
+//go:generate stringer -type Format
+
+import (
+	"path"
+	"strings"
+)
+
+type Format int
+
+const (
+	YAML Format = iota
+	JSON
+	TOML
+	INI
+	Properties
+	Dotenv
+)
+
+func Formats() []Format {
+	return []Format{
+		YAML,
+		JSON,
+		TOML,
+		INI,
+		Properties,
+		Dotenv,
+	}
+}
+
+func (i Format) Value() string {
+	return strings.ToLower(i.String())
+}
+
+// Which format a file is in, going by its name
+func NewFormatFromPath(filePath string) (result Format, ok bool) {
+	base := strings.ToLower(path.Base(filePath))
+
+	// .env, .env.production, production.env
+	if base == ".env" || strings.HasPrefix(base, ".env.") || strings.HasSuffix(base, ".env") {
+		return Dotenv, true
+	}
+
+	switch path.Ext(base) {
+	case ".yml", ".yaml":
+		return YAML, true
+	case ".json":
+		return JSON, true
+	case ".toml":
+		return TOML, true
+	case ".ini", ".cfg":
+		return INI, true
+	case ".properties":
+		return Properties, true
+	}
+
+	return
+}
+
+func NewFormatFromValue(val string) Format {
+	for _, e := range Formats() {
+		if e.Value() == val {
+			return e
+		}
+	}
+	panic("unknown structured format: " + val)
+}
+
+func ValidFormatValues() (result []string) {
+	formats := Formats()
+	result = make([]string, len(formats))
+	for i := range formats {
+		result[i] = formats[i].Value()
+	}
+	return
+}
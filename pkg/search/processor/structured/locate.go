@@ -0,0 +1,148 @@
+package structured
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Block scalar indicators and quotes that can come between a key and its value
+var valuePrefixRegex = regexp.MustCompile(`^(?:[|>][-+0-9]*[ \t]*(?:#.*)?\r?\n[ \t]*|["'])`)
+
+// Where a pair's value starts and ends in the file
+type Position struct {
+	StartLineNum int
+	StartIndex   int
+	EndLineNum   int
+	EndIndex     int
+	offset       int
+}
+
+// Finds each pair's value by its key, in order, so repeated keys map to successive
+// occurrences. Pairs that can't be found get a nil position. Each key is matched once
+// and its matches are walked with a cursor, so the file isn't rescanned for every pair.
+func Locate(contents string, pairs []*Pair) (result []*Position) {
+	result = make([]*Position, len(pairs))
+	keys := map[string]*keyMatches{}
+	used := map[int]bool{}
+	lineStarts := lineStartOffsets(contents)
+
+	for i, pair := range pairs {
+		key := pair.Key()
+		if key == "" {
+			continue
+		}
+		matches, ok := keys[key]
+		if !ok {
+			matches = newKeyMatches(contents, key)
+			keys[key] = matches
+		}
+
+		result[i] = matches.locate(contents, lineStarts, pair, used)
+		if result[i] != nil {
+			used[result[i].offset] = true
+		}
+	}
+
+	return
+}
+
+// Where a key's values can start, and how far the pairs with that key have been found
+type keyMatches struct {
+	keyEnds   []int
+	cursor    int
+	arrayFrom int
+}
+
+func newKeyMatches(contents, key string) (result *keyMatches) {
+	keyRe := regexp.MustCompile(`(?:^|[^\w.-])['"]?` + regexp.QuoteMeta(key) + `['"]?[ \t]*[:=][ \t]*`)
+	result = &keyMatches{}
+	for _, match := range keyRe.FindAllStringIndex(contents, -1) {
+		result.keyEnds = append(result.keyEnds, match[1])
+	}
+
+	return
+}
+
+// Matches are tried from the cursor on, then from the start, since some parsers don't keep the file's order
+func (m *keyMatches) locate(contents string, lineStarts []int, pair *Pair, used map[int]bool) (result *Position) {
+	for _, raw := range pair.rawForms() {
+		lines := strings.Split(strings.TrimRight(raw, "\n"), "\n")
+		if lines[0] == "" {
+			continue
+		}
+
+		for j := range m.keyEnds {
+			matchIndex := (m.cursor + j) % len(m.keyEnds)
+			keyEnd := m.keyEnds[matchIndex]
+
+			// Items of an array come one after another
+			from := keyEnd
+			if pair.IsArrayItem() && matchIndex == m.cursor && m.arrayFrom > from {
+				from = m.arrayFrom
+			}
+
+			offset, ok := valueOffset(contents, from, lines[0], pair.IsArrayItem(), used)
+			if !ok {
+				continue
+			}
+
+			if pair.IsArrayItem() {
+				m.cursor, m.arrayFrom = matchIndex, offset+1
+			} else {
+				m.cursor, m.arrayFrom = (matchIndex+1)%len(m.keyEnds), 0
+			}
+
+			lineIndex := sort.SearchInts(lineStarts, offset+1) - 1
+			result = &Position{
+				StartLineNum: lineIndex + 1,
+				StartIndex:   offset - lineStarts[lineIndex],
+				offset:       offset,
+			}
+
+			// Multi-line scalars keep the indent of their first line
+			result.EndLineNum = result.StartLineNum + len(lines) - 1
+			result.EndIndex = result.StartIndex + len(lines[len(lines)-1])
+
+			return
+		}
+	}
+
+	return
+}
+
+func lineStartOffsets(contents string) (result []int) {
+	result = []int{0}
+	for i := 0; i < len(contents); i++ {
+		if contents[i] == '\n' {
+			result = append(result, i+1)
+		}
+	}
+	return
+}
+
+// Values come right after their key, array items come somewhere after it
+func valueOffset(contents string, from int, firstLine string, arrayItem bool, used map[int]bool) (result int, ok bool) {
+	if !arrayItem {
+		result = from
+		if prefix := valuePrefixRegex.FindString(contents[from:]); prefix != "" {
+			result += len(prefix)
+		}
+		ok = !used[result] && strings.HasPrefix(contents[result:], firstLine)
+		return
+	}
+
+	for searchFrom := from; searchFrom < len(contents); {
+		i := strings.Index(contents[searchFrom:], firstLine)
+		if i == -1 {
+			return
+		}
+		result = searchFrom + i
+		if !used[result] {
+			return result, true
+		}
+		searchFrom = result + 1
+	}
+
+	return
+}
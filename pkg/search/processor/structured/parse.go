@@ -0,0 +1,394 @@
+package structured
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/magiconair/properties"
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	"github.com/pelletier/go-toml"
+	"github.com/subosito/gotenv"
+	"gopkg.in/ini.v1"
+	"gopkg.in/yaml.v2"
+)
+
+// A string value in a structured file, and the path of keys that leads to it
+type Pair struct {
+	Path  []string
+	Value string
+
+	// How the value is written in the file, when that's different from Value
+	Raw string
+}
+
+// The last key on the path, array indexes aside
+func (p *Pair) Key() string {
+	for i := len(p.Path) - 1; i >= 0; i-- {
+		if !strings.HasPrefix(p.Path[i], "[") {
+			return p.Path[i]
+		}
+	}
+	return ""
+}
+
+func (p *Pair) IsArrayItem() bool {
+	return len(p.Path) > 0 && strings.HasPrefix(p.Path[len(p.Path)-1], "[")
+}
+
+// Ways the value could be written in the file
+func (p *Pair) rawForms() (result []string) {
+	if p.Raw == "" {
+		return []string{p.Value}
+	}
+
+	result = []string{p.Raw}
+
+	// JSON encoders may escape forward slashes
+	if strings.Contains(p.Raw, "/") {
+		result = append(result, strings.Replace(p.Raw, "/", `\/`, -1))
+	}
+
+	return
+}
+
+func (p *Pair) PathString() string {
+	return strings.Replace(strings.Join(p.Path, "."), ".[", "[", -1)
+}
+
+// String values in the file, in document order where the parser keeps it
+func Parse(format Format, contents string) (result []*Pair, err error) {
+	switch format {
+	case YAML:
+		result, err = parseYAML(contents)
+	case JSON:
+		result, err = parseJSON(contents)
+	case TOML:
+		result, err = parseTOML(contents)
+	case INI:
+		result, err = parseINI(contents)
+	case Properties:
+		result, err = parseProperties(contents)
+	case Dotenv:
+		result, err = parseDotenv(contents)
+	default:
+		panic("unknown format: " + format.String())
+	}
+	if err != nil {
+		err = errors.Wrapv(err, "unable to parse file", format.String())
+	}
+
+	return
+}
+
+//
+// YAML
+
+func parseYAML(contents string) (result []*Pair, err error) {
+	decoder := yaml.NewDecoder(strings.NewReader(contents))
+	for {
+		var doc yamlValue
+		if err = decoder.Decode(&doc); err == io.EOF {
+			err = nil
+			return
+		}
+		if err != nil {
+			return
+		}
+		result = walkYAML(result, nil, doc.value)
+	}
+}
+
+// Any YAML value, with mappings decoded as yaml.MapSlice so document order is kept,
+// even when the document is a sequence
+type yamlValue struct {
+	value interface{}
+}
+
+func (v *yamlValue) UnmarshalYAML(unmarshal func(interface{}) error) (err error) {
+	if err = unmarshal(&v.value); err != nil {
+		return
+	}
+
+	switch v.value.(type) {
+	case map[interface{}]interface{}:
+		var mapSlice yaml.MapSlice
+		err = unmarshal(&mapSlice)
+		v.value = mapSlice
+	case []interface{}:
+		var items []yamlValue
+		err = unmarshal(&items)
+		values := make([]interface{}, len(items))
+		for i, item := range items {
+			values[i] = item.value
+		}
+		v.value = values
+	}
+
+	return
+}
+
+func walkYAML(result []*Pair, path []string, value interface{}) []*Pair {
+	switch typed := value.(type) {
+	case yaml.MapSlice:
+		for _, item := range typed {
+			result = walkYAML(result, appendPath(path, stringKey(item.Key)), item.Value)
+		}
+	case []interface{}:
+		for i, item := range typed {
+			result = walkYAML(result, appendPath(path, indexKey(i)), item)
+		}
+	case string:
+		result = append(result, &Pair{Path: path, Value: typed})
+	}
+
+	return result
+}
+
+//
+// JSON
+
+// Tokens are read in order, so document order is kept
+func parseJSON(contents string) (result []*Pair, err error) {
+	decoder := json.NewDecoder(strings.NewReader(contents))
+
+	var value interface{}
+	if value, err = readJSONValue(decoder); err != nil {
+		return
+	}
+
+	return walkJSON(result, nil, value), nil
+}
+
+type jsonObject struct {
+	keys   []string
+	values []interface{}
+}
+
+func readJSONValue(decoder *json.Decoder) (result interface{}, err error) {
+	var token json.Token
+	if token, err = decoder.Token(); err != nil {
+		return
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+
+	switch delim {
+	case '{':
+		object := &jsonObject{}
+		for decoder.More() {
+			var keyToken json.Token
+			if keyToken, err = decoder.Token(); err != nil {
+				return
+			}
+			var value interface{}
+			if value, err = readJSONValue(decoder); err != nil {
+				return
+			}
+			object.keys = append(object.keys, keyToken.(string))
+			object.values = append(object.values, value)
+		}
+		result = object
+	case '[':
+		var array []interface{}
+		for decoder.More() {
+			var value interface{}
+			if value, err = readJSONValue(decoder); err != nil {
+				return
+			}
+			array = append(array, value)
+		}
+		result = array
+	}
+
+	// Closing delimiter
+	_, err = decoder.Token()
+
+	return
+}
+
+func walkJSON(result []*Pair, path []string, value interface{}) []*Pair {
+	switch typed := value.(type) {
+	case *jsonObject:
+		for i, key := range typed.keys {
+			result = walkJSON(result, appendPath(path, key), typed.values[i])
+		}
+	case []interface{}:
+		for i, item := range typed {
+			result = walkJSON(result, appendPath(path, indexKey(i)), item)
+		}
+	case string:
+		result = append(result, &Pair{Path: path, Value: typed, Raw: jsonRaw(typed)})
+	}
+
+	return result
+}
+
+// The escaped form, without the quotes
+func jsonRaw(value string) string {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return value
+	}
+	encoded := strings.TrimSpace(buf.String())
+
+	return encoded[1 : len(encoded)-1]
+}
+
+//
+// TOML
+
+func parseTOML(contents string) (result []*Pair, err error) {
+	var tree *toml.Tree
+	if tree, err = toml.Load(contents); err != nil {
+		return
+	}
+
+	return walkTOML(result, nil, tree), nil
+}
+
+func walkTOML(result []*Pair, path []string, tree *toml.Tree) []*Pair {
+
+	// Keys come back unordered, so order them by where they are
+	keys := tree.Keys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		pi, pj := tree.GetPosition(keys[i]), tree.GetPosition(keys[j])
+		return pi.Line < pj.Line || (pi.Line == pj.Line && pi.Col < pj.Col)
+	})
+
+	for _, key := range keys {
+		result = walkTOMLValue(result, appendPath(path, key), tree.GetPath([]string{key}))
+	}
+
+	return result
+}
+
+func walkTOMLValue(result []*Pair, path []string, value interface{}) []*Pair {
+	switch typed := value.(type) {
+	case *toml.Tree:
+		result = walkTOML(result, path, typed)
+	case []*toml.Tree:
+		for i, item := range typed {
+			result = walkTOML(result, appendPath(path, indexKey(i)), item)
+		}
+	case []interface{}:
+		for i, item := range typed {
+			result = walkTOMLValue(result, appendPath(path, indexKey(i)), item)
+		}
+	case string:
+		result = append(result, &Pair{Path: path, Value: typed})
+	}
+
+	return result
+}
+
+//
+// INI
+
+func parseINI(contents string) (result []*Pair, err error) {
+	var file *ini.File
+	if file, err = ini.Load([]byte(contents)); err != nil {
+		return
+	}
+
+	for _, section := range file.Sections() {
+		var path []string
+		if section.Name() != ini.DefaultSection {
+			path = []string{section.Name()}
+		}
+		for _, key := range section.Keys() {
+			result = append(result, &Pair{Path: appendPath(path, key.Name()), Value: key.Value()})
+		}
+	}
+
+	return
+}
+
+//
+// Java properties
+
+func parseProperties(contents string) (result []*Pair, err error) {
+	var props *properties.Properties
+	if props, err = properties.LoadString(contents); err != nil {
+		return
+	}
+
+	for _, key := range props.Keys() {
+		value, _ := props.Get(key)
+		result = append(result, &Pair{Path: []string{key}, Value: value})
+	}
+
+	return
+}
+
+//
+// .env
+
+func parseDotenv(contents string) (result []*Pair, err error) {
+	var env gotenv.Env
+	if env, err = gotenv.StrictParse(strings.NewReader(contents)); err != nil {
+		return
+	}
+
+	// Keys come back unordered, so order them by the line they're set on
+	keyLineNums := dotenvKeyLineNums(contents, env)
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sort.SliceStable(keys, func(i, j int) bool { return keyLineNums[keys[i]] < keyLineNums[keys[j]] })
+
+	for _, key := range keys {
+		result = append(result, &Pair{Path: []string{key}, Value: env[key]})
+	}
+
+	return
+}
+
+// The last line each key is set on, like gotenv
+func dotenvKeyLineNums(contents string, env gotenv.Env) (result map[string]int) {
+	result = map[string]int{}
+	for i, line := range strings.Split(contents, "\n") {
+		line = strings.TrimPrefix(strings.TrimSpace(line), "export ")
+		end := strings.IndexAny(line, "=:")
+		if end == -1 {
+			continue
+		}
+		key := strings.TrimSpace(line[:end])
+		if _, ok := env[key]; ok {
+			result[key] = i
+		}
+	}
+
+	return
+}
+
+//
+// Helpers
+
+func appendPath(path []string, key string) []string {
+	result := make([]string, len(path), len(path)+1)
+	copy(result, path)
+	return append(result, key)
+}
+
+func stringKey(key interface{}) string {
+	if typed, ok := key.(string); ok {
+		return typed
+	}
+	return fmt.Sprint(key)
+}
+
+func indexKey(i int) string {
+	return "[" + strconv.Itoa(i) + "]"
+}
@@ -0,0 +1,194 @@
+package structured_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/processor/structured"
+	"github.com/stretchr/testify/require"
+)
+
+type located struct {
+	path     string
+	value    string
+	position Position
+}
+
+func runParseTest(t *testing.T, format Format, contents string, expected []located) {
+
+	// Fire
+	pairs, err := Parse(format, contents)
+
+	require.NoError(t, err)
+	positions := Locate(contents, pairs)
+
+	var actual []located
+	for i, pair := range pairs {
+		require.NotNil(t, positions[i], "unable to locate %s", pair.PathString())
+		position := *positions[i]
+		actual = append(actual, located{
+			path:  pair.PathString(),
+			value: pair.Value,
+			position: Position{
+				StartLineNum: position.StartLineNum,
+				StartIndex:   position.StartIndex,
+				EndLineNum:   position.EndLineNum,
+				EndIndex:     position.EndIndex,
+			},
+		})
+	}
+	require.Equal(t, expected, actual)
+}
+
+func TestParse_YAML(t *testing.T) {
+	contents := `database:
+  prod:
+    password: "pr0d-pass"
+  staging:
+    password: st4ging-pass
+    hosts: [db1, db2]
+cert: |
+  line one
+  line two
+`
+	runParseTest(t, YAML, contents, []located{
+		{"database.prod.password", "pr0d-pass", Position{StartLineNum: 3, StartIndex: 15, EndLineNum: 3, EndIndex: 24}},
+		{"database.staging.password", "st4ging-pass", Position{StartLineNum: 5, StartIndex: 14, EndLineNum: 5, EndIndex: 26}},
+		{"database.staging.hosts[0]", "db1", Position{StartLineNum: 6, StartIndex: 12, EndLineNum: 6, EndIndex: 15}},
+		{"database.staging.hosts[1]", "db2", Position{StartLineNum: 6, StartIndex: 17, EndLineNum: 6, EndIndex: 20}},
+		{"cert", "line one\nline two\n", Position{StartLineNum: 8, StartIndex: 2, EndLineNum: 9, EndIndex: 10}},
+	})
+}
+
+func TestParse_YAML_Sequence(t *testing.T) {
+	contents := `- name: prod
+  password: pr0d-pass
+- name: staging
+  password: st4ging-pass
+---
+token: t0ken
+`
+	runParseTest(t, YAML, contents, []located{
+		{"[0].name", "prod", Position{StartLineNum: 1, StartIndex: 8, EndLineNum: 1, EndIndex: 12}},
+		{"[0].password", "pr0d-pass", Position{StartLineNum: 2, StartIndex: 12, EndLineNum: 2, EndIndex: 21}},
+		{"[1].name", "staging", Position{StartLineNum: 3, StartIndex: 8, EndLineNum: 3, EndIndex: 15}},
+		{"[1].password", "st4ging-pass", Position{StartLineNum: 4, StartIndex: 12, EndLineNum: 4, EndIndex: 24}},
+		{"token", "t0ken", Position{StartLineNum: 6, StartIndex: 7, EndLineNum: 6, EndIndex: 12}},
+	})
+}
+
+func TestParse_JSON(t *testing.T) {
+	contents := `{
+  "name": "app",
+  "auth": {"token": "a\/b\"c"}
+}`
+	runParseTest(t, JSON, contents, []located{
+		{"name", "app", Position{StartLineNum: 2, StartIndex: 11, EndLineNum: 2, EndIndex: 14}},
+		{"auth.token", `a/b"c`, Position{StartLineNum: 3, StartIndex: 21, EndLineNum: 3, EndIndex: 28}},
+	})
+}
+
+// A package-lock.json of about 1MB, with the same keys and values over and over
+func TestLocate_LargeFile(t *testing.T) {
+	var packages []string
+	for i := 0; i < 5000; i++ {
+		packages = append(packages, fmt.Sprintf(`    "node_modules/pkg-%d": {
+      "version": "1.0.0",
+      "resolved": "https://registry.npmjs.org/pkg-%d/-/pkg-%d-1.0.0.tgz",
+      "integrity": "sha512-%064d",
+      "files": ["index.js", "lib/pkg-%d.js"]
+    }`, i, i, i, i, i))
+	}
+	contents := "{\n  \"packages\": {\n" + strings.Join(packages, ",\n") + "\n  }\n}\n"
+	pairs, err := Parse(JSON, contents)
+	require.NoError(t, err)
+
+	// Fire
+	positions := Locate(contents, pairs)
+
+	require.Len(t, positions, 5*5000)
+	for i, position := range positions {
+		require.NotNil(t, position, pairs[i].PathString())
+	}
+	lastPackageLineNum := 3 + 6*4999
+	require.Equal(t, Position{StartLineNum: lastPackageLineNum + 1, StartIndex: 18, EndLineNum: lastPackageLineNum + 1, EndIndex: 23},
+		withoutOffset(positions[5*4999]))
+	require.Equal(t, Position{StartLineNum: lastPackageLineNum + 4, StartIndex: 29, EndLineNum: lastPackageLineNum + 4, EndIndex: 44},
+		withoutOffset(positions[5*4999+4]))
+}
+
+func withoutOffset(position *Position) Position {
+	return Position{
+		StartLineNum: position.StartLineNum,
+		StartIndex:   position.StartIndex,
+		EndLineNum:   position.EndLineNum,
+		EndIndex:     position.EndIndex,
+	}
+}
+
+func TestParse_TOML(t *testing.T) {
+	contents := `title = "app"
+
+[database]
+password = "t0ml-pass"
+`
+	runParseTest(t, TOML, contents, []located{
+		{"title", "app", Position{StartLineNum: 1, StartIndex: 9, EndLineNum: 1, EndIndex: 12}},
+		{"database.password", "t0ml-pass", Position{StartLineNum: 4, StartIndex: 12, EndLineNum: 4, EndIndex: 21}},
+	})
+}
+
+func TestParse_INI(t *testing.T) {
+	contents := `[client]
+user = admin
+password = 1n1-pass
+`
+	runParseTest(t, INI, contents, []located{
+		{"client.user", "admin", Position{StartLineNum: 2, StartIndex: 7, EndLineNum: 2, EndIndex: 12}},
+		{"client.password", "1n1-pass", Position{StartLineNum: 3, StartIndex: 11, EndLineNum: 3, EndIndex: 19}},
+	})
+}
+
+func TestParse_Properties(t *testing.T) {
+	contents := `db.user=admin
+db.password=pr0p-pass
+`
+	runParseTest(t, Properties, contents, []located{
+		{"db.user", "admin", Position{StartLineNum: 1, StartIndex: 8, EndLineNum: 1, EndIndex: 13}},
+		{"db.password", "pr0p-pass", Position{StartLineNum: 2, StartIndex: 12, EndLineNum: 2, EndIndex: 21}},
+	})
+}
+
+func TestParse_Dotenv(t *testing.T) {
+	contents := `SECRET=3nv-secret
+export API_KEY="3nv-key"
+`
+	runParseTest(t, Dotenv, contents, []located{
+		{"SECRET", "3nv-secret", Position{StartLineNum: 1, StartIndex: 7, EndLineNum: 1, EndIndex: 17}},
+		{"API_KEY", "3nv-key", Position{StartLineNum: 2, StartIndex: 16, EndLineNum: 2, EndIndex: 23}},
+	})
+}
+
+func TestNewFormatFromPath(t *testing.T) {
+	for path, expected := range map[string]Format{
+		"config/app.yml":         YAML,
+		"settings.JSON":          JSON,
+		"Cargo.toml":             TOML,
+		"my.cnf.ini":             INI,
+		"application.properties": Properties,
+		".env":                   Dotenv,
+		"deploy/.env.production": Dotenv,
+		"deploy/production.env":  Dotenv,
+	} {
+
+		// Fire
+		format, ok := NewFormatFromPath(path)
+
+		require.True(t, ok, path)
+		require.Equal(t, expected, format, path)
+	}
+
+	_, ok := NewFormatFromPath("main.go")
+	require.False(t, ok)
+}
@@ -0,0 +1,108 @@
+package structured
+
+import (
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
+)
+
+const KeyPathExtraKey = "structured-key-path"
+
+type Processor struct {
+	name    string
+	formats []Format
+	targets *search.TargetSet
+	log     logg.Logg
+}
+
+func NewProcessor(name string, formats []Format, targets *search.TargetSet, log logg.Logg) *Processor {
+	return &Processor{
+		name:    name,
+		formats: formats,
+		targets: targets,
+		log:     log,
+	}
+}
+
+func (p *Processor) GetName() string {
+	return p.name
+}
+
+// The file is parsed as it is at the commit, and values on added lines are checked
+func (p *Processor) FindResultsInFileChange(job contract.ProcessorJobI) (err error) {
+	fileChange := job.FileChange()
+
+	format, ok := NewFormatFromPath(fileChange.Path)
+	if !ok || !p.supports(format) {
+		return
+	}
+
+	var contents string
+	if contents, err = fileChange.FileContents(); err != nil {
+		return
+	}
+
+	pairs, parseErr := Parse(format, contents)
+	if parseErr != nil {
+		job.Log(p.log).WithError(parseErr).Debug("skipping file that can't be parsed")
+		return
+	}
+
+	// Only the pairs that are targeted are located, locating is the slow part
+	var targeted []*Pair
+	for _, pair := range pairs {
+		if p.targets.Matches(pair.Key(), pair.Value, job.Log(p.log)) {
+			targeted = append(targeted, pair)
+		}
+	}
+
+	added := job.Diff().AddedLineNums()
+	positions := Locate(contents, targeted)
+
+	for i, pair := range targeted {
+		position := positions[i]
+		if position == nil {
+			job.Log(p.log).WithField("keyPath", pair.PathString()).Trace("unable to locate value in file")
+			continue
+		}
+		if !added[position.StartLineNum] {
+			continue
+		}
+
+		job.SearchingLine(position.StartLineNum)
+		job.SubmitResult(&contract.Result{
+			FileRange: &manip.FileRange{
+				StartLineNum: position.StartLineNum,
+				StartIndex:   position.StartIndex,
+				EndLineNum:   position.EndLineNum,
+				EndIndex:     position.EndIndex,
+			},
+			SecretValue: pair.Value,
+			FindingExtras: []*contract.ResultExtra{
+				{
+					Key:    "structured-format",
+					Header: "File format",
+					Value:  format.Value(),
+				},
+				{
+					Key:    KeyPathExtraKey,
+					Header: "Key path",
+					Value:  pair.PathString(),
+					Code:   true,
+				},
+			},
+		})
+	}
+
+	return
+}
+
+func (p *Processor) supports(format Format) bool {
+	for _, supported := range p.formats {
+		if supported == format {
+			return true
+		}
+	}
+	return false
+}
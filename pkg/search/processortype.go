@@ -9,6 +9,14 @@ const (
 	PEM
 	Setter
 	Entropy
+	Keystore
+	JWT
+	DSN
+	K8sSecret
+	Structured
+	Dotfile
+	IaC
+	Notebook
 )
 
 func ProcessorTypes() []ProcessorType {
@@ -17,6 +25,14 @@ func ProcessorTypes() []ProcessorType {
 		PEM,
 		Setter,
 		Entropy,
+		Keystore,
+		JWT,
+		DSN,
+		K8sSecret,
+		Structured,
+		Dotfile,
+		IaC,
+		Notebook,
 	}
 }
 
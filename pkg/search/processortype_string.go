@@ -12,11 +12,19 @@ func _() {
 	_ = x[PEM-1]
 	_ = x[Setter-2]
 	_ = x[Entropy-3]
+	_ = x[Keystore-4]
+	_ = x[JWT-5]
+	_ = x[DSN-6]
+	_ = x[K8sSecret-7]
+	_ = x[Structured-8]
+	_ = x[Dotfile-9]
+	_ = x[IaC-10]
+	_ = x[Notebook-11]
 }
 
-const _ProcessorType_name = "RegexPEMSetterEntropy"
+const _ProcessorType_name = "RegexPEMSetterEntropyKeystoreJWTDSNK8sSecretStructuredDotfileIaCNotebook"
 
-var _ProcessorType_index = [...]uint8{0, 5, 8, 14, 21}
+var _ProcessorType_index = [...]uint8{0, 5, 8, 14, 21, 29, 32, 35, 44, 54, 61, 64, 72}
 
 func (i ProcessorType) String() string {
 	if i < 0 || i >= ProcessorType(len(_ProcessorType_index)-1) {
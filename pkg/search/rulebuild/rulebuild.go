@@ -69,6 +69,8 @@ const (
 	SingleDblColonNoneKey   = SingleDblColonNoQuotePattern + Key + SingleDblNoQuotePattern // 'api_key', "api_key", :api_key, api_key
 	SingleDblQuoteDollarKey = SingleDblDollarQuotePattern + Key + SingleDblNoQuotePattern  // 'api_key', "api_key", $api_key
 	DeclaredStrVarKey       = `\b` + Key + `(?:\s{1,10}string)?`                           // api_key, api_key string
+	TypedStrVarKey          = `\b` + Key + `(?:\s{0,10}:\s{0,10}String\??)?`               // api_key, api_key: String
+	DollarEnvVarKey         = `(?:\$(?:env:)?)?\b` + Key                                   // api_key, $api_key, $env:api_key
 	//BracketVarKey                  = `\[` + VarKey + `]`                                          // [api_key]
 	//BracketSingleQuoteKey          = `\[` + SingleQuoteKey + `]`                                  // ['api_key']
 	//BracketDblQuoteKey             = `\[` + DblQuoteKey + `]`                                     // ["api_key"]
@@ -90,6 +92,7 @@ const (
 	SingleDblQuoteVal     = SingleDblQuotePattern + Val + SingleDblQuotePattern     // 'shhh', "shhh"
 	SingleDblQuoteJustVal = SingleDblNoQuotePattern + Val + SingleDblNoQuotePattern // 'shhh', "shhh", shhh
 	DblTickQuoteVal       = DblTickQuotePattern + Val + DblTickQuotePattern         // "shhh", `shhh`
+	VerbatimDblQuoteVal   = `@?` + DblQu + Val + DblQu                              // "shhh", @"shhh"
 )
 
 //
@@ -171,6 +174,38 @@ func GoExtPaths() []string {
 	return []string{`\.go$`}
 }
 
+func JVMExtPaths() []string {
+	return []string{`\.java$`, `\.kts?$`, `\.scala$`, `\.sc$`}
+}
+
+func PropertiesExtPaths() []string {
+	return []string{`\.properties$`}
+}
+
+func CSharpExtPaths() []string {
+	return []string{`\.cs$`, `\.csx$`}
+}
+
+func DotNetConfigExtPaths() []string {
+	return []string{`\.config$`}
+}
+
+func AppSettingsExtPaths() []string {
+	return []string{`(?:^|/)appsettings(?:\.[\w-]+)?\.json$`, `(?:^|/)secrets\.json$`}
+}
+
+func PowerShellExtPaths() []string {
+	return []string{`\.ps[dm]?1$`}
+}
+
+func HCLExtPaths() []string {
+	return []string{`\.tf$`, `\.tfvars$`, `\.hcl$`, `\.nomad$`}
+}
+
+func DockerfileExtPaths() []string {
+	return []string{`(?:^|/)(?:Dockerfile|Containerfile)(?:\.[\w-]+)?$`, `\.dockerfile$`}
+}
+
 func TemplateExtPaths() []string {
 	return TemplateExts()
 }
@@ -225,6 +260,7 @@ const DashChar = `\-`
 const UnderscoreChar = `\_`
 
 const PeriodChar = `\.`
+const ColonChar = `\:`
 
 const EqChar = `\=`
 
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"context"
 	"sync"
 
 	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
@@ -8,6 +9,9 @@ import (
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
 )
 
+// Results waiting to be written, beyond which searching waits on the writer
+const resultBufferSize = 100
+
 type (
 	JobRunner struct {
 		workers        []*Worker
@@ -17,11 +21,23 @@ type (
 	}
 	jobRunnerState struct {
 		secretCount int
+
+		// Repos with jobs that weren't completed, so they can be searched again
+		incompleteRepoIDs map[string]bool
+
 		jobQueue    chan *Job
-		resultsChan chan *contract.JobResult
+		resultsChan chan *jobOutput
 		jobsWG      *sync.WaitGroup
 		processWG   *sync.WaitGroup
 	}
+
+	// Either a result of the job, or the job being done
+	jobOutput struct {
+		job       *Job
+		result    *contract.JobResult
+		done      bool
+		completed bool
+	}
 )
 
 func NewJobRunner(workers []*Worker, dbResultWriter *dbResultWriter, log logg.Logg) *JobRunner {
@@ -32,12 +48,14 @@ func NewJobRunner(workers []*Worker, dbResultWriter *dbResultWriter, log logg.Lo
 	}
 }
 
-func (r *JobRunner) runJobs(jobs []*Job) {
+// When the context is cancelled, jobs that haven't started are skipped and jobs in progress stop
+// after the commit they're on. What was found so far is still written.
+func (r *JobRunner) runJobs(ctx context.Context, jobs []*Job) {
 	r.resetState(jobs)
 
 	// Start workers hanging on job queue
 	for _, worker := range r.workers {
-		go r.putToWork(worker)
+		go r.putToWork(ctx, worker)
 	}
 
 	// Queue the jobs and start the work
@@ -59,19 +77,25 @@ func (r *JobRunner) runJobs(jobs []*Job) {
 
 // This is run once per worker. The workers all continuously pull from
 // the job queue until it there are no more jobs, then they stop.
-func (r *JobRunner) putToWork(worker *Worker) {
+func (r *JobRunner) putToWork(ctx context.Context, worker *Worker) {
 	for job := range r.jobQueue {
 
-		// Worker performs the job
-		worker.Do(job)
+		// Drain the queue without starting anything once the search is interrupted
+		if ctx.Err() != nil {
+			r.jobsWG.Done()
+			continue
+		}
 
-		// Get job results
-		jobResults := job.GetJobResults()
+		// Results are written as they're found
+		job.StreamResults(func(result *contract.JobResult) {
+			r.resultsChan <- &jobOutput{job: job, result: result}
+		})
 
-		// Write results to results channel for processing
-		for _, jobResult := range jobResults {
-			r.resultsChan <- jobResult
-		}
+		// Worker performs the job
+		completed := worker.Do(ctx, job)
+
+		r.resultsChan <- &jobOutput{job: job, done: true, completed: completed}
+		job.Release()
 
 		// One down, more to go
 		r.jobsWG.Done()
@@ -79,13 +103,28 @@ func (r *JobRunner) putToWork(worker *Worker) {
 }
 
 // This is run in a goroutine to process job results as they come in and write them
-// to the database.
+// to the database. A job is checkpointed after all of its results are written.
 func (r *JobRunner) processJobResults() {
-	for result := range r.resultsChan {
-		if err := r.dbResultWriter.WriteResult(result); err != nil {
-			errors.ErrLog(r.log, err).Error("error writing search result")
+	writeFailed := map[*Job]bool{}
+
+	for output := range r.resultsChan {
+		if !output.done {
+			if err := r.dbResultWriter.WriteResult(output.result); err != nil {
+				errors.ErrLog(r.log, err).Error("error writing search result")
+				writeFailed[output.job] = true
+			}
 			continue
 		}
+
+		failed := writeFailed[output.job]
+		delete(writeFailed, output.job)
+		if !output.completed || failed {
+			r.incompleteRepoIDs[output.job.repoID] = true
+			continue
+		}
+		if err := r.dbResultWriter.WriteCheckpoint(output.job); err != nil {
+			errors.ErrLog(r.log, err).Error("error writing job checkpoint")
+		}
 	}
 
 	r.secretCount = r.dbResultWriter.secretTracker.Len()
@@ -103,10 +142,11 @@ func (r *JobRunner) resetState(jobs []*Job) {
 	processWG.Add(1)
 
 	r.jobRunnerState = &jobRunnerState{
-		secretCount: 0,
-		jobQueue:    make(chan *Job, jobCount),
-		resultsChan: make(chan *contract.JobResult),
-		jobsWG:      jobsWG,
-		processWG:   processWG,
+		secretCount:       0,
+		incompleteRepoIDs: map[string]bool{},
+		jobQueue:          make(chan *Job, jobCount),
+		resultsChan:       make(chan *jobOutput, resultBufferSize),
+		jobsWG:            jobsWG,
+		processWG:         processWG,
 	}
 }
@@ -0,0 +1,146 @@
+package search
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	gitpkg "github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+)
+
+type (
+	// Remembers where each repo's refs pointed when it was last searched, so only commits added
+	// since then are searched. Marks left by a search with a different ruleset are ignored,
+	// so a changed ruleset searches everything again.
+	ScanMarks struct {
+		rulesetVersion string
+		db             *database.Database
+		log            logg.Logg
+		scanMarksState
+	}
+	scanMarksState struct {
+		previous database.ScanMarkGroups
+		pending  database.ScanMarkGroups
+	}
+)
+
+func NewScanMarks(rulesetVersion string, db *database.Database, log logg.Logg) *ScanMarks {
+	return &ScanMarks{
+		rulesetVersion: rulesetVersion,
+		db:             db,
+		log:            log,
+		scanMarksState: scanMarksState{
+			pending: database.ScanMarkGroups{},
+		},
+	}
+}
+
+// Whether an earlier search with the same ruleset left marks, so its results are still good
+func (m *ScanMarks) HasCurrent() (result bool, err error) {
+	if err = m.loadPrevious(); err != nil {
+		return
+	}
+
+	for _, marks := range m.previous {
+		for _, mark := range marks {
+			if mark.RulesetVersion == m.rulesetVersion {
+				return true, nil
+			}
+		}
+	}
+
+	return
+}
+
+// Drops the commits that were searched before, and remembers where the repo's refs point now
+func (m *ScanMarks) NewCommitHashes(repo *database.Repo, repository *gitpkg.Repository, commitHashes []string) (result []string, err error) {
+	if err = m.loadPrevious(); err != nil {
+		return
+	}
+
+	var tips map[string]string
+	if tips, err = repository.RefTips(); err != nil {
+		err = errors.WithMessage(err, "unable to get ref tips")
+		return
+	}
+
+	now := time.Now()
+	m.pending[repo.ID] = nil
+	for ref, commitHash := range tips {
+		m.pending[repo.ID] = append(m.pending[repo.ID], &database.ScanMark{
+			ID:             database.CreateHashID(repo.ID, ref),
+			RepoID:         repo.ID,
+			RepoName:       repo.Name,
+			Ref:            ref,
+			CommitHash:     commitHash,
+			RulesetVersion: m.rulesetVersion,
+			Date:           now,
+		})
+	}
+
+	var markedHashes []string
+	for _, mark := range m.previous[repo.ID] {
+		if mark.RulesetVersion == m.rulesetVersion {
+			markedHashes = append(markedHashes, mark.CommitHash)
+		}
+	}
+	if len(markedHashes) == 0 {
+		m.log.WithField("repo", repo.Name).Debug("no scan marks for the current ruleset, searching all commits")
+		return commitHashes, nil
+	}
+	sort.Strings(markedHashes)
+
+	var searched map[string]bool
+	if searched, err = repository.AncestorHashes(markedHashes); err != nil {
+		err = errors.WithMessage(err, "unable to get commits that were already searched")
+		return
+	}
+
+	for _, commitHash := range commitHashes {
+		if !searched[commitHash] {
+			result = append(result, commitHash)
+		}
+	}
+
+	m.log.WithField("repo", repo.Name).Debugf("%d of %d commits were added since the last search", len(result), len(commitHashes))
+
+	return
+}
+
+// The repo's marks stay where they were, so the commits it wasn't completely searched for are searched again
+func (m *ScanMarks) Keep(repoID string) {
+	delete(m.pending, repoID)
+}
+
+// Replaces the marks of the repos that were searched with where their refs pointed when the search started
+func (m *ScanMarks) Save() (err error) {
+	for repoID, marks := range m.pending {
+		for _, prevMark := range m.previous[repoID] {
+			if err = m.db.DeleteScanMark(prevMark.ID); err != nil {
+				return errors.WithMessagev(err, "unable to delete scan mark", prevMark.ID)
+			}
+		}
+
+		for _, mark := range marks {
+			if err = m.db.WriteScanMark(mark); err != nil {
+				return errors.WithMessagev(err, "unable to write scan mark", mark.ID)
+			}
+		}
+	}
+
+	return
+}
+
+func (m *ScanMarks) loadPrevious() (err error) {
+	if m.previous != nil {
+		return
+	}
+
+	if m.previous, err = m.db.GetScanMarksGroupedByRepoID(); err != nil {
+		err = errors.WithMessage(err, "unable to get scan marks")
+	}
+
+	return
+}
@@ -0,0 +1,117 @@
+package search_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/git"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	gitvendor "gopkg.in/src-d/go-git.v4"
+	gitobject "gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func scanMarksCommit(t *testing.T, worktree *gitvendor.Worktree, cloneDir, contents string) string {
+	require.NoError(t, ioutil.WriteFile(filepath.Join(cloneDir, "file.txt"), []byte(contents), 0644))
+	_, err := worktree.Add("file.txt")
+	require.NoError(t, err)
+
+	hash, err := worktree.Commit(contents, &gitvendor.CommitOptions{
+		Author: &gitobject.Signature{Name: "name", Email: "name@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	return hash.String()
+}
+
+func TestScanMarks_NewCommitHashes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scan-marks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log := logg.NewLogrusLogg(logrus.New())
+	db, err := database.New(filepath.Join(dir, "db"), log)
+	require.NoError(t, err)
+
+	// Repo with two commits that were searched before
+	cloneDir := filepath.Join(dir, "repo")
+	gitRepo, err := gitvendor.PlainInit(cloneDir, false)
+	require.NoError(t, err)
+	worktree, err := gitRepo.Worktree()
+	require.NoError(t, err)
+	first := scanMarksCommit(t, worktree, cloneDir, "first")
+	second := scanMarksCommit(t, worktree, cloneDir, "second")
+	repository, err := git.New(nil, log).OpenRepository(cloneDir)
+	require.NoError(t, err)
+	repo := &database.Repo{ID: "repoID", Name: "repo"}
+
+	previous := NewScanMarks("rulesetVersion", db, log)
+	previousHashes, err := previous.NewCommitHashes(repo, repository, []string{second, first})
+	require.NoError(t, err)
+	require.Equal(t, []string{second, first}, previousHashes)
+	require.NoError(t, previous.Save())
+
+	// A commit is added
+	third := scanMarksCommit(t, worktree, cloneDir, "third")
+	commitHashes := []string{third, second, first}
+
+	// Fire
+	subject := NewScanMarks("rulesetVersion", db, log)
+	hasCurrent, err := subject.HasCurrent()
+	require.NoError(t, err)
+	result, err := subject.NewCommitHashes(repo, repository, commitHashes)
+	require.NoError(t, err)
+	otherRuleset := NewScanMarks("otherRulesetVersion", db, log)
+	otherHasCurrent, err := otherRuleset.HasCurrent()
+	require.NoError(t, err)
+	otherResult, err := otherRuleset.NewCommitHashes(repo, repository, commitHashes)
+	require.NoError(t, err)
+
+	require.True(t, hasCurrent)
+	require.Equal(t, []string{third}, result)
+	require.False(t, otherHasCurrent)
+	require.Equal(t, commitHashes, otherResult)
+}
+
+// A repo that wasn't completely searched keeps its marks, so its new commits are searched again
+func TestScanMarks_Keep(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scan-marks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log := logg.NewLogrusLogg(logrus.New())
+	db, err := database.New(filepath.Join(dir, "db"), log)
+	require.NoError(t, err)
+	cloneDir := filepath.Join(dir, "repo")
+	gitRepo, err := gitvendor.PlainInit(cloneDir, false)
+	require.NoError(t, err)
+	worktree, err := gitRepo.Worktree()
+	require.NoError(t, err)
+	first := scanMarksCommit(t, worktree, cloneDir, "first")
+	repository, err := git.New(nil, log).OpenRepository(cloneDir)
+	require.NoError(t, err)
+	repo := &database.Repo{ID: "repoID", Name: "repo"}
+	previous := NewScanMarks("rulesetVersion", db, log)
+	_, err = previous.NewCommitHashes(repo, repository, []string{first})
+	require.NoError(t, err)
+	require.NoError(t, previous.Save())
+	second := scanMarksCommit(t, worktree, cloneDir, "second")
+	incomplete := NewScanMarks("rulesetVersion", db, log)
+	_, err = incomplete.NewCommitHashes(repo, repository, []string{second, first})
+	require.NoError(t, err)
+
+	// Fire
+	incomplete.Keep(repo.ID)
+
+	require.NoError(t, incomplete.Save())
+	subject := NewScanMarks("rulesetVersion", db, log)
+	result, err := subject.NewCommitHashes(repo, repository, []string{second, first})
+	require.NoError(t, err)
+	require.Equal(t, []string{second}, result)
+}
@@ -166,6 +166,49 @@ func (s *Scope) StartLine(line int) {
 	s.LineScope = &LineScope{Line: line, start: now}
 }
 
+// A copy of the scope at its current commit, so one of the commit's file changes can be searched on its own
+func (s *Scope) ForkCommit() *Scope {
+	if !s.hasCommit() {
+		panic("can't fork a commit scope from here")
+	}
+
+	return &Scope{
+		RepoScope: &RepoScope{
+			Repo:  s.Repo,
+			start: s.RepoScope.start,
+			RepoJobScope: &RepoJobScope{
+				RepoJob: s.RepoJob,
+				start:   s.RepoJobScope.start,
+				CommitScope: &CommitScope{
+					Commit: s.Commit,
+					start:  s.CommitScope.start,
+				},
+			},
+		},
+		enableProfiling: s.enableProfiling,
+		stats:           s.stats,
+	}
+}
+
+func (s *Scope) FinishFileChange() {
+	if !s.hasFileChange() {
+		panic("can't finish file change scope from here")
+	}
+
+	if !s.enableProfiling {
+		s.FileChangeScope = nil
+		return
+	}
+
+	now := time.Now()
+
+	s.profileLine(now)
+	s.profileProc(now)
+	s.profileFileChange(now)
+
+	s.FileChangeScope = nil
+}
+
 func (s *Scope) FinishRepo() {
 	now := time.Now()
 
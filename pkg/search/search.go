@@ -1,6 +1,7 @@
 package search
 
 import (
+	"context"
 	"time"
 
 	"github.com/pantheon-systems/secrets-searcher/pkg/database"
@@ -13,17 +14,20 @@ import (
 type Search struct {
 	jobBuilder *JobBuilder
 	jobRunner  *JobRunner
+	scanMarks  *ScanMarks
 	interact   *interactpkg.Interact
 	stats      *stats.Stats
 	db         *database.Database
 	log        logg.Logg
 }
 
-func New(jobBuilder *JobBuilder, jobRunner *JobRunner, interact *interactpkg.Interact, stats *stats.Stats, db *database.Database, log logg.Logg) *Search {
+// Scan marks are optional, they're saved after a search completes when they're set
+func New(jobBuilder *JobBuilder, jobRunner *JobRunner, scanMarks *ScanMarks, interact *interactpkg.Interact, stats *stats.Stats, db *database.Database, log logg.Logg) *Search {
 
 	return &Search{
 		jobBuilder: jobBuilder,
 		jobRunner:  jobRunner,
+		scanMarks:  scanMarks,
 		interact:   interact,
 		stats:      stats,
 		db:         db,
@@ -31,7 +35,8 @@ func New(jobBuilder *JobBuilder, jobRunner *JobRunner, interact *interactpkg.Int
 	}
 }
 
-func (s *Search) Search() (err error) {
+// Stops early when the context is cancelled, what was completed can be resumed
+func (s *Search) Search(ctx context.Context) (err error) {
 	s.stats.SearchStartTime = time.Now()
 
 	s.log.Info("finding secrets ...")
@@ -49,23 +54,48 @@ func (s *Search) Search() (err error) {
 	commitsLen := countCommits(jobs)
 
 	// Run jobs
-	s.jobRunner.runJobs(jobs)
+	s.jobRunner.runJobs(ctx, jobs)
 
 	// End the progress bar and resume logging to stdout
 	if jobProg != nil {
 		jobProg.Wait()
 	}
 
-	s.log.Info("completed search")
-
 	// Stats
 	s.stats.CommitsSearchedCount = int64(commitsLen)
 	s.stats.SecretsFoundCount = int64(s.jobRunner.secretCount)
 	s.stats.SearchEndTime = time.Now()
 
+	if ctx.Err() != nil {
+		err = errors.WithMessage(ctx.Err(), "search was interrupted, run it again with --resume to continue")
+		return
+	}
+
+	s.log.Info("completed search")
+
+	// The next search can start where this one ended
+	if s.scanMarks != nil {
+		for repoID := range s.jobRunner.incompleteRepoIDs {
+			s.scanMarks.Keep(repoID)
+		}
+		if err = s.scanMarks.Save(); err != nil {
+			err = errors.WithMessage(err, "unable to save scan marks")
+			return
+		}
+	}
+
 	return
 }
 
+// Results from earlier searches are kept when only new commits are being searched
+func (s *Search) KeepsPreviousResults() (result bool, err error) {
+	if s.scanMarks == nil {
+		return
+	}
+
+	return s.scanMarks.HasCurrent()
+}
+
 func countCommits(jobs []*Job) (result int) {
 	for _, j := range jobs {
 		result += len(j.commitHashes)
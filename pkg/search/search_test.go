@@ -0,0 +1,70 @@
+package search_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/git"
+	interactpkg "github.com/pantheon-systems/secrets-searcher/pkg/interact"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	"github.com/pantheon-systems/secrets-searcher/pkg/manip"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/pantheon-systems/secrets-searcher/pkg/stats"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	gitvendor "gopkg.in/src-d/go-git.v4"
+)
+
+func resumableSearch(sourceDir string, resume bool, db *database.Database, log logg.Logg) (*Search, *stats.Stats) {
+	st := stats.New()
+	interact := interactpkg.New(false, log)
+	worker := NewWorker(nil, nil, nil, nil, nil, nil, log)
+	jobBuilder := NewJobBuilder(manip.NewSliceFilter(manip.StringSet([]string{"repo"}), nil), sourceDir, git.NewCommitFilter(nil, time.Time{}, time.Time{}, true), 1, 1, false, false, resume, git.New(nil, log), nil, interact, st, db, log)
+	jobRunner := NewJobRunner([]*Worker{worker}, NewDBResultWriter(db, log), log)
+
+	return New(jobBuilder, jobRunner, nil, interact, st, db, log), st
+}
+
+func TestSearch_Resume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "search")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log := logg.NewLogrusLogg(logrus.New())
+	db, err := database.New(filepath.Join(dir, "db"), log)
+	require.NoError(t, err)
+
+	// Repo with three commits, searched one commit per job
+	sourceDir := filepath.Join(dir, "source")
+	cloneDir := filepath.Join(sourceDir, "repo")
+	gitRepo, err := gitvendor.PlainInit(cloneDir, false)
+	require.NoError(t, err)
+	worktree, err := gitRepo.Worktree()
+	require.NoError(t, err)
+	for _, contents := range []string{"first", "second", "third"} {
+		scanMarksCommit(t, worktree, cloneDir, contents)
+	}
+	require.NoError(t, db.WriteRepo(&database.Repo{ID: "repoID", Name: "repo"}))
+
+	interrupted, _ := resumableSearch(sourceDir, false, db, log)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	interruptedErr := interrupted.Search(ctx)
+
+	// Fire
+	resumed, resumedStats := resumableSearch(sourceDir, true, db, log)
+	resumedErr := resumed.Search(context.Background())
+	completed, completedStats := resumableSearch(sourceDir, true, db, log)
+	completedErr := completed.Search(context.Background())
+
+	require.Error(t, interruptedErr)
+	require.NoError(t, resumedErr)
+	require.Equal(t, int64(3), resumedStats.CommitsSearchedCount)
+	require.NoError(t, completedErr)
+	require.Equal(t, int64(0), completedStats.CommitsSearchedCount)
+}
@@ -125,7 +125,7 @@ func CoreProcessor(name builtin.ProcessorName, targets *search.TargetSet) (resul
 	procConfig := builtin.ProcessorConfig(name)
 
 	var err error
-	result, err = build.Proc(procConfig, targets, Log())
+	result, err = build.Proc(procConfig, targets, nil, Log())
 	if err != nil {
 		panic("error building processor: " + procConfig.Name)
 	}
@@ -137,7 +137,7 @@ func CustomProcessor(procConfig *config.ProcessorConfig, targets *search.TargetS
 	PrepareConfig(procConfig)
 
 	var err error
-	result, err = build.Proc(procConfig, targets, Log())
+	result, err = build.Proc(procConfig, targets, nil, Log())
 	if err != nil {
 		panic("error building processor: " + procConfig.Name)
 	}
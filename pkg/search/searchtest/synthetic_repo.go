@@ -0,0 +1,344 @@
+package searchtest
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	gitvendor "gopkg.in/src-d/go-git.v4"
+	gitobject "gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+//
+// Synthetic repositories
+
+// Commit dates start here, so the same config always builds the same commit hashes
+var syntheticRepoStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type (
+	SyntheticRepoConfig struct {
+		Seed         int64
+		CommitCount  int
+		FileCount    int
+		LinesPerEdit int
+		SecretCount  int
+		// Names from SyntheticLanguages, all of them when it's empty
+		Languages []string
+	}
+
+	// A secret added to the repo, which the builtin processors should find
+	PlantedSecret struct {
+		Kind       string
+		CommitHash string
+		Path       string
+		Value      string
+	}
+
+	SyntheticRepo struct {
+		CloneDir     string
+		CommitHashes []string
+		Secrets      []*PlantedSecret
+	}
+
+	syntheticLanguage struct {
+		ext     string
+		filler  []string
+		secrets []string
+	}
+
+	secretKind struct {
+		name  string
+		value func(rng *rand.Rand) string
+
+		// Secrets that need a file of their own are committed in one, otherwise they're set in a line of code
+		file func(value string) (name, contents string)
+	}
+)
+
+// Filler lines take an identifier and a number, secret lines take the secret
+var syntheticLanguages = map[string]*syntheticLanguage{
+	"go": {
+		ext: ".go",
+		filler: []string{
+			"\t%s := compute(%d)",
+			"\tif %s > %d {\n\t\treturn nil\n\t}",
+			"\tlog.Printf(\"%s: %%d\", %d)",
+		},
+		secrets: []string{"\taccessKey := \"%s\"", "\tconst apiToken = \"%s\""},
+	},
+	"python": {
+		ext: ".py",
+		filler: []string{
+			"    %s = compute(%d)",
+			"    if %s > %d:\n        return None",
+			"    logging.info(\"%s: %%d\", %d)",
+		},
+		secrets: []string{"    access_key = \"%s\"", "API_TOKEN = '%s'"},
+	},
+	"javascript": {
+		ext: ".js",
+		filler: []string{
+			"  const %s = compute(%d);",
+			"  if (%s > %d) {\n    return null;\n  }",
+			"  console.log('%s', %d);",
+		},
+		secrets: []string{"  const accessKey = \"%s\";", "  apiToken: '%s',"},
+	},
+	"php": {
+		ext: ".php",
+		filler: []string{
+			"    $%s = compute(%d);",
+			"    if ($%s > %d) {\n        return null;\n    }",
+			"    error_log('%s: ' . %d);",
+		},
+		secrets: []string{"    $accessKey = \"%s\";", "    define('API_TOKEN', '%s');"},
+	},
+	"yaml": {
+		ext: ".yaml",
+		filler: []string{
+			"%s: %d",
+			"%s:\n  enabled: true\n  size: %d",
+			"# %s is set to %d by default",
+		},
+		secrets: []string{"access_key: \"%s\"", "api_token: '%s'"},
+	},
+}
+
+// Secrets in formats the builtin processors know, at least one for each type of processor that can score
+var secretKinds = []*secretKind{
+	{name: "aws-access-key-id", value: func(rng *rand.Rand) string { return "AKIA" + randomString(rng, upperDigits, 16) }},
+	{name: "slack-token", value: func(rng *rand.Rand) string {
+		return fmt.Sprintf("xoxb-%s-%s-%s-%s", randomString(rng, digits, 12), randomString(rng, digits, 12),
+			randomString(rng, digits, 12), randomString(rng, lowerDigits, 32))
+	}},
+	{name: "stripe-key", value: func(rng *rand.Rand) string { return "sk_live_" + randomString(rng, alphaDigits, 24) }},
+	{name: "sendgrid-key", value: func(rng *rand.Rand) string {
+		return "SG." + randomString(rng, alphaDigits, 22) + "." + randomString(rng, alphaDigits, 43)
+	}},
+	{name: "jwt", value: func(rng *rand.Rand) string {
+		encode := base64.RawURLEncoding.EncodeToString
+		claims := fmt.Sprintf(`{"sub":"svc-%s","iat":1577836800}`, randomString(rng, lowerDigits, 8))
+		return encode([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encode([]byte(claims)) + "." + randomString(rng, alphaDigits, 43)
+	}},
+	{name: "dsn-password", value: func(rng *rand.Rand) string { return randomString(rng, alphaDigits, 20) },
+		file: func(value string) (string, string) {
+			return "database.env", fmt.Sprintf("DATABASE_URL=postgres://app:%s@db.internal:5432/app\n", value)
+		}},
+	{name: "pem-private-key", value: func(rng *rand.Rand) string {
+		return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: randomBytes(rng, 300)}))
+	}, file: func(value string) (string, string) { return "id_ecdsa", value }},
+	{name: "k8s-secret", value: func(rng *rand.Rand) string { return randomString(rng, alphaDigits, 24) },
+		file: func(value string) (string, string) {
+			return "secret.yaml", "apiVersion: v1\nkind: Secret\nmetadata:\n  name: app\ntype: Opaque\ndata:\n" +
+				"  db-password: " + base64.StdEncoding.EncodeToString([]byte(value)) + "\n"
+		}},
+	{name: "npm-token", value: func(rng *rand.Rand) string { return "npm_" + randomString(rng, alphaDigits, 36) },
+		file: func(value string) (string, string) {
+			return ".npmrc", "registry=https://registry.npmjs.org/\n//registry.npmjs.org/:_authToken=" + value + "\n"
+		}},
+	{name: "terraform-state-password", value: func(rng *rand.Rand) string { return randomString(rng, alphaDigits, 20) },
+		file: func(value string) (string, string) {
+			return "terraform.tfstate", `{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_db_instance",
+      "name": "main",
+      "instances": [{"attributes": {"identifier": "main", "password": "` + value + `"}}]
+    }
+  ]
+}
+`
+		}},
+}
+
+const (
+	digits      = "0123456789"
+	upperDigits = "ABCDEFGHIJKLMNOPQRSTUVWXYZ" + digits
+	lowerDigits = "abcdefghijklmnopqrstuvwxyz" + digits
+	alphaDigits = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ" + digits
+)
+
+var syntheticWords = []string{"count", "total", "index", "offset", "limit", "retries", "width", "height", "score", "weight"}
+
+func SyntheticLanguages() (result []string) {
+	for name := range syntheticLanguages {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return
+}
+
+func SecretKinds() (result []string) {
+	for _, kind := range secretKinds {
+		result = append(result, kind.name)
+	}
+	return
+}
+
+// Builds a git repository in the clone dir. Each commit inserts lines into one of the files,
+// and the secrets are planted in random commits. The same config always builds the same repo.
+func NewSyntheticRepo(cloneDir string, cfg *SyntheticRepoConfig) (result *SyntheticRepo, err error) {
+	if cfg.CommitCount < 1 || cfg.FileCount < 1 {
+		err = errors.New("a synthetic repo needs at least one commit and one file")
+		return
+	}
+
+	languageNames := cfg.Languages
+	if len(languageNames) == 0 {
+		languageNames = SyntheticLanguages()
+	}
+	var languages []*syntheticLanguage
+	for _, name := range languageNames {
+		language, ok := syntheticLanguages[name]
+		if !ok {
+			err = errors.Errorv("unknown synthetic repo language", name)
+			return
+		}
+		languages = append(languages, language)
+	}
+
+	linesPerEdit := cfg.LinesPerEdit
+	if linesPerEdit < 1 {
+		linesPerEdit = 1
+	}
+
+	var gitRepo *gitvendor.Repository
+	if gitRepo, err = gitvendor.PlainInit(cloneDir, false); err != nil {
+		err = errors.Wrapv(err, "unable to init repo", cloneDir)
+		return
+	}
+	var worktree *gitvendor.Worktree
+	if worktree, err = gitRepo.Worktree(); err != nil {
+		err = errors.Wrap(err, "unable to get worktree")
+		return
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+
+	// Files are spread over the languages and a few directories
+	paths := make([]string, cfg.FileCount)
+	fileLanguages := make([]*syntheticLanguage, cfg.FileCount)
+	fileLines := make([][]string, cfg.FileCount)
+	for i := range paths {
+		language := languages[i%len(languages)]
+		paths[i] = filepath.Join(fmt.Sprintf("dir%d", i%8), fmt.Sprintf("file%d%s", i, language.ext))
+		fileLanguages[i] = language
+	}
+
+	// Commits that get a secret, more than one when there are more secrets than commits
+	secretsByCommit := make([]int, cfg.CommitCount)
+	for i := 0; i < cfg.SecretCount; i++ {
+		secretsByCommit[rng.Intn(cfg.CommitCount)]++
+	}
+
+	result = &SyntheticRepo{CloneDir: cloneDir}
+	for i := 0; i < cfg.CommitCount; i++ {
+		fileIndex := rng.Intn(cfg.FileCount)
+		path := paths[fileIndex]
+		language := fileLanguages[fileIndex]
+
+		// Filler lines
+		var added []string
+		for j := 0; j < linesPerEdit; j++ {
+			tmpl := language.filler[rng.Intn(len(language.filler))]
+			word := syntheticWords[rng.Intn(len(syntheticWords))]
+			added = append(added, fmt.Sprintf(tmpl, fmt.Sprintf("%s%d", word, rng.Intn(100)), rng.Intn(1000)))
+		}
+
+		// Secret lines, mixed in with the filler, and secret files next to the file
+		files := map[string]string{}
+		var planted []*PlantedSecret
+		for j := 0; j < secretsByCommit[i]; j++ {
+			kind := secretKinds[rng.Intn(len(secretKinds))]
+			value := kind.value(rng)
+			if kind.file != nil {
+				name, contents := kind.file(value)
+				secretPath := filepath.Join(filepath.Dir(path), fmt.Sprintf("secrets%d-%d", i, j), name)
+				files[secretPath] = contents
+				planted = append(planted, &PlantedSecret{Kind: kind.name, Path: secretPath, Value: value})
+				continue
+			}
+			line := fmt.Sprintf(language.secrets[rng.Intn(len(language.secrets))], value)
+			at := rng.Intn(len(added) + 1)
+			added = append(added[:at], append([]string{line}, added[at:]...)...)
+			planted = append(planted, &PlantedSecret{Kind: kind.name, Path: path, Value: value})
+		}
+
+		// Insert the lines somewhere in the file
+		lines := fileLines[fileIndex]
+		at := rng.Intn(len(lines) + 1)
+		fileLines[fileIndex] = append(lines[:at:at], append(added, lines[at:]...)...)
+		files[path] = strings.Join(fileLines[fileIndex], "\n") + "\n"
+
+		var hash string
+		if hash, err = syntheticCommit(worktree, cloneDir, files, i); err != nil {
+			err = errors.WithMessagev(err, "unable to commit to synthetic repo", cloneDir)
+			return
+		}
+
+		result.CommitHashes = append(result.CommitHashes, hash)
+		for _, secret := range planted {
+			secret.CommitHash = hash
+			result.Secrets = append(result.Secrets, secret)
+		}
+	}
+
+	return
+}
+
+func syntheticCommit(worktree *gitvendor.Worktree, cloneDir string, files map[string]string, commitNum int) (result string, err error) {
+	for path, contents := range files {
+		filePath := filepath.Join(cloneDir, path)
+		if err = os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			err = errors.Wrap(err, "unable to create directory")
+			return
+		}
+		if err = ioutil.WriteFile(filePath, []byte(contents), 0644); err != nil {
+			err = errors.Wrap(err, "unable to write file")
+			return
+		}
+		if _, err = worktree.Add(path); err != nil {
+			err = errors.Wrap(err, "unable to add file")
+			return
+		}
+	}
+
+	signature := &gitobject.Signature{
+		Name:  "Synthetic Author",
+		Email: "author@example.com",
+		When:  syntheticRepoStart.Add(time.Duration(commitNum) * time.Hour),
+	}
+	hash, err := worktree.Commit(fmt.Sprintf("Commit %d", commitNum), &gitvendor.CommitOptions{Author: signature})
+	if err != nil {
+		err = errors.Wrap(err, "unable to commit")
+		return
+	}
+
+	return hash.String(), nil
+}
+
+func randomBytes(rng *rand.Rand, length int) []byte {
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = byte(rng.Intn(256))
+	}
+	return result
+}
+
+func randomString(rng *rand.Rand, chars string, length int) string {
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = chars[rng.Intn(len(chars))]
+	}
+	return string(result)
+}
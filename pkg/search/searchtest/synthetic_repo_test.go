@@ -0,0 +1,34 @@
+package searchtest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search/searchtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSyntheticRepo_SameSeed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "synthetic")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cfg := &SyntheticRepoConfig{Seed: 7, CommitCount: 10, FileCount: 4, LinesPerEdit: 3, SecretCount: 12}
+
+	// Fire
+	first, err := NewSyntheticRepo(filepath.Join(dir, "first"), cfg)
+	require.NoError(t, err)
+	second, err := NewSyntheticRepo(filepath.Join(dir, "second"), cfg)
+	require.NoError(t, err)
+
+	require.Len(t, first.CommitHashes, 10)
+	require.Equal(t, first.CommitHashes, second.CommitHashes)
+	require.Equal(t, first.Secrets, second.Secrets)
+
+	// Another seed, another repo
+	cfg.Seed = 8
+	other, err := NewSyntheticRepo(filepath.Join(dir, "other"), cfg)
+	require.NoError(t, err)
+	require.NotEqual(t, first.CommitHashes, other.CommitHashes)
+}
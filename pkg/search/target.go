@@ -164,6 +164,10 @@ func NewTargetSet(targets []*Target) (tt *TargetSet) {
 	return tt
 }
 
+func (tt *TargetSet) KeyRegexp() *regexp.Regexp {
+	return tt.keyRe
+}
+
 func (tt *TargetSet) KeyPatterns() (result []string) {
 	set := manip.NewEmptyBasicSet()
 	for _, target := range tt.Targets {
@@ -1,6 +1,9 @@
 package search
 
 import (
+	"context"
+	"sync"
+
 	"github.com/pantheon-systems/secrets-searcher/pkg/dev"
 	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
 	gitpkg "github.com/pantheon-systems/secrets-searcher/pkg/git"
@@ -12,78 +15,194 @@ type Worker struct {
 	processors       []contract.ProcessorI
 	targets          []contract.ProcessorI
 	fileChangeFilter *gitpkg.FileChangeFilter
+	blobCache        *BlobCache
+	prefilter        *Prefilter
+	pool             *FileChangePool
+	limiter          *Limiter
 	log              logg.Logg
 }
 
-func NewWorker(processors []contract.ProcessorI, fileChangeFilter *gitpkg.FileChangeFilter, log logg.Logg) *Worker {
+// Blob cache is optional, every file change is searched when it's nil
+// Prefilter is optional, line processors search every line when it's nil
+// Pool is optional, a commit's file changes are searched one after another when it's nil
+// Limiter is optional, there are no limits on lines or search time when it's nil
+func NewWorker(processors []contract.ProcessorI, fileChangeFilter *gitpkg.FileChangeFilter, blobCache *BlobCache, prefilter *Prefilter, pool *FileChangePool, limiter *Limiter, log logg.Logg) *Worker {
 
 	return &Worker{
 		processors:       processors,
 		fileChangeFilter: fileChangeFilter,
+		blobCache:        blobCache,
+		prefilter:        prefilter,
+		pool:             pool,
+		limiter:          limiter,
 		log:              log,
 	}
 }
 
-func (w *Worker) Do(job contract.WorkerJobI) {
+// Stops between commits when the context is cancelled, in which case the job isn't completed.
+// A commit that can't be searched doesn't stop the others, but the job isn't completed either,
+// so it's searched again.
+func (w *Worker) Do(ctx context.Context, job contract.WorkerJobI) (completed bool) {
 	var commits []*gitpkg.Commit
 	var err error
 	commits, err = job.Start()
 	if err != nil {
 		return
 	}
+	defer job.Finish()
 
+	failed := false
 	for _, commit := range commits {
+		if ctx.Err() != nil {
+			job.Log(w.log).Warn("search was interrupted, job is incomplete")
+			return
+		}
+
 		job.SearchingCommit(commit)
 
 		if err = w.findInCommit(job, commit); err != nil {
 			errors.ErrLog(job.Log(w.log), err).Error("error while processing commit")
+			failed = true
 		}
 	}
 
-	job.Finish()
-
-	return
+	return !failed
 }
 
 func (w *Worker) findInCommit(job contract.WorkerJobI, commit *gitpkg.Commit) (err error) {
 	defer job.Increment()
-	defer errors.CatchPanicDo(func(err error) { job.Log(w.log).Error(err, "error during commit search") })
+	defer errors.CatchPanicDo(func(panicErr error) {
+		job.Log(w.log).Error(panicErr, "error during commit search")
+		err = panicErr
+	})
 
 	job.Log(w.log).WithField("commitDate", commit.Date.Format("2006-01-02")).
 		Debug("searching commit")
 
-	var fileChanges []*gitpkg.FileChange
-	fileChanges, err = w.getFileChangesForCommit(job, commit)
+	// The commit is done when all of its file changes are
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	err = w.eachFileChangeInCommit(job, commit, func(change *gitpkg.FileChange) error {
+		fileChangeJob := job.ForFileChange(change)
+
+		if w.pool == nil {
+			w.searchFileChange(fileChangeJob)
+			return nil
+		}
+
+		w.pool.Go(wg, func() { w.searchFileChange(fileChangeJob) })
+		return nil
+	})
 	if err != nil {
 		err = errors.WithMessage(err, "unable to get file changes")
 		return
 	}
 
-	for _, change := range fileChanges {
-		job.SearchingFileChange(change)
+	return
+}
 
-		err = w.findInFileChange(job)
-		if err != nil {
-			err = errors.WithMessage(err, "unable to find in file change")
-			return
+func (w *Worker) searchFileChange(job contract.WorkerJobI) {
+	defer job.FinishFileChange()
+
+	err := w.findInFileChange(job)
+	if err != nil && errors.WasCausedBy(err, contract.ErrDeadlineExceeded) && w.limiter != nil {
+		w.limiter.SkippedDeadline(job)
+		return
+	}
+	if err != nil {
+		errors.ErrLog(job.Log(w.log), err).Error("unable to find in file change")
+	}
+}
+
+func (w *Worker) findInFileChange(job contract.WorkerJobI) (err error) {
+	defer errors.CatchPanicDo(func(err error) { job.Log(w.log).Error(err, "error during file change search") })
+
+	fileChange := job.FileChange()
+	isBinary := fileChange.IsBinaryOrEmpty || !fileChange.HasCodeChanges()
+
+	// Larger than the max blob size, so there's nothing to search
+	if fileChange.TooLarge {
+		if w.limiter != nil {
+			w.limiter.SkippedTooLarge(job)
 		}
+		return
+	}
+
+	// Not in the clone, so there's nothing to search
+	if fileChange.NotFetched {
+		if w.limiter != nil {
+			w.limiter.SkippedNotFetched(job)
+		}
+		return
+	}
+
+	if w.limiter != nil {
+		w.limiter.Apply(job)
+	}
+
+	if w.blobCache == nil || fileChange.BlobHash == "" {
+		return w.findWithProcessors(job, nil, isBinary, false)
+	}
+
+	// If the blob was searched before, what was found is submitted again and only
+	// the processors that have to run are run
+	var searchedLines map[int]bool
+	if !isBinary {
+		searchedLines = job.Diff().AddedLineNums()
+	}
+	if w.blobCache.Replay(job, searchedLines, isBinary) {
+		return w.findWithProcessors(job, nil, isBinary, true)
+	}
+
+	recorder := w.blobCache.Recorder(job)
+	if err = w.findWithProcessors(job, recorder, isBinary, false); err != nil {
+		return
+	}
+
+	if saveErr := w.blobCache.Save(recorder, searchedLines, isBinary); saveErr != nil {
+		errors.ErrLog(job.Log(w.log), saveErr).Warn("unable to save blob scan")
 	}
 
 	return
 }
 
-func (w *Worker) findInFileChange(job contract.WorkerJobI) (err error) {
-	defer errors.CatchPanicDo(func(err error) { job.Log(w.log).Error(err, "error during file change search") })
+func (w *Worker) findWithProcessors(job contract.WorkerJobI, recorder *BlobScanRecorder, isBinary, uncachedOnly bool) (err error) {
+	fileChange := job.FileChange()
+
+	// One pass over the file change finds the lines each line processor has to search
+	var hits *PrefilterHits
+	if w.prefilter != nil && !isBinary {
+		hits = w.prefilter.Scan(fileChange)
+	}
 
 	for _, proc := range w.processors {
 		procName := proc.GetName()
-		path := job.FileChange().Path
+		path := fileChange.Path
+
+		if isBinary && !acceptsBinary(proc, fileChange) {
+			continue
+		}
+		if uncachedOnly && !runsUncached(proc) {
+			continue
+		}
+
+		// Processors that run uncached aren't recorded, or what they submit would be submitted twice
+		var procJob contract.ProcessorJobI = job
+		if recorder != nil && !runsUncached(proc) {
+			procJob = recorder
+		}
 
 		job.SearchingWithProcessor(proc)
-		job.Diff().SetLine(1)
+		if job.DeadlineExceeded() {
+			return contract.ErrDeadlineExceeded
+		}
+		if !isBinary {
+			job.Diff().SetLine(1)
+		}
 		dev.BreakpointInProcessor(path, procName, -1)
 
-		err = proc.FindResultsInFileChange(job)
+		err = w.findWithProcessor(procJob, proc, hits)
 		if err != nil {
 			err = errors.WithMessagev(err, "unable to search in file change using processor", procName)
 			return
@@ -93,12 +212,43 @@ func (w *Worker) findInFileChange(job contract.WorkerJobI) (err error) {
 	return
 }
 
-func (w *Worker) getFileChangesForCommit(job contract.WorkerJobI, commit *gitpkg.Commit) (result []*gitpkg.FileChange, err error) {
+func (w *Worker) findWithProcessor(job contract.ProcessorJobI, proc contract.ProcessorI, hits *PrefilterHits) (err error) {
+	if lineProcsRunner, ok := proc.(contract.RunsLineProcessorsI); ok && hits != nil {
+		return lineProcsRunner.FindResultsInPrefilteredFileChange(job, hits.Lines)
+	}
+
+	wrapper, ok := proc.(*LineProcessorWrapper)
+	if !ok || hits == nil {
+		return proc.FindResultsInFileChange(job)
+	}
+
+	lineNums, filtered := hits.Lines(proc.GetName())
+	if !filtered {
+		return proc.FindResultsInFileChange(job)
+	}
+	if len(lineNums) == 0 {
+		return
+	}
+
+	return wrapper.FindResultsInLines(job, lineNums)
+}
+
+func acceptsBinary(proc contract.ProcessorI, fileChange *gitpkg.FileChange) bool {
+	binaryProc, ok := proc.(contract.AcceptsBinaryI)
+	return ok && binaryProc.AcceptsBinaryFileChange(fileChange)
+}
+
+func runsUncached(proc contract.ProcessorI) bool {
+	uncachedProc, ok := proc.(contract.RunsUncachedI)
+	return ok && uncachedProc.RunsUncached()
+}
+
+func (w *Worker) eachFileChangeInCommit(job contract.WorkerJobI, commit *gitpkg.Commit, fn func(change *gitpkg.FileChange) error) (err error) {
 
 	// The git.Change.Patch() function is too panicky so we'll just log it here
 	defer errors.CatchPanicAndLogWarning(job.Log(w.log), "got a panic while getting file changes, probably from git.Change.Patch()")
 
-	result, err = commit.FileChanges(w.fileChangeFilter)
+	err = commit.EachFileChange(w.fileChangeFilter, fn)
 
 	return
 }
@@ -1,6 +1,8 @@
 package search
 
 import (
+	"time"
+
 	"github.com/pantheon-systems/secrets-searcher/pkg/database"
 	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
 	gitpkg "github.com/pantheon-systems/secrets-searcher/pkg/git"
@@ -9,7 +11,12 @@ import (
 	"github.com/pantheon-systems/secrets-searcher/pkg/search/contract"
 )
 
-const contextLenLimit = 50
+const (
+	contextLenLimit = 50
+
+	// There's no code to show for binary files
+	binaryCode = "(binary file)"
+)
 
 type dbResultWriter struct {
 	secretTracker manip.Set
@@ -72,6 +79,18 @@ func (w *dbResultWriter) WriteResult(result *contract.JobResult) (err error) {
 	return
 }
 
+// Records that the job was completed, so a resumed search can skip it
+func (w *dbResultWriter) WriteCheckpoint(job *Job) (err error) {
+	return w.db.WriteCheckpoint(&database.Checkpoint{
+		ID:          job.CheckpointID(),
+		RepoID:      job.repoID,
+		RepoName:    job.repoName,
+		JobName:     job.name,
+		CommitCount: len(job.commitHashes),
+		Date:        time.Now(),
+	})
+}
+
 func (w *dbResultWriter) buildDBObjects(jobResult *contract.JobResult) (dbCommit *database.Commit, dbFinding *database.Finding, dbSecret *database.Secret, dbSecretExtras database.SecretExtras, dbFindingExtras database.FindingExtras, err error) {
 
 	// Commit
@@ -127,17 +146,29 @@ func (w *dbResultWriter) buildDBFinding(jobResult *contract.JobResult, secretID,
 		return
 	}
 
-	codeLineRange := manip.NewLineRangeFromFileRange(jobResult.FileRange, fileContents)
-
-	// Code context
-	var contextLineRange *manip.LineRange
-	if jobResult.ContextFileRange != nil {
-		contextLineRange = manip.NewLineRangeFromFileRange(jobResult.ContextFileRange, fileContents)
+	var beforeCode, code, afterCode string
+	var section string
+	var sectionLineNum int
+	switch {
+	case jobResult.FileChange.IsBinaryOrEmpty:
+		code = binaryCode
+	case jobResult.Section != nil:
+		section = jobResult.Section.Name
+		sectionLineNum = jobResult.Section.FileRange.StartLineNum
+		beforeCode, code, afterCode = sectionCode(jobResult.Section)
+	default:
+		codeLineRange := manip.NewLineRangeFromFileRange(jobResult.FileRange, fileContents)
+
+		// Code context
+		var contextLineRange *manip.LineRange
+		if jobResult.ContextFileRange != nil {
+			contextLineRange = manip.NewLineRangeFromFileRange(jobResult.ContextFileRange, fileContents)
+		}
+		beforeCodeValue, afterCodeValue := manip.CodeContext(fileContents, codeLineRange, contextLineRange, contextLenLimit)
+		beforeCode = beforeCodeValue.ExtractValue(fileContents).Value
+		afterCode = afterCodeValue.ExtractValue(fileContents).Value
+		code = codeLineRange.ExtractValue(fileContents).Value
 	}
-	beforeCodeValue, afterCodeValue := manip.CodeContext(fileContents, codeLineRange, contextLineRange, contextLenLimit)
-	beforeCode := beforeCodeValue.ExtractValue(fileContents).Value
-	afterCode := afterCodeValue.ExtractValue(fileContents).Value
-	code := codeLineRange.ExtractValue(fileContents).Value
 
 	result = &database.Finding{
 		ID: database.CreateHashID(
@@ -149,23 +180,37 @@ func (w *dbResultWriter) buildDBFinding(jobResult *contract.JobResult, secretID,
 			jobResult.FileRange.EndLineNum,
 			jobResult.FileRange.EndIndex,
 		),
-		CommitID:     commitID,
-		SecretID:     secretID,
-		Processor:    jobResult.Processor.GetName(),
-		Path:         jobResult.FileChange.Path,
-		StartLineNum: jobResult.FileRange.StartLineNum,
-		StartIndex:   jobResult.FileRange.StartIndex,
-		EndLineNum:   jobResult.FileRange.EndLineNum,
-		EndIndex:     jobResult.FileRange.EndIndex,
-		BeforeCode:   beforeCode,
-		Code:         code,
-		AfterCode:    afterCode,
-		FileBasename: jobResult.FileBaseName,
+		CommitID:       commitID,
+		SecretID:       secretID,
+		Processor:      jobResult.Processor.GetName(),
+		Path:           jobResult.FileChange.Path,
+		StartLineNum:   jobResult.FileRange.StartLineNum,
+		StartIndex:     jobResult.FileRange.StartIndex,
+		EndLineNum:     jobResult.FileRange.EndLineNum,
+		EndIndex:       jobResult.FileRange.EndIndex,
+		BeforeCode:     beforeCode,
+		Code:           code,
+		AfterCode:      afterCode,
+		FileBasename:   jobResult.FileBaseName,
+		Section:        section,
+		SectionLineNum: sectionLineNum,
 	}
 
 	return
 }
 
+// The whole section is the code context
+func sectionCode(section *contract.ResultSection) (beforeCode, code, afterCode string) {
+	contents := section.Contents
+	codeLineRange := manip.NewLineRangeFromFileRange(section.FileRange, contents)
+
+	beforeCode = contents[:codeLineRange.StartIndex]
+	code = codeLineRange.ExtractValue(contents).Value
+	afterCode = contents[codeLineRange.EndIndex:]
+
+	return
+}
+
 func (w *dbResultWriter) buildDBSecretExtra(extra *contract.ResultExtra, secretID, findingID string, order int) *database.SecretExtra {
 	return &database.SecretExtra{
 		ID:        database.CreateHashID(secretID, extra.Key, order),
@@ -0,0 +1,42 @@
+package search_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/app/build"
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/search"
+	"github.com/stretchr/testify/require"
+)
+
+// Writes the results of searching the synthetic repo to an empty database
+func BenchmarkDBResultWriter_WriteResult(b *testing.B) {
+	fixture := newSyntheticFixture(b, benchmarkRepoConfig)
+	defer os.RemoveAll(fixture.dir)
+
+	targets, err := build.Targets(fixture.cfg)
+	require.NoError(b, err)
+	processors, err := build.Procs(fixture.cfg, targets, fixture.db, fixture.log)
+	require.NoError(b, err)
+	results := fixture.search(b, NewWorker(processors, nil, nil, nil, nil, nil, fixture.log))
+	require.NotEmpty(b, results)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		db, err := database.New(filepath.Join(fixture.dir, "write-db-"+strconv.Itoa(i)), fixture.log)
+		require.NoError(b, err)
+		writer := NewDBResultWriter(db, fixture.log)
+		b.StartTimer()
+
+		for _, result := range results {
+			require.NoError(b, writer.WriteResult(result))
+		}
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(len(results)), "results")
+}
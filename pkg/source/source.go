@@ -16,15 +16,16 @@ import (
 
 type (
 	Source struct {
-		sourceDir   string
-		skipFetch   bool
-		workerCount int
-		repoFilter  *manip.SliceFilter
-		git         *gitpkg.Git
-		provider    ProviderI
-		interact    *interactpkg.Interact
-		db          *database.Database
-		log         logg.Logg
+		sourceDir    string
+		skipFetch    bool
+		fetchOptions *gitpkg.FetchOptions
+		workerCount  int
+		repoFilter   *manip.SliceFilter
+		git          *gitpkg.Git
+		provider     ProviderI
+		interact     *interactpkg.Interact
+		db           *database.Database
+		log          logg.Logg
 	}
 	ProviderI interface {
 		manip.Named
@@ -44,6 +45,7 @@ type (
 func New(
 	sourceDir string,
 	skipFetch bool,
+	fetchOptions *gitpkg.FetchOptions,
 	workerCount int,
 	repoFilter *manip.SliceFilter,
 	git *gitpkg.Git,
@@ -53,15 +55,16 @@ func New(
 	log logg.Logg,
 ) *Source {
 	return &Source{
-		sourceDir:   sourceDir,
-		skipFetch:   skipFetch,
-		workerCount: workerCount,
-		repoFilter:  repoFilter,
-		git:         git,
-		provider:    provider,
-		interact:    interact,
-		db:          db,
-		log:         log,
+		sourceDir:    sourceDir,
+		skipFetch:    skipFetch,
+		fetchOptions: fetchOptions,
+		workerCount:  workerCount,
+		repoFilter:   repoFilter,
+		git:          git,
+		provider:     provider,
+		interact:     interact,
+		db:           db,
+		log:          log,
 	}
 }
 
@@ -96,7 +99,7 @@ func (s *Source) cloneRepos(repoInfos []*RepoInfo) {
 		log := s.log.WithField("repo", repoInfo.Name)
 		cloneDir := filepath.Join(s.sourceDir, repoInfo.Name)
 
-		p.Add(newCloneWorker(repoInfo, cloneDir, s.skipFetch, s.git, prog, s.db, log))
+		p.Add(newCloneWorker(repoInfo, cloneDir, s.skipFetch, s.fetchOptions, s.git, prog, s.db, log))
 	}
 
 	p.Close()
@@ -15,26 +15,28 @@ import (
 )
 
 type cloneWorker struct {
-	repoInfo  *RepoInfo
-	cloneDir  string
-	skipFetch bool
-	git       *gitpkg.Git
-	prog      *progress.Progress
-	db        *database.Database
-	log       logg.Logg
+	repoInfo     *RepoInfo
+	cloneDir     string
+	skipFetch    bool
+	fetchOptions *gitpkg.FetchOptions
+	git          *gitpkg.Git
+	prog         *progress.Progress
+	db           *database.Database
+	log          logg.Logg
 }
 
-func newCloneWorker(repoInfo *RepoInfo, cloneDir string, skipFetch bool, git *gitpkg.Git, prog *progress.Progress,
-	db *database.Database, log logg.Logg) *cloneWorker {
+func newCloneWorker(repoInfo *RepoInfo, cloneDir string, skipFetch bool, fetchOptions *gitpkg.FetchOptions, git *gitpkg.Git,
+	prog *progress.Progress, db *database.Database, log logg.Logg) *cloneWorker {
 
 	return &cloneWorker{
-		repoInfo:  repoInfo,
-		cloneDir:  cloneDir,
-		skipFetch: skipFetch,
-		git:       git,
-		prog:      prog,
-		db:        db,
-		log:       log,
+		repoInfo:     repoInfo,
+		cloneDir:     cloneDir,
+		skipFetch:    skipFetch,
+		fetchOptions: fetchOptions,
+		git:          git,
+		prog:         prog,
+		db:           db,
+		log:          log,
 	}
 }
 
@@ -89,10 +91,12 @@ func (w *cloneWorker) prepareRepo() (err error) {
 		}
 	}
 
+	// Credentials in the URL aren't saved
+	remoteURL := gitpkg.RedactURL(w.repoInfo.RemoteURL)
 	err = w.db.WriteRepo(&database.Repo{
-		ID:        database.CreateHashID(w.repoInfo.RemoteURL),
+		ID:        database.CreateHashID(remoteURL),
 		Name:      w.repoInfo.Name,
-		RemoteURL: w.repoInfo.RemoteURL,
+		RemoteURL: remoteURL,
 	})
 	if err != nil {
 		err = errors.WithMessagev(err, "unable to write repo", w.repoInfo.Name)
@@ -134,7 +138,7 @@ func (w *cloneWorker) cloneRepo() (err error) {
 	}
 
 	// Clone to tmp dir
-	if _, err = w.git.Clone(w.repoInfo.RemoteURL, cloneDirTmp); err != nil {
+	if _, err = w.git.Clone(w.repoInfo.RemoteURL, cloneDirTmp, w.fetchOptions); err != nil {
 		err = errors.Wrapv(err, "unable to clone", cloneDirTmp)
 		_ = os.RemoveAll(cloneDirTmp)
 		return
@@ -158,7 +162,7 @@ func (w *cloneWorker) fetchRepo() (err error) {
 		return
 	}
 
-	if err = repository.FetchAll(w.repoInfo.RemoteURL); err != nil {
+	if err = repository.FetchAll(w.repoInfo.RemoteURL, w.fetchOptions); err != nil {
 		err = errors.Wrapv(err, "unable to fetch all from repository", w.cloneDir)
 	}
 
@@ -18,6 +18,7 @@ type (
 		CommitDurations      DurationStats
 		FileChangeDurations  DurationStats
 		FileTypeDurations    DurationStats
+		SkipCounts           SkipCounts
 	}
 	DurationStats struct {
 		stats []*DurationStat
@@ -27,6 +28,16 @@ type (
 		Item string
 		Dur  time.Duration
 	}
+
+	// File changes that weren't searched completely, by reason
+	SkipCounts struct {
+		counts map[string]int64
+		mu     sync.Mutex
+	}
+	SkipCount struct {
+		Reason string
+		Count  int64
+	}
 )
 
 func New() *Stats {
@@ -35,6 +46,7 @@ func New() *Stats {
 		CommitDurations:     NewUniqueDurationStats(),
 		FileChangeDurations: NewUniqueDurationStats(),
 		FileTypeDurations:   NewAggregatedDurationStats(),
+		SkipCounts:          SkipCounts{counts: map[string]int64{}},
 	}
 }
 
@@ -106,3 +118,25 @@ func (ss *DurationStats) SubmitAggregatedDuration(dur time.Duration, item string
 
 	ss.stats = append(ss.stats, &DurationStat{Item: item, Dur: dur})
 }
+
+//
+// SkipCounts
+
+func (sc *SkipCounts) Add(reason string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	sc.counts[reason]++
+}
+
+func (sc *SkipCounts) Counts() (result []*SkipCount) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	for reason, count := range sc.counts {
+		result = append(result, &SkipCount{Reason: reason, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Reason < result[j].Reason })
+
+	return
+}
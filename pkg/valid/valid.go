@@ -26,8 +26,10 @@ var (
 	ErrEmptyString        = va.NewError("valid_is_empty_string", "must be empty")
 	ErrExistingFile       = va.NewError("valid_is_existing_file", "file does not exist")
 	ErrExistingDir        = va.NewError("valid_is_existing_dir", "directory does not exist")
+	ErrExistingPath       = va.NewError("valid_is_existing_path", "file or directory does not exist")
 	ErrZero               = va.NewError("valid_is_zero", "must be empty")
 	ErrBeforeTimeParam    = va.NewError("valid_before_time_param", "must not come before {{.param}}")
+	ErrNotAfterTimeParam  = va.NewError("valid_not_after_time_param", "must not come after {{.param}}")
 	ErrPathNotWithinParam = va.NewError("valid_not_within_dir", "must not be within {{.param}}")
 	ErrRegexpPattern      = va.NewError("valid_regex", "must be a valid regular expression")
 )
@@ -112,12 +114,35 @@ func BeforeTime(param *manip.Param) ParamRule {
 type IsBeforeTimeParamRule struct{}
 
 func (r IsBeforeTimeParamRule) Validate(param *manip.Param, value interface{}) (err error) {
-	if !value.(*time.Time).Before(*param.LeafFieldValue().(*time.Time)) {
+	if !timeValue(value).Before(timeValue(param.LeafFieldValue())) {
 		return setDotParam(ErrBeforeTimeParam, r)
 	}
 	return
 }
 
+// NotAfterTime
+
+func NotAfterTime(param *manip.Param) ParamRule {
+	return NewParamRule(param, IsNotAfterTimeParamRule{})
+}
+
+type IsNotAfterTimeParamRule struct{}
+
+func (r IsNotAfterTimeParamRule) Validate(param *manip.Param, value interface{}) (err error) {
+	if timeValue(value).After(timeValue(param.LeafFieldValue())) {
+		return setDotParam(ErrNotAfterTimeParam, r)
+	}
+	return
+}
+
+// Params and ozzo pass times either by value or by pointer
+func timeValue(value interface{}) time.Time {
+	if ptr, ok := value.(*time.Time); ok {
+		return *ptr
+	}
+	return value.(time.Time)
+}
+
 // PathNotWithinParam
 
 func PathNotWithinParam(param *manip.Param) IsPathNotWithinParamRule {
@@ -170,6 +195,13 @@ var ExistingDir = va.NewStringRuleWithError(func(value string) bool {
 	return !os.IsNotExist(err) && fileInfo.Mode().IsDir()
 }, ErrExistingDir)
 
+// ExistingPath
+
+var ExistingPath = va.NewStringRuleWithError(func(value string) bool {
+	_, err := os.Stat(value)
+	return err == nil
+}, ErrExistingPath)
+
 // Zero
 
 var Zero = newRuleWithError(IsZero, ErrZero)
@@ -203,8 +235,10 @@ var RegexpTmpl = va.By(func(value interface{}) (err error) {
 //
 // Validators
 
+// Pointers are zero when nil or when they point to a zero value
 func IsZero(value interface{}) bool {
-	return value == reflect.Zero(reflect.TypeOf(value)).Interface()
+	v := reflect.Indirect(reflect.ValueOf(value))
+	return !v.IsValid() || v.IsZero()
 }
 
 //
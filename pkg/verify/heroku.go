@@ -0,0 +1,64 @@
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+)
+
+// The secret value is the whole match, like `HEROKU_API_KEY=<key>`, so the key is the last UUID in it
+var herokuAPIKeyRe = regexp.MustCompile(`[0-9A-F]{8}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{12}`)
+
+// Calls https://devcenter.heroku.com/articles/platform-api-reference#account-info
+type HerokuVerifier struct {
+	httpVerifier
+}
+
+func (v *HerokuVerifier) Verify(ctx context.Context, req *Request) (result *Result, err error) {
+	keys := herokuAPIKeyRe.FindAllString(req.SecretValue, -1)
+	if len(keys) == 0 {
+		result = unknownResult("no API key in secret value")
+		return
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+keys[len(keys)-1])
+	header.Set("Accept", "application/vnd.heroku+json; version=3")
+
+	var resp *http.Response
+	var body []byte
+	resp, body, err = v.do(ctx, http.MethodGet, "/account", header, nil)
+	if err != nil {
+		return
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		result = &Result{Status: Invalid}
+		return
+	default:
+		result = unknownResult(fmt.Sprintf("unexpected status %d", resp.StatusCode))
+		return
+	}
+
+	var account struct {
+		Email string `json:"email"`
+		ID    string `json:"id"`
+	}
+	if err = json.Unmarshal(body, &account); err != nil {
+		err = errors.Wrap(err, "unable to parse account response")
+		return
+	}
+
+	result = &Result{
+		Status:   Valid,
+		Metadata: []*Metadata{{Key: "email", Header: "Heroku account", Value: account.Email}},
+	}
+
+	return
+}
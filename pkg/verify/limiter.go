@@ -0,0 +1,57 @@
+package verify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Spaces calls out evenly so we don't hammer the APIs we verify against
+type RateLimiter struct {
+	interval time.Duration
+	next     time.Time
+	mutex    *sync.Mutex
+}
+
+// A rate of zero or less means no limit
+func NewRateLimiter(perSecond float64) *RateLimiter {
+	var interval time.Duration
+	if perSecond > 0 {
+		interval = time.Duration(float64(time.Second) / perSecond)
+	}
+
+	return &RateLimiter{
+		interval: interval,
+		mutex:    &sync.Mutex{},
+	}
+}
+
+func (l *RateLimiter) Wait(ctx context.Context) (err error) {
+	if l.interval == 0 {
+		return
+	}
+
+	l.mutex.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mutex.Unlock()
+
+	if wait == 0 {
+		return
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	return
+}
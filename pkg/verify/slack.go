@@ -0,0 +1,73 @@
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+)
+
+var slackInvalidErrors = []string{"invalid_auth", "not_authed", "account_inactive", "token_revoked", "token_expired"}
+
+// Calls https://api.slack.com/methods/auth.test
+type SlackVerifier struct {
+	httpVerifier
+}
+
+func (v *SlackVerifier) Verify(ctx context.Context, req *Request) (result *Result, err error) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+req.SecretValue)
+	header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var resp *http.Response
+	var body []byte
+	resp, body, err = v.do(ctx, http.MethodPost, "/api/auth.test", header, nil)
+	if err != nil {
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		result = unknownResult(fmt.Sprintf("unexpected status %d", resp.StatusCode))
+		return
+	}
+
+	var authTest struct {
+		OK     bool   `json:"ok"`
+		Error  string `json:"error"`
+		URL    string `json:"url"`
+		Team   string `json:"team"`
+		User   string `json:"user"`
+		UserID string `json:"user_id"`
+	}
+	if err = json.Unmarshal(body, &authTest); err != nil {
+		err = errors.Wrap(err, "unable to parse auth.test response")
+		return
+	}
+
+	if authTest.OK {
+		result = &Result{
+			Status: Valid,
+			Metadata: []*Metadata{
+				{Key: "team", Header: "Slack team", Value: authTest.Team},
+				{Key: "user", Header: "Slack user", Value: authTest.User},
+				{Key: "url", Header: "Slack URL", Value: authTest.URL},
+			},
+		}
+		return
+	}
+
+	for _, invalidError := range slackInvalidErrors {
+		if authTest.Error == invalidError {
+			result = &Result{
+				Status:   Invalid,
+				Metadata: []*Metadata{{Key: "reason", Header: "Reason", Value: authTest.Error}},
+			}
+			return
+		}
+	}
+
+	result = unknownResult(authTest.Error)
+
+	return
+}
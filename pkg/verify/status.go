@@ -0,0 +1,34 @@
+package verify
+
+//go:generate stringer -type Status
+
+import "strings"
+
+type Status int
+
+const (
+	Unknown Status = iota
+	Valid
+	Invalid
+)
+
+func Statuses() []Status {
+	return []Status{
+		Unknown,
+		Valid,
+		Invalid,
+	}
+}
+
+func (i Status) Value() string {
+	return strings.ToLower(i.String())
+}
+
+func NewStatusFromValue(val string) Status {
+	for _, e := range Statuses() {
+		if e.Value() == val {
+			return e
+		}
+	}
+	panic("unknown verification status: " + val)
+}
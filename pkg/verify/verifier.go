@@ -0,0 +1,116 @@
+package verify
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+)
+
+// Responses bigger than this are truncated before being parsed
+const maxResponseLen = 1 << 20
+
+type (
+	VerifierI interface {
+		GetName() string
+		Verify(ctx context.Context, req *Request) (result *Result, err error)
+	}
+
+	Request struct {
+		SecretValue string
+
+		// Secret and finding extra values by key, e.g. "setter-key-value"
+		Extras map[string]string
+	}
+
+	Result struct {
+		Status   Status
+		Metadata []*Metadata
+	}
+
+	Metadata struct {
+		Key    string
+		Header string
+		Value  string
+	}
+
+	// Base for verifiers that call an HTTP API
+	httpVerifier struct {
+		name    string
+		baseURL string
+		client  *http.Client
+	}
+)
+
+func NewVerifier(name VerifierName, baseURL string, client *http.Client) (result VerifierI) {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL(name)
+	}
+	base := httpVerifier{
+		name:    name.Value(),
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+	}
+
+	switch name {
+	case Slack:
+		return &SlackVerifier{base}
+	case Heroku:
+		return &HerokuVerifier{base}
+	default:
+		panic("unknown verifier: " + name.String())
+	}
+}
+
+func DefaultBaseURL(name VerifierName) string {
+	switch name {
+	case Slack:
+		return "https://slack.com"
+	case Heroku:
+		return "https://api.heroku.com"
+	default:
+		panic("unknown verifier: " + name.String())
+	}
+}
+
+func (v *httpVerifier) GetName() string {
+	return v.name
+}
+
+func (v *httpVerifier) do(ctx context.Context, method, path string, header http.Header, body io.Reader) (resp *http.Response, respBody []byte, err error) {
+	var req *http.Request
+	req, err = http.NewRequest(method, v.baseURL+path, body)
+	if err != nil {
+		err = errors.Wrapv(err, "unable to build request", method, path)
+		return
+	}
+	req = req.WithContext(ctx)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err = v.client.Do(req)
+	if err != nil {
+		// Never include the request, since it contains the credential
+		err = errors.Wrapv(err, "request failed", method, path)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err = ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseLen))
+	if err != nil {
+		err = errors.Wrapv(err, "unable to read response", method, path)
+	}
+
+	return
+}
+
+func unknownResult(reason string) *Result {
+	return &Result{
+		Status:   Unknown,
+		Metadata: []*Metadata{{Key: "reason", Header: "Reason", Value: reason}},
+	}
+}
@@ -0,0 +1,41 @@
+package verify
+
+//go:generate stringer -type VerifierName
+
+import "strings"
+
+type VerifierName int
+
+const (
+	Slack VerifierName = iota
+	Heroku
+)
+
+func VerifierNames() []VerifierName {
+	return []VerifierName{
+		Slack,
+		Heroku,
+	}
+}
+
+func (i VerifierName) Value() string {
+	return strings.ToLower(i.String())
+}
+
+func NewVerifierNameFromValue(val string) VerifierName {
+	for _, e := range VerifierNames() {
+		if e.Value() == val {
+			return e
+		}
+	}
+	panic("unknown verifier: " + val)
+}
+
+func ValidVerifierNameValues() (result []string) {
+	names := VerifierNames()
+	result = make([]string, len(names))
+	for i := range names {
+		result[i] = names[i].Value()
+	}
+	return
+}
@@ -0,0 +1,174 @@
+package verify
+
+import (
+	"context"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/errors"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+)
+
+const (
+	StatusExtraKey   = "verification-status"
+	VerifierExtraKey = "verification-verifier"
+
+	// Verification extras come after the ones the processors add
+	extraOrderStart = 1000
+)
+
+type Verify struct {
+	enabled       bool
+	verifiers     map[string]VerifierI
+	procVerifiers map[string]string
+	limiter       *RateLimiter
+	timeout       time.Duration
+	db            *database.Database
+	log           logg.Logg
+}
+
+// procVerifiers maps processor names to the names of the verifiers they opted into
+func New(enabled bool, verifiers map[string]VerifierI, procVerifiers map[string]string, limiter *RateLimiter, timeout time.Duration, db *database.Database, log logg.Logg) *Verify {
+	return &Verify{
+		enabled:       enabled,
+		verifiers:     verifiers,
+		procVerifiers: procVerifiers,
+		limiter:       limiter,
+		timeout:       timeout,
+		db:            db,
+		log:           log,
+	}
+}
+
+func (v *Verify) VerifySecrets(ctx context.Context) (err error) {
+	if !v.enabled {
+		return
+	}
+
+	v.log.Info("verifying secrets ...")
+
+	var reportData *database.ReportData
+	reportData, err = v.db.GetBaseReportData()
+	if err != nil {
+		err = errors.WithMessage(err, "unable to get secrets")
+		return
+	}
+
+	findingsBySecretID := map[string]database.Findings{}
+	for _, finding := range reportData.Findings {
+		findingsBySecretID[finding.SecretID] = append(findingsBySecretID[finding.SecretID], finding)
+	}
+	findingExtrasByFindingID := database.FindingExtraGroups{}
+	for _, extra := range reportData.FindingExtras {
+		findingExtrasByFindingID[extra.FindingID] = append(findingExtrasByFindingID[extra.FindingID], extra)
+	}
+	secretExtrasBySecretID := database.SecretExtraGroups{}
+	for _, extra := range reportData.SecretExtras {
+		secretExtrasBySecretID[extra.SecretID] = append(secretExtrasBySecretID[extra.SecretID], extra)
+	}
+
+	var verifiedCount int
+	for _, secret := range reportData.Secrets {
+		verifier, finding := v.verifierForFindings(findingsBySecretID[secret.ID])
+		if verifier == nil {
+			continue
+		}
+
+		req := &Request{
+			SecretValue: secret.Value,
+			Extras:      map[string]string{},
+		}
+		for _, extra := range secretExtrasBySecretID[secret.ID] {
+			req.Extras[extra.Key] = extra.Value
+		}
+		for _, extra := range findingExtrasByFindingID[finding.ID] {
+			req.Extras[extra.Key] = extra.Value
+		}
+
+		var result *Result
+		if result, err = v.verifySecret(ctx, verifier, req); err != nil {
+			return
+		}
+
+		if err = v.writeResult(secret, finding, verifier, result); err != nil {
+			err = errors.WithMessagev(err, "unable to write verification result", secret.ID)
+			return
+		}
+		verifiedCount++
+	}
+
+	v.log.Infof("verified %d secrets", verifiedCount)
+
+	return
+}
+
+func (v *Verify) verifierForFindings(findings database.Findings) (verifier VerifierI, finding *database.Finding) {
+	for _, finding = range findings {
+		verifierName, ok := v.procVerifiers[finding.Processor]
+		if !ok {
+			continue
+		}
+		if verifier, ok = v.verifiers[verifierName]; ok {
+			return
+		}
+	}
+
+	return nil, nil
+}
+
+// Verifier errors don't stop the run, they just make the result unknown.
+// Only cancellation of the parent context does.
+func (v *Verify) verifySecret(ctx context.Context, verifier VerifierI, req *Request) (result *Result, err error) {
+	if err = v.limiter.Wait(ctx); err != nil {
+		err = errors.Wrap(err, "verification interrupted")
+		return
+	}
+
+	verifyCtx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	var verifyErr error
+	result, verifyErr = verifier.Verify(verifyCtx, req)
+	if verifyErr != nil {
+		if ctx.Err() != nil {
+			err = errors.Wrap(ctx.Err(), "verification interrupted")
+			return
+		}
+		v.log.WithError(verifyErr).Warnf("unable to verify secret with %s verifier", verifier.GetName())
+		result = unknownResult(verifyErr.Error())
+	}
+
+	return
+}
+
+func (v *Verify) writeResult(secret *database.Secret, finding *database.Finding, verifier VerifierI, result *Result) (err error) {
+	extras := []*Metadata{
+		{Key: StatusExtraKey, Header: "Verification", Value: result.Status.Value()},
+		{Key: VerifierExtraKey, Header: "Verified with", Value: verifier.GetName()},
+	}
+	for _, metadata := range result.Metadata {
+		extras = append(extras, &Metadata{
+			Key:    "verification-" + metadata.Key,
+			Header: metadata.Header,
+			Value:  metadata.Value,
+		})
+	}
+
+	for i, extra := range extras {
+		order := extraOrderStart + i
+		err = v.db.WriteSecretExtra(&database.SecretExtra{
+			ID:        database.CreateHashID(secret.ID, extra.Key, order),
+			SecretID:  secret.ID,
+			FindingID: finding.ID,
+			Order:     order,
+			Key:       extra.Key,
+			Header:    extra.Header,
+			Value:     extra.Value,
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
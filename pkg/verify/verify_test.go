@@ -0,0 +1,191 @@
+package verify_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pantheon-systems/secrets-searcher/pkg/database"
+	"github.com/pantheon-systems/secrets-searcher/pkg/logg"
+	. "github.com/pantheon-systems/secrets-searcher/pkg/verify"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	liveToken = "xoxb-live"
+	deadToken = "xoxb-dead"
+)
+
+var log = logg.NewLogrusLogg(logrus.New())
+
+func slackStandIn() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/auth.test" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Header.Get("Authorization") {
+		case "Bearer " + liveToken:
+			_, _ = w.Write([]byte(`{"ok":true,"team":"Acme","user":"bot","url":"https://acme.slack.com/"}`))
+		case "Bearer " + deadToken:
+			_, _ = w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+		default:
+			_, _ = w.Write([]byte(`{"ok":false,"error":"ratelimited"}`))
+		}
+	}))
+}
+
+func TestSlackVerifier_Valid(t *testing.T) {
+	server := slackStandIn()
+	defer server.Close()
+	subject := NewVerifier(Slack, server.URL, server.Client())
+
+	// Fire
+	result, err := subject.Verify(context.Background(), &Request{SecretValue: liveToken})
+
+	require.NoError(t, err)
+	require.Equal(t, Valid, result.Status)
+	require.Equal(t, "Acme", result.Metadata[0].Value)
+}
+
+func TestSlackVerifier_Invalid(t *testing.T) {
+	server := slackStandIn()
+	defer server.Close()
+	subject := NewVerifier(Slack, server.URL, server.Client())
+
+	// Fire
+	result, err := subject.Verify(context.Background(), &Request{SecretValue: deadToken})
+
+	require.NoError(t, err)
+	require.Equal(t, Invalid, result.Status)
+}
+
+func TestSlackVerifier_Unknown(t *testing.T) {
+	server := slackStandIn()
+	defer server.Close()
+	subject := NewVerifier(Slack, server.URL, server.Client())
+
+	// Fire
+	result, err := subject.Verify(context.Background(), &Request{SecretValue: "xoxb-other"})
+
+	require.NoError(t, err)
+	require.Equal(t, Unknown, result.Status)
+}
+
+func TestHerokuVerifier_Valid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer 01234567-89AB-CDEF-0123-456789ABCDEF" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"email":"me@example.com","id":"account-id"}`))
+	}))
+	defer server.Close()
+	subject := NewVerifier(Heroku, server.URL, server.Client())
+
+	// Fire
+	result, err := subject.Verify(context.Background(), &Request{SecretValue: `HEROKU_API_KEY = "01234567-89AB-CDEF-0123-456789ABCDEF"`})
+
+	require.NoError(t, err)
+	require.Equal(t, Valid, result.Status)
+	require.Equal(t, "me@example.com", result.Metadata[0].Value)
+}
+
+func TestHerokuVerifier_Invalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	subject := NewVerifier(Heroku, server.URL, server.Client())
+
+	// Fire
+	result, err := subject.Verify(context.Background(), &Request{SecretValue: "01234567-89AB-CDEF-0123-456789ABCDEF"})
+
+	require.NoError(t, err)
+	require.Equal(t, Invalid, result.Status)
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	subject := NewRateLimiter(50)
+	start := time.Now()
+
+	// Fire
+	for i := 0; i < 4; i++ {
+		require.NoError(t, subject.Wait(context.Background()))
+	}
+
+	require.True(t, time.Since(start) >= 60*time.Millisecond)
+}
+
+func TestVerify_VerifySecrets(t *testing.T) {
+	server := slackStandIn()
+	defer server.Close()
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+
+	// Only the processor that opted in gets verified
+	writeTestSecret(t, db, liveToken, "SlackTokenRegex")
+	writeTestSecret(t, db, deadToken, "SlackTokenRegex")
+	writeTestSecret(t, db, "xoxb-skipped", "GenericSecretRegex")
+
+	verifiers := map[string]VerifierI{Slack.Value(): NewVerifier(Slack, server.URL, server.Client())}
+	procVerifiers := map[string]string{"SlackTokenRegex": Slack.Value()}
+	subject := New(true, verifiers, procVerifiers, NewRateLimiter(0), time.Second, db, log)
+
+	// Fire
+	err := subject.VerifySecrets(context.Background())
+
+	require.NoError(t, err)
+	statuses := map[string]string{}
+	extras, err := db.GetSecretExtras()
+	require.NoError(t, err)
+	for _, extra := range extras {
+		if extra.Key == StatusExtraKey {
+			statuses[extra.SecretID] = extra.Value
+		}
+	}
+	require.Equal(t, map[string]string{
+		database.CreateHashID(liveToken): Valid.Value(),
+		database.CreateHashID(deadToken): Invalid.Value(),
+	}, statuses)
+}
+
+func TestVerify_VerifySecrets_Disabled(t *testing.T) {
+	db, cleanup := newTestDB(t)
+	defer cleanup()
+	writeTestSecret(t, db, liveToken, "SlackTokenRegex")
+	subject := New(false, nil, nil, NewRateLimiter(0), time.Second, db, log)
+
+	// Fire
+	err := subject.VerifySecrets(context.Background())
+
+	require.NoError(t, err)
+	require.False(t, db.SecretExtraTableExists())
+}
+
+func newTestDB(t *testing.T) (db *database.Database, cleanup func()) {
+	dir, err := ioutil.TempDir("", "verify-test")
+	require.NoError(t, err)
+	cleanup = func() { _ = os.RemoveAll(dir) }
+
+	db, err = database.New(dir, log)
+	require.NoError(t, err)
+	require.NoError(t, db.PrepareFilesystemForWriting())
+
+	return
+}
+
+func writeTestSecret(t *testing.T, db *database.Database, value, processor string) {
+	secretID := database.CreateHashID(value)
+	require.NoError(t, db.WriteSecret(&database.Secret{ID: secretID, Value: value}))
+	require.NoError(t, db.WriteFinding(&database.Finding{
+		ID:        database.CreateHashID(secretID, processor),
+		SecretID:  secretID,
+		Processor: processor,
+	}))
+}